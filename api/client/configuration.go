@@ -13,6 +13,7 @@ type Configuration struct {
 	caravelaInstancePort int    // Port of the CARAVELA's Daemon that will receive the request.
 
 	httpRequestTimeout time.Duration // HTTP requests timeout.
+	authToken          string        // User's API token (empty if the daemon does not authenticate users).
 }
 
 // DefaultConfig creates a new configuration structure with the default values.
@@ -49,3 +50,13 @@ func (c *Configuration) RequestTimeout() time.Duration {
 func (c *Configuration) SetRequestTimeout(newReqTimeout time.Duration) {
 	c.httpRequestTimeout = newReqTimeout
 }
+
+// AuthToken returns the user's API token sent in the API requests.
+func (c *Configuration) AuthToken() string {
+	return c.authToken
+}
+
+// SetAuthToken sets the user's API token sent in the API requests.
+func (c *Configuration) SetAuthToken(token string) {
+	c.authToken = token
+}
@@ -1,6 +1,10 @@
 package client
 
-import "strings"
+import (
+	"errors"
+	"github.com/strabox/caravela/api/types"
+	"strings"
+)
 
 // CARAVELA's client error codes.
 const (
@@ -9,8 +13,19 @@ const (
 	// CaravelaInstanceUnavailableError is obtained when the client tries to send a request to a CARAVELA's
 	// instance that is not working e.g. because it is turned off.
 	CaravelaInstanceUnavailableError
+	// ImageRejectedError is obtained when the suppliers' image policies rejected the container's image, the
+	// error message contains the reason.
+	ImageRejectedError
+	// UnauthenticatedError is obtained when the CARAVELA's instance does not accept the user's API token.
+	UnauthenticatedError
+	// QuotaExceededError is obtained when the request exceeds the user's resources quota, the error message
+	// contains the limit exceeded.
+	QuotaExceededError
 )
 
+// errUnauthenticated is the internal error of the requests rejected due to the user's API token.
+var errUnauthenticated = errors.New("invalid API token")
+
 // Error represents the errors returned by the CARAVELA's client.
 // It implements the error interface.
 type Error struct {
@@ -24,7 +39,13 @@ func newClientError(err error) *Error {
 	res := &Error{
 		err: err,
 	}
-	if strings.Contains(err.Error(), "No connection") {
+	if _, ok := err.(*types.ImageRejectedError); ok {
+		res.Code = ImageRejectedError
+	} else if _, ok := err.(*types.QuotaExceededError); ok {
+		res.Code = QuotaExceededError
+	} else if err == errUnauthenticated {
+		res.Code = UnauthenticatedError
+	} else if strings.Contains(err.Error(), "No connection") {
 		res.Code = CaravelaInstanceUnavailableError
 	} else {
 		res.Code = UnknownError
@@ -7,11 +7,13 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"github.com/pkg/errors"
 	"github.com/strabox/caravela/api/rest/user"
 	"github.com/strabox/caravela/api/rest/util"
 	"github.com/strabox/caravela/api/types"
 	"net/http"
+	neturl "net/url"
 	"time"
 )
 
@@ -21,26 +23,46 @@ type Client struct {
 	config     *Configuration // Configuration parameters for the CARAVELA's client
 }
 
-// NewCaravelaIP creates a new client for a CARAVELA's daemon hosted in the given IP.
-func NewCaravelaIP(caravelaHostIP string) *Client {
-	config := DefaultConfig(caravelaHostIP)
-
+// NewCaravela creates a new client for a CARAVELA's daemon with the given configuration.
+func NewCaravela(config *Configuration) *Client {
 	return &Client{
 		config: config,
 		httpClient: &http.Client{
-			Timeout: config.RequestTimeout(),
+			Timeout:   config.RequestTimeout(),
+			Transport: &authTransport{token: config.AuthToken(), base: http.DefaultTransport},
 		},
 	}
+}
 
+// NewCaravelaIP creates a new client for a CARAVELA's daemon hosted in the given IP.
+func NewCaravelaIP(caravelaHostIP string) *Client {
+	return NewCaravela(DefaultConfig(caravelaHostIP))
 }
 
 func NewCaravelaTimeoutIP(caravelaHostIP string, requestTimeout time.Duration) *Client {
-	return &Client{
-		config: DefaultConfig(caravelaHostIP),
-		httpClient: &http.Client{
-			Timeout: requestTimeout,
-		},
+	config := DefaultConfig(caravelaHostIP)
+	config.SetRequestTimeout(requestTimeout)
+	return NewCaravela(config)
+}
+
+// authTransport adds the user's API token to the requests.
+type authTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (a *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if a.token == "" {
+		return a.base.RoundTrip(req)
+	}
+	authReq := new(http.Request)
+	*authReq = *req
+	authReq.Header = make(http.Header, len(req.Header)+1)
+	for key, values := range req.Header {
+		authReq.Header[key] = values
 	}
+	authReq.Header.Set("Authorization", "Bearer "+a.token)
+	return a.base.RoundTrip(authReq)
 }
 
 // SubmitContainers allows to submit a set of containers that you want to deploy in the CARAVELA's system.
@@ -49,13 +71,27 @@ func (c *Client) SubmitContainers(ctx context.Context, containersConfigs []types
 	url := util.BuildHttpURL(false, c.config.CaravelaInstanceIP(), c.config.CaravelaInstancePort(),
 		user.ContainerBaseEndpoint)
 
-	err, httpCode := util.DoHttpRequestJSON(ctx, c.httpClient, url, http.MethodPost, containersConfigs, nil)
+	body, err, httpCode := util.DoHttpRequestJSONBody(ctx, c.httpClient, url, http.MethodPost, containersConfigs)
 	if err != nil {
 		return newClientError(err)
 	}
 
 	if httpCode == http.StatusOK {
 		return nil
+	} else if httpCode == http.StatusUnauthorized {
+		return newClientError(errUnauthenticated)
+	} else if httpCode == http.StatusForbidden { // Suppliers' image policies rejected the image.
+		imageRejectedErr := &types.ImageRejectedError{}
+		if err := json.Unmarshal(body, imageRejectedErr); err != nil {
+			return newClientError(err)
+		}
+		return newClientError(imageRejectedErr)
+	} else if httpCode == http.StatusTooManyRequests { // User's quota exceeded.
+		quotaExceededErr := &types.QuotaExceededError{}
+		if err := json.Unmarshal(body, quotaExceededErr); err != nil {
+			return newClientError(err)
+		}
+		return newClientError(quotaExceededErr)
 	} else {
 		return newClientError(errors.New("impossible deploy the container"))
 	}
@@ -73,6 +109,8 @@ func (c *Client) StopContainers(ctx context.Context, containersIDs []string) *Er
 
 	if httpCode == http.StatusOK {
 		return nil
+	} else if httpCode == http.StatusUnauthorized {
+		return newClientError(errUnauthenticated)
 	} else {
 		return newClientError(errors.New("error stopping the containers"))
 	}
@@ -86,18 +124,182 @@ func (c *Client) ListContainers(ctx context.Context) ([]types.ContainerStatus, *
 	url := util.BuildHttpURL(false, c.config.CaravelaInstanceIP(), c.config.CaravelaInstancePort(),
 		user.ContainerBaseEndpoint)
 
-	err, httpCode := util.DoHttpRequestJSON(ctx, c.httpClient, url, http.MethodGet, nil, &containersList)
+	body, err, httpCode := util.DoHttpRequestJSONBody(ctx, c.httpClient, url, http.MethodGet, nil)
 	if err != nil {
 		return nil, newClientError(err)
 	}
 
 	if httpCode == http.StatusOK {
+		if err := json.Unmarshal(body, &containersList); err != nil {
+			return nil, newClientError(err)
+		}
 		return containersList, nil
+	} else if httpCode == http.StatusUnauthorized {
+		return nil, newClientError(errUnauthenticated)
 	} else {
 		return nil, newClientError(errors.New("error checking the container"))
 	}
 }
 
+// Quota returns the user's resources quota, with the current usage and the remaining resources in the
+// CARAVELA's system.
+func (c *Client) Quota(ctx context.Context) (*types.UserQuota, *Error) {
+	var userQuota types.UserQuota
+
+	url := util.BuildHttpURL(false, c.config.CaravelaInstanceIP(), c.config.CaravelaInstancePort(),
+		user.QuotaEndpoint)
+
+	body, err, httpCode := util.DoHttpRequestJSONBody(ctx, c.httpClient, url, http.MethodGet, nil)
+	if err != nil {
+		return nil, newClientError(err)
+	}
+
+	if httpCode == http.StatusOK {
+		if err := json.Unmarshal(body, &userQuota); err != nil {
+			return nil, newClientError(err)
+		}
+		return &userQuota, nil
+	} else if httpCode == http.StatusUnauthorized {
+		return nil, newClientError(errUnauthenticated)
+	} else {
+		return nil, newClientError(errors.New("error obtaining the quota"))
+	}
+}
+
+// Accounting returns the resources used, since the given time, by the containers that run in the CARAVELA's
+// instance as a supplier. Only the administrators obtain the usage of all the users.
+func (c *Client) Accounting(ctx context.Context, since time.Time) ([]types.UsageRecord, *Error) {
+	var records []types.UsageRecord
+
+	url := util.BuildHttpURL(false, c.config.CaravelaInstanceIP(), c.config.CaravelaInstancePort(),
+		user.AccountingEndpoint) + "?since=" + neturl.QueryEscape(since.Format(time.RFC3339))
+
+	body, err, httpCode := util.DoHttpRequestJSONBody(ctx, c.httpClient, url, http.MethodGet, nil)
+	if err != nil {
+		return nil, newClientError(err)
+	}
+
+	if httpCode == http.StatusOK {
+		if err := json.Unmarshal(body, &records); err != nil {
+			return nil, newClientError(err)
+		}
+		return records, nil
+	} else if httpCode == http.StatusUnauthorized {
+		return nil, newClientError(errUnauthenticated)
+	} else {
+		return nil, newClientError(errors.New("error obtaining the accounting records"))
+	}
+}
+
+// Reputations returns the reputation that the CARAVELA's instance gives to the suppliers it interacted with.
+func (c *Client) Reputations(ctx context.Context) ([]types.SupplierReputation, *Error) {
+	var reputations []types.SupplierReputation
+
+	url := util.BuildHttpURL(false, c.config.CaravelaInstanceIP(), c.config.CaravelaInstancePort(),
+		user.ReputationEndpoint)
+
+	body, err, httpCode := util.DoHttpRequestJSONBody(ctx, c.httpClient, url, http.MethodGet, nil)
+	if err != nil {
+		return nil, newClientError(err)
+	}
+
+	if httpCode == http.StatusOK {
+		if err := json.Unmarshal(body, &reputations); err != nil {
+			return nil, newClientError(err)
+		}
+		return reputations, nil
+	} else if httpCode == http.StatusUnauthorized {
+		return nil, newClientError(errUnauthenticated)
+	} else {
+		return nil, newClientError(errors.New("error obtaining the suppliers reputation"))
+	}
+}
+
+// Drain puts the CARAVELA's instance in maintenance. It stops offering its resources and asks the buyers of its
+// containers to move them, waiting until the timeout. If leave is true the instance leaves the system afterwards.
+func (c *Client) Drain(ctx context.Context, leave bool, timeout time.Duration) (*types.DrainStatus, *Error) {
+	var drainStatus types.DrainStatus
+
+	url := util.BuildHttpURL(false, c.config.CaravelaInstanceIP(), c.config.CaravelaInstancePort(),
+		user.DrainEndpoint)
+
+	drainRequest := types.DrainRequest{Leave: leave, Timeout: timeout}
+	body, err, httpCode := util.DoHttpRequestJSONBody(ctx, c.httpClient, url, http.MethodPost, drainRequest)
+	if err != nil {
+		return nil, newClientError(err)
+	}
+
+	if httpCode == http.StatusOK {
+		if err := json.Unmarshal(body, &drainStatus); err != nil {
+			return nil, newClientError(err)
+		}
+		return &drainStatus, nil
+	} else if httpCode == http.StatusUnauthorized {
+		return nil, newClientError(errUnauthenticated)
+	} else {
+		return nil, newClientError(errors.New("error draining the node"))
+	}
+}
+
+// Undrain takes the CARAVELA's instance out of maintenance, offering its resources again.
+func (c *Client) Undrain(ctx context.Context) *Error {
+	url := util.BuildHttpURL(false, c.config.CaravelaInstanceIP(), c.config.CaravelaInstancePort(),
+		user.DrainEndpoint)
+
+	err, httpCode := util.DoHttpRequestJSON(ctx, c.httpClient, url, http.MethodDelete, nil, nil)
+	if err != nil {
+		return newClientError(err)
+	}
+
+	if httpCode == http.StatusOK {
+		return nil
+	} else if httpCode == http.StatusUnauthorized {
+		return newClientError(errUnauthenticated)
+	} else {
+		return newClientError(errors.New("error undraining the node"))
+	}
+}
+
+// Faults obtains the faults being injected by the CARAVELA's instance in the requests it sends to other nodes.
+func (c *Client) Faults(ctx context.Context) (*types.Faults, *Error) {
+	var faults types.Faults
+
+	url := util.BuildHttpURL(false, c.config.CaravelaInstanceIP(), c.config.CaravelaInstancePort(),
+		user.FaultsEndpoint)
+
+	err, httpCode := util.DoHttpRequestJSON(ctx, c.httpClient, url, http.MethodGet, nil, &faults)
+	if err != nil {
+		return nil, newClientError(err)
+	}
+
+	if httpCode == http.StatusOK {
+		return &faults, nil
+	} else if httpCode == http.StatusUnauthorized {
+		return nil, newClientError(errUnauthenticated)
+	} else {
+		return nil, newClientError(errors.New("error obtaining the faults"))
+	}
+}
+
+// SetFaults replaces the faults injected by the CARAVELA's instance in the requests it sends to other nodes.
+func (c *Client) SetFaults(ctx context.Context, faults *types.Faults) *Error {
+	url := util.BuildHttpURL(false, c.config.CaravelaInstanceIP(), c.config.CaravelaInstancePort(),
+		user.FaultsEndpoint)
+
+	err, httpCode := util.DoHttpRequestJSON(ctx, c.httpClient, url, http.MethodPut, faults, nil)
+	if err != nil {
+		return newClientError(err)
+	}
+
+	if httpCode == http.StatusOK {
+		return nil
+	} else if httpCode == http.StatusUnauthorized {
+		return newClientError(errUnauthenticated)
+	} else {
+		return newClientError(errors.New("error setting the faults"))
+	}
+}
+
 // Shutdown makes the daemon cleanly shutdown and leave the system.
 func (c *Client) Shutdown(ctx context.Context) *Error {
 	url := util.BuildHttpURL(false, c.config.CaravelaInstanceIP(), c.config.CaravelaInstancePort(),
@@ -110,6 +312,8 @@ func (c *Client) Shutdown(ctx context.Context) *Error {
 
 	if httpCode == http.StatusOK {
 		return nil
+	} else if httpCode == http.StatusUnauthorized {
+		return newClientError(errUnauthenticated)
 	} else {
 		return newClientError(errors.New("error exiting from the system"))
 	}
@@ -3,7 +3,10 @@ package api
 import (
 	"github.com/strabox/caravela/api/rest/configuration"
 	"github.com/strabox/caravela/api/rest/containers"
+	"github.com/strabox/caravela/api/rest/credits"
 	"github.com/strabox/caravela/api/rest/discovery"
+	"github.com/strabox/caravela/api/rest/images"
+	"github.com/strabox/caravela/api/rest/quota"
 	"github.com/strabox/caravela/api/rest/scheduling"
 	"github.com/strabox/caravela/api/rest/user"
 )
@@ -12,7 +15,10 @@ import (
 type LocalNode interface {
 	configuration.Configurations
 	containers.Containers
+	credits.Credits
 	discovery.Discovery
+	images.Images
+	quota.Quota
 	scheduling.Scheduling
 	user.User
 }
@@ -0,0 +1,60 @@
+package remote
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/tls"
+	"errors"
+	log "github.com/Sirupsen/logrus"
+	securityREST "github.com/strabox/caravela/api/rest/security"
+	"github.com/strabox/caravela/api/rest/util"
+	"github.com/strabox/caravela/api/security"
+	"net/http"
+	"time"
+)
+
+// RequestCredentials obtains the node's credentials from a node of the system (that has the cluster's CA),
+// proving the knowledge of the bootstrap token.
+// The joining node does not know the CA yet, so the CA given is only trusted if it is bound to the token.
+func RequestCredentials(ctx context.Context, joinNodeIP string, apiPort int, requestTimeout time.Duration,
+	hostIP string, token string, protocols *util.Protocols) (*security.Credentials, error) {
+	log.Infof("--> ISSUE CERTIFICATE To: %s", joinNodeIP)
+
+	csr, keyPEM, err := security.NewCertificateRequest(hostIP)
+	if err != nil {
+		return nil, err
+	}
+
+	certificateRequestMsg := util.CertificateRequestMsg{
+		CSR:   csr,
+		Proof: security.BootstrapProof(token, csr),
+	}
+
+	bootstrapClient := &http.Client{
+		Timeout: requestTimeout,
+		Transport: protocols.Transport(&http.Transport{ // The server is authenticated by the proof in the response.
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true, MinVersion: tls.VersionTLS12},
+		}),
+	}
+
+	var certificateResponseMsg util.CertificateResponseMsg
+
+	url := util.BuildHttpURL(true, joinNodeIP, apiPort, securityREST.CertificateEndpoint)
+
+	err, httpCode := util.DoHttpRequestJSON(ctx, bootstrapClient, url, http.MethodPost, certificateRequestMsg,
+		&certificateResponseMsg)
+	if err != nil {
+		return nil, NewRemoteClientError(err)
+	}
+
+	if httpCode != http.StatusOK {
+		return nil, NewRemoteClientError(errors.New("impossible obtain the node's certificate"))
+	}
+
+	expectedProof := security.BootstrapProof(token, certificateResponseMsg.Certificate, certificateResponseMsg.CA)
+	if !hmac.Equal(expectedProof, certificateResponseMsg.Proof) {
+		return nil, errors.New("node that issued the certificate does not know the bootstrap token")
+	}
+
+	return security.NewCredentials(certificateResponseMsg.CA, certificateResponseMsg.Certificate, keyPEM)
+}
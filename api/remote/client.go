@@ -6,6 +6,7 @@ import (
 	"github.com/strabox/caravela/configuration"
 	"github.com/strabox/caravela/node/common"
 	"github.com/strabox/caravela/node/external"
+	"io"
 )
 
 type Client struct {
@@ -54,14 +55,73 @@ func (h *Client) AdvertiseOffersNeighbor(ctx context.Context, fromTrader, toNeig
 	return h.httpClient.AdvertiseOffersNeighbor(h.getRequestContext(ctx), fromTrader, toNeighborTrader, traderOffering)
 }
 
+func (h *Client) ReplicateOffers(ctx context.Context, fromTrader, toTrader *types.Node, offers []types.ReplicatedOffer) error {
+	return h.httpClient.ReplicateOffers(h.getRequestContext(ctx), fromTrader, toTrader, offers)
+}
+
+func (h *Client) HandOverOffers(ctx context.Context, fromTrader, toTrader *types.Node, offers []types.ReplicatedOffer) error {
+	return h.httpClient.HandOverOffers(h.getRequestContext(ctx), fromTrader, toTrader, offers)
+}
+
+func (h *Client) ChangeOfferTrader(ctx context.Context, fromTrader, toSupplier, newTrader *types.Node, offer *types.Offer) error {
+	return h.httpClient.ChangeOfferTrader(h.getRequestContext(ctx), fromTrader, toSupplier, newTrader, offer)
+}
+
 func (h *Client) LaunchContainer(ctx context.Context, fromBuyer, toSupplier *types.Node, offer *types.Offer,
 	containersConfigs []types.ContainerConfig) ([]types.ContainerStatus, error) {
 
 	return h.httpClient.LaunchContainer(h.getRequestContext(ctx), fromBuyer, toSupplier, offer, containersConfigs)
 }
 
-func (h *Client) StopLocalContainer(ctx context.Context, toSupplier *types.Node, containerID string) error {
-	return h.httpClient.StopLocalContainer(h.getRequestContext(ctx), toSupplier, containerID)
+func (h *Client) RescheduleContainer(ctx context.Context, fromSupplier, toBuyer *types.Node, containerID string) error {
+	return h.httpClient.RescheduleContainer(h.getRequestContext(ctx), fromSupplier, toBuyer, containerID)
+}
+
+func (h *Client) AdoptContainer(ctx context.Context, fromSupplier, toBuyer *types.Node, userID string,
+	containerStatus *types.ContainerStatus) error {
+	return h.httpClient.AdoptContainer(h.getRequestContext(ctx), fromSupplier, toBuyer, userID, containerStatus)
+}
+
+func (h *Client) StopLocalContainer(ctx context.Context, fromBuyer, toSupplier *types.Node, containerID string) error {
+	return h.httpClient.StopLocalContainer(h.getRequestContext(ctx), fromBuyer, toSupplier, containerID)
+}
+
+func (h *Client) AdvertiseImage(ctx context.Context, fromNode, toNode *types.Node, imageKey string) error {
+	return h.httpClient.AdvertiseImage(h.getRequestContext(ctx), fromNode, toNode, imageKey)
+}
+
+func (h *Client) GetImageHolders(ctx context.Context, fromNode, toNode *types.Node, imageKey string) ([]types.Node, error) {
+	return h.httpClient.GetImageHolders(h.getRequestContext(ctx), fromNode, toNode, imageKey)
+}
+
+func (h *Client) DownloadImage(ctx context.Context, toHolder *types.Node, imageKey string) (io.ReadCloser, error) {
+	return h.httpClient.DownloadImage(h.getRequestContext(ctx), toHolder, imageKey)
+}
+
+func (h *Client) ReserveQuota(ctx context.Context, fromNode, toNode *types.Node, userID string,
+	resources types.QuotaUsage) (*types.UserQuota, error) {
+	return h.httpClient.ReserveQuota(h.getRequestContext(ctx), fromNode, toNode, userID, resources)
+}
+
+func (h *Client) ReplicateQuota(ctx context.Context, fromNode, toNode *types.Node, userID string, resources types.QuotaUsage) error {
+	return h.httpClient.ReplicateQuota(h.getRequestContext(ctx), fromNode, toNode, userID, resources)
+}
+
+func (h *Client) GetQuota(ctx context.Context, fromNode, toNode *types.Node, userID string) (*types.UserQuota, error) {
+	return h.httpClient.GetQuota(h.getRequestContext(ctx), fromNode, toNode, userID)
+}
+
+func (h *Client) ExchangeReceipt(ctx context.Context, fromBuyer, toSupplier *types.Node,
+	receipt *types.CreditReceipt) (*types.CreditReceipt, error) {
+	return h.httpClient.ExchangeReceipt(h.getRequestContext(ctx), fromBuyer, toSupplier, receipt)
+}
+
+func (h *Client) PostReceipt(ctx context.Context, fromNode, toNode *types.Node, receipt *types.CreditReceipt) error {
+	return h.httpClient.PostReceipt(h.getRequestContext(ctx), fromNode, toNode, receipt)
+}
+
+func (h *Client) GetBalance(ctx context.Context, fromNode, toNode *types.Node, nodeIP string) (float64, error) {
+	return h.httpClient.GetBalance(h.getRequestContext(ctx), fromNode, toNode, nodeIP)
 }
 
 func (h *Client) ObtainConfiguration(ctx context.Context, systemsNode *types.Node) (*configuration.Configuration, error) {
@@ -1,6 +1,7 @@
 package remote
 
 import (
+	"net"
 	"strings"
 )
 
@@ -25,6 +26,15 @@ func NewRemoteClientError(err error) *Error {
 	return res
 }
 
+// NewUnavailableRemoteClientError returns an error of a request to an instance that could not be reached or did not
+// answer in time, for the clients that know it from the error returned by their transport.
+func NewUnavailableRemoteClientError(err error) *Error {
+	return &Error{
+		Code: CaravelaInstanceUnavailable,
+		err:  err,
+	}
+}
+
 func (ce *Error) Error() string {
 	switch ce.Code {
 	case CaravelaInstanceUnavailable:
@@ -33,3 +43,14 @@ func (ce *Error) Error() string {
 		return ce.err.Error()
 	}
 }
+
+// Timeout returns true if the remote instance did not answer in time.
+func (ce *Error) Timeout() bool {
+	netErr, ok := ce.err.(net.Error)
+	return ok && netErr.Timeout()
+}
+
+// Unavailable returns true if the remote instance could not be reached or did not answer in time.
+func (ce *Error) Unavailable() bool {
+	return ce.Code == CaravelaInstanceUnavailable || ce.Timeout()
+}
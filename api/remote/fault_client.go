@@ -0,0 +1,297 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	log "github.com/Sirupsen/logrus"
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/configuration"
+	"github.com/strabox/caravela/node/external"
+	"github.com/strabox/caravela/util"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// FaultyClient decorates a remote client injecting faults (delays, drops and errors) in the requests sent to other
+// nodes, following the configured rules. It is used to run chaos experiments and the rules can be changed at runtime.
+type FaultyClient struct {
+	client  external.Caravela // Client that really sends the requests
+	localIP string            // IP of the node that uses the client
+	faults  types.Faults      // Faults being injected
+	random  *rand.Rand        // Source of the faults' probabilities
+	mutex   sync.RWMutex      // Mutex to manage the faults
+}
+
+// NewFaultyClient creates a new fault injection decorator for the client, initially without faults.
+func NewFaultyClient(client external.Caravela, localIP string) *FaultyClient {
+	return &FaultyClient{
+		client:  client,
+		localIP: localIP,
+		faults:  types.Faults{Enabled: false, Rules: make([]types.FaultRule, 0)},
+		random:  rand.New(util.NewSourceSafe(rand.NewSource(time.Now().UnixNano()))),
+		mutex:   sync.RWMutex{},
+	}
+}
+
+// LoadFile sets the faults described in a JSON file.
+func (f *FaultyClient) LoadFile(faultsFile string) error {
+	fileContent, err := ioutil.ReadFile(faultsFile)
+	if err != nil {
+		return err
+	}
+
+	faults := &types.Faults{}
+	if err := json.Unmarshal(fileContent, faults); err != nil {
+		return err
+	}
+	return f.SetFaults(faults)
+}
+
+// Faults returns the faults being injected.
+func (f *FaultyClient) Faults() *types.Faults {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	return &types.Faults{
+		Enabled: f.faults.Enabled,
+		Rules:   append([]types.FaultRule(nil), f.faults.Rules...),
+	}
+}
+
+// SetFaults replaces the faults being injected.
+func (f *FaultyClient) SetFaults(faults *types.Faults) error {
+	for i, rule := range faults.Rules {
+		if rule.Delay < 0 {
+			return fmt.Errorf("fault rule %d: invalid delay %s", i, rule.Delay)
+		}
+		if rule.DropRate < 0 || rule.DropRate > 1 {
+			return fmt.Errorf("fault rule %d: invalid drop rate %f", i, rule.DropRate)
+		}
+		if rule.ErrorRate < 0 || rule.ErrorRate > 1 {
+			return fmt.Errorf("fault rule %d: invalid error rate %f", i, rule.ErrorRate)
+		}
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.faults = types.Faults{
+		Enabled: faults.Enabled,
+		Rules:   append([]types.FaultRule(nil), faults.Rules...),
+	}
+	log.Infof(util.LogTag("Faults")+"Enabled: %t, Rules: %d", f.faults.Enabled, len(f.faults.Rules))
+	return nil
+}
+
+// inject applies the faults of all the rules that match a request. It returns an error when the request must
+// not be sent.
+func (f *FaultyClient) inject(ctx context.Context, method, toIP string) error {
+	f.mutex.RLock()
+	if !f.faults.Enabled {
+		f.mutex.RUnlock()
+		return nil
+	}
+	rules := make([]types.FaultRule, 0)
+	for _, rule := range f.faults.Rules {
+		if (rule.Method == "" || rule.Method == method) && (rule.FromIP == "" || rule.FromIP == f.localIP) &&
+			(rule.ToIP == "" || rule.ToIP == toIP) {
+			rules = append(rules, rule)
+		}
+	}
+	f.mutex.RUnlock()
+
+	for _, rule := range rules {
+		if rule.Delay > 0 {
+			select {
+			case <-time.After(rule.Delay):
+			case <-ctx.Done():
+				return NewRemoteClientError(ctx.Err())
+			}
+		}
+		if rule.DropRate > 0 && f.random.Float64() < rule.DropRate {
+			log.Debugf(util.LogTag("Faults")+"DROPPED %s To: %s", method, toIP)
+			return NewRemoteClientError(fmt.Errorf("No connection to %s (fault injected)", toIP))
+		}
+		if rule.ErrorRate > 0 && f.random.Float64() < rule.ErrorRate {
+			log.Debugf(util.LogTag("Faults")+"FAILED %s To: %s", method, toIP)
+			return NewRemoteClientError(errors.New("fault injected"))
+		}
+	}
+	return nil
+}
+
+func (f *FaultyClient) CreateOffer(ctx context.Context, fromNode, toNode *types.Node, offer *types.Offer) error {
+	if err := f.inject(ctx, "CreateOffer", toNode.IP); err != nil {
+		return err
+	}
+	return f.client.CreateOffer(ctx, fromNode, toNode, offer)
+}
+
+func (f *FaultyClient) RefreshOffer(ctx context.Context, fromTrader, toSupp *types.Node, offer *types.Offer) (bool, error) {
+	if err := f.inject(ctx, "RefreshOffer", toSupp.IP); err != nil {
+		return false, err
+	}
+	return f.client.RefreshOffer(ctx, fromTrader, toSupp, offer)
+}
+
+func (f *FaultyClient) UpdateOffer(ctx context.Context, fromSupplier, toTrader *types.Node, offer *types.Offer) error {
+	if err := f.inject(ctx, "UpdateOffer", toTrader.IP); err != nil {
+		return err
+	}
+	return f.client.UpdateOffer(ctx, fromSupplier, toTrader, offer)
+}
+
+func (f *FaultyClient) RemoveOffer(ctx context.Context, fromSupp, toTrader *types.Node, offer *types.Offer) error {
+	if err := f.inject(ctx, "RemoveOffer", toTrader.IP); err != nil {
+		return err
+	}
+	return f.client.RemoveOffer(ctx, fromSupp, toTrader, offer)
+}
+
+func (f *FaultyClient) GetOffers(ctx context.Context, fromNode, toTrader *types.Node, relay bool) ([]types.AvailableOffer, error) {
+	if err := f.inject(ctx, "GetOffers", toTrader.IP); err != nil {
+		return nil, err
+	}
+	return f.client.GetOffers(ctx, fromNode, toTrader, relay)
+}
+
+func (f *FaultyClient) AdvertiseOffersNeighbor(ctx context.Context, fromTrader, toNeighborTrader, traderOffering *types.Node) error {
+	if err := f.inject(ctx, "AdvertiseOffersNeighbor", toNeighborTrader.IP); err != nil {
+		return err
+	}
+	return f.client.AdvertiseOffersNeighbor(ctx, fromTrader, toNeighborTrader, traderOffering)
+}
+
+func (f *FaultyClient) ReplicateOffers(ctx context.Context, fromTrader, toTrader *types.Node, offers []types.ReplicatedOffer) error {
+	if err := f.inject(ctx, "ReplicateOffers", toTrader.IP); err != nil {
+		return err
+	}
+	return f.client.ReplicateOffers(ctx, fromTrader, toTrader, offers)
+}
+
+func (f *FaultyClient) HandOverOffers(ctx context.Context, fromTrader, toTrader *types.Node, offers []types.ReplicatedOffer) error {
+	if err := f.inject(ctx, "HandOverOffers", toTrader.IP); err != nil {
+		return err
+	}
+	return f.client.HandOverOffers(ctx, fromTrader, toTrader, offers)
+}
+
+func (f *FaultyClient) ChangeOfferTrader(ctx context.Context, fromTrader, toSupplier, newTrader *types.Node,
+	offer *types.Offer) error {
+
+	if err := f.inject(ctx, "ChangeOfferTrader", toSupplier.IP); err != nil {
+		return err
+	}
+	return f.client.ChangeOfferTrader(ctx, fromTrader, toSupplier, newTrader, offer)
+}
+
+func (f *FaultyClient) LaunchContainer(ctx context.Context, fromBuyer, toSupplier *types.Node, offer *types.Offer,
+	containersConfigs []types.ContainerConfig) ([]types.ContainerStatus, error) {
+
+	if err := f.inject(ctx, "LaunchContainer", toSupplier.IP); err != nil {
+		return nil, err
+	}
+	return f.client.LaunchContainer(ctx, fromBuyer, toSupplier, offer, containersConfigs)
+}
+
+func (f *FaultyClient) RescheduleContainer(ctx context.Context, fromSupplier, toBuyer *types.Node, containerID string) error {
+	if err := f.inject(ctx, "RescheduleContainer", toBuyer.IP); err != nil {
+		return err
+	}
+	return f.client.RescheduleContainer(ctx, fromSupplier, toBuyer, containerID)
+}
+
+func (f *FaultyClient) AdoptContainer(ctx context.Context, fromSupplier, toBuyer *types.Node, userID string,
+	containerStatus *types.ContainerStatus) error {
+
+	if err := f.inject(ctx, "AdoptContainer", toBuyer.IP); err != nil {
+		return err
+	}
+	return f.client.AdoptContainer(ctx, fromSupplier, toBuyer, userID, containerStatus)
+}
+
+func (f *FaultyClient) StopLocalContainer(ctx context.Context, fromBuyer, toSupplier *types.Node, containerID string) error {
+	if err := f.inject(ctx, "StopLocalContainer", toSupplier.IP); err != nil {
+		return err
+	}
+	return f.client.StopLocalContainer(ctx, fromBuyer, toSupplier, containerID)
+}
+
+func (f *FaultyClient) AdvertiseImage(ctx context.Context, fromNode, toNode *types.Node, imageKey string) error {
+	if err := f.inject(ctx, "AdvertiseImage", toNode.IP); err != nil {
+		return err
+	}
+	return f.client.AdvertiseImage(ctx, fromNode, toNode, imageKey)
+}
+
+func (f *FaultyClient) GetImageHolders(ctx context.Context, fromNode, toNode *types.Node, imageKey string) ([]types.Node, error) {
+	if err := f.inject(ctx, "GetImageHolders", toNode.IP); err != nil {
+		return nil, err
+	}
+	return f.client.GetImageHolders(ctx, fromNode, toNode, imageKey)
+}
+
+func (f *FaultyClient) DownloadImage(ctx context.Context, toHolder *types.Node, imageKey string) (io.ReadCloser, error) {
+	if err := f.inject(ctx, "DownloadImage", toHolder.IP); err != nil {
+		return nil, err
+	}
+	return f.client.DownloadImage(ctx, toHolder, imageKey)
+}
+
+func (f *FaultyClient) ReserveQuota(ctx context.Context, fromNode, toNode *types.Node, userID string,
+	resources types.QuotaUsage) (*types.UserQuota, error) {
+
+	if err := f.inject(ctx, "ReserveQuota", toNode.IP); err != nil {
+		return nil, err
+	}
+	return f.client.ReserveQuota(ctx, fromNode, toNode, userID, resources)
+}
+
+func (f *FaultyClient) ReplicateQuota(ctx context.Context, fromNode, toNode *types.Node, userID string, resources types.QuotaUsage) error {
+	if err := f.inject(ctx, "ReplicateQuota", toNode.IP); err != nil {
+		return err
+	}
+	return f.client.ReplicateQuota(ctx, fromNode, toNode, userID, resources)
+}
+
+func (f *FaultyClient) GetQuota(ctx context.Context, fromNode, toNode *types.Node, userID string) (*types.UserQuota, error) {
+	if err := f.inject(ctx, "GetQuota", toNode.IP); err != nil {
+		return nil, err
+	}
+	return f.client.GetQuota(ctx, fromNode, toNode, userID)
+}
+
+func (f *FaultyClient) ExchangeReceipt(ctx context.Context, fromBuyer, toSupplier *types.Node,
+	receipt *types.CreditReceipt) (*types.CreditReceipt, error) {
+
+	if err := f.inject(ctx, "ExchangeReceipt", toSupplier.IP); err != nil {
+		return nil, err
+	}
+	return f.client.ExchangeReceipt(ctx, fromBuyer, toSupplier, receipt)
+}
+
+func (f *FaultyClient) PostReceipt(ctx context.Context, fromNode, toNode *types.Node, receipt *types.CreditReceipt) error {
+	if err := f.inject(ctx, "PostReceipt", toNode.IP); err != nil {
+		return err
+	}
+	return f.client.PostReceipt(ctx, fromNode, toNode, receipt)
+}
+
+func (f *FaultyClient) GetBalance(ctx context.Context, fromNode, toNode *types.Node, nodeIP string) (float64, error) {
+	if err := f.inject(ctx, "GetBalance", toNode.IP); err != nil {
+		return 0, err
+	}
+	return f.client.GetBalance(ctx, fromNode, toNode, nodeIP)
+}
+
+func (f *FaultyClient) ObtainConfiguration(ctx context.Context, systemsNode *types.Node) (*configuration.Configuration, error) {
+	if err := f.inject(ctx, "ObtainConfiguration", systemsNode.IP); err != nil {
+		return nil, err
+	}
+	return f.client.ObtainConfiguration(ctx, systemsNode)
+}
@@ -0,0 +1,56 @@
+package remote
+
+import (
+	"context"
+	"github.com/strabox/caravela/api/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFaultyClient_Inject_OneWayPartition(t *testing.T) {
+	faultyClient := NewFaultyClient(nil, "10.0.0.1")
+	err := faultyClient.SetFaults(&types.Faults{
+		Enabled: true,
+		Rules:   []types.FaultRule{{FromIP: "10.0.0.1", ToIP: "10.0.0.2", DropRate: 1}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = faultyClient.inject(context.Background(), "RefreshOffer", "10.0.0.2")
+	assert.Error(t, err, "Request not dropped")
+	assert.Equal(t, CaravelaInstanceUnavailable, err.(*Error).Code, "Dropped request must look like an unavailable node")
+	assert.NoError(t, faultyClient.inject(context.Background(), "RefreshOffer", "10.0.0.3"), "Other link affected")
+
+	otherClient := NewFaultyClient(nil, "10.0.0.2")
+	otherClient.SetFaults(faultyClient.Faults())
+	assert.NoError(t, otherClient.inject(context.Background(), "RefreshOffer", "10.0.0.1"), "Partition is not one-way")
+}
+
+func TestFaultyClient_Inject_MethodAndToggle(t *testing.T) {
+	faultyClient := NewFaultyClient(nil, "10.0.0.1")
+	faults := &types.Faults{
+		Enabled: true,
+		Rules:   []types.FaultRule{{Method: "RefreshOffer", ErrorRate: 1}},
+	}
+	if err := faultyClient.SetFaults(faults); err != nil {
+		t.Fatal(err)
+	}
+
+	err := faultyClient.inject(context.Background(), "RefreshOffer", "10.0.0.2")
+	assert.Error(t, err, "Request did not fail")
+	assert.Equal(t, Unknown, err.(*Error).Code, "Failed request must not look like an unavailable node")
+	assert.NoError(t, faultyClient.inject(context.Background(), "CreateOffer", "10.0.0.2"), "Other method affected")
+
+	faults.Enabled = false
+	assert.NoError(t, faultyClient.SetFaults(faults))
+	assert.NoError(t, faultyClient.inject(context.Background(), "RefreshOffer", "10.0.0.2"), "Disabled faults injected")
+}
+
+func TestFaultyClient_SetFaults_Invalid(t *testing.T) {
+	faultyClient := NewFaultyClient(nil, "10.0.0.1")
+
+	err := faultyClient.SetFaults(&types.Faults{Enabled: true, Rules: []types.FaultRule{{DropRate: 1.5}}})
+	assert.Error(t, err, "Invalid drop rate accepted")
+	assert.False(t, faultyClient.Faults().Enabled, "Invalid faults applied")
+}
@@ -2,33 +2,82 @@ package remote
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	log "github.com/Sirupsen/logrus"
 	configREST "github.com/strabox/caravela/api/rest/configuration"
 	"github.com/strabox/caravela/api/rest/containers"
+	"github.com/strabox/caravela/api/rest/credits"
 	"github.com/strabox/caravela/api/rest/discovery"
+	"github.com/strabox/caravela/api/rest/images"
+	"github.com/strabox/caravela/api/rest/quota"
+	"github.com/strabox/caravela/api/rest/scheduling"
 	"github.com/strabox/caravela/api/rest/util"
+	"github.com/strabox/caravela/api/security"
 	"github.com/strabox/caravela/api/types"
 	"github.com/strabox/caravela/configuration"
+	"github.com/strabox/caravela/version"
+	"io"
 	"net/http"
 	"time"
 )
 
 // httpClient is used to contact the REST API of other nodes.
 type httpClient struct {
-	httpClient *http.Client
-	apiPort    int
+	httpClient   *http.Client
+	streamClient *http.Client // Used in long transfers (bounded by the request's context).
+	protocols    *util.Protocols
+	apiPort      int
+	https        bool // True if the nodes communicate using mutual TLS.
 }
 
-func NewHttpClient(apiPort int, requestTimeout time.Duration) *httpClient {
+// NewHttpClient creates a client that contacts the other nodes negotiating the protocol through the given protocols.
+func NewHttpClient(apiPort int, requestTimeout time.Duration, protocols *util.Protocols) *httpClient {
+	transport := protocols.Transport(http.DefaultTransport)
 	return &httpClient{
 		httpClient: &http.Client{
-			Timeout: requestTimeout,
+			Timeout:   requestTimeout,
+			Transport: transport,
 		},
-		apiPort: apiPort,
+		streamClient: &http.Client{
+			Transport: transport,
+		},
+		protocols: protocols,
+		apiPort:   apiPort,
+		https:     false,
+	}
+}
+
+// NewSecureHttpClient creates a client that contacts the other nodes using mutual TLS with the given credentials.
+func NewSecureHttpClient(apiPort int, requestTimeout time.Duration, credentials *security.Credentials,
+	protocols *util.Protocols) *httpClient {
+	transport := protocols.Transport(&http.Transport{
+		TLSClientConfig: credentials.ClientTLSConfig(),
+	})
+	return &httpClient{
+		httpClient: &http.Client{
+			Timeout:   requestTimeout,
+			Transport: transport,
+		},
+		streamClient: &http.Client{
+			Transport: transport,
+		},
+		protocols: protocols,
+		apiPort:   apiPort,
+		https:     true,
 	}
 }
 
+// checkCapability returns an error if the node is known to not support the capability needed by a message, e.g.
+// older nodes during a rolling upgrade.
+func (h *httpClient) checkCapability(ip, capability string) error {
+	if !h.protocols.Supports(ip, capability) {
+		return NewRemoteClientError(fmt.Errorf("node %s does not support %s", ip, capability))
+	}
+	return nil
+}
+
 func (h *httpClient) CreateOffer(ctx context.Context, fromNode, toNode *types.Node, offer *types.Offer) error {
 	log.Infof("--> CREATE OFFER From: %s, ID: %d, Amt: %d, Res: <%d;%d>, To: <%s;%s>",
 		fromNode.IP, offer.ID, offer.Amount, offer.FreeResources.CPUs, offer.FreeResources.Memory, toNode.IP, toNode.GUID[0:12])
@@ -39,7 +88,7 @@ func (h *httpClient) CreateOffer(ctx context.Context, fromNode, toNode *types.No
 		Offer:    *offer,
 	}
 
-	url := util.BuildHttpURL(false, toNode.IP, h.apiPort, discovery.OfferBaseEndpoint)
+	url := util.BuildHttpURL(h.https, toNode.IP, h.apiPort, discovery.OfferBaseEndpoint)
 
 	err, _ := util.DoHttpRequestJSON(ctx, h.httpClient, url, http.MethodPost, createOfferMsg, nil)
 	if err != nil {
@@ -58,7 +107,7 @@ func (h *httpClient) RefreshOffer(ctx context.Context, fromTrader, toSupp *types
 	}
 	var refreshOfferResp util.RefreshOfferResponseMsg
 
-	url := util.BuildHttpURL(false, toSupp.IP, h.apiPort, discovery.OfferBaseEndpoint)
+	url := util.BuildHttpURL(h.https, toSupp.IP, h.apiPort, discovery.OfferBaseEndpoint)
 
 	err, _ := util.DoHttpRequestJSON(ctx, h.httpClient, url, http.MethodPatch, refreshOfferMsg,
 		&refreshOfferResp)
@@ -79,7 +128,7 @@ func (h *httpClient) UpdateOffer(ctx context.Context, fromSupplier, toTrader *ty
 	}
 	var refreshOfferResp util.RefreshOfferResponseMsg
 
-	url := util.BuildHttpURL(false, toTrader.IP, h.apiPort, discovery.OfferBaseEndpoint)
+	url := util.BuildHttpURL(h.https, toTrader.IP, h.apiPort, discovery.OfferBaseEndpoint)
 
 	err, _ := util.DoHttpRequestJSON(ctx, h.httpClient, url, http.MethodPut, updateOfferMsg,
 		&refreshOfferResp)
@@ -99,7 +148,7 @@ func (h *httpClient) RemoveOffer(ctx context.Context, fromSupp, toTrader *types.
 		ToTrader:     *toTrader,
 		Offer:        *offer}
 
-	url := util.BuildHttpURL(false, toTrader.IP, h.apiPort, discovery.OfferBaseEndpoint)
+	url := util.BuildHttpURL(h.https, toTrader.IP, h.apiPort, discovery.OfferBaseEndpoint)
 
 	err, _ := util.DoHttpRequestJSON(ctx, h.httpClient, url, http.MethodDelete, offerRemoveMsg, nil)
 	if err != nil {
@@ -119,7 +168,7 @@ func (h *httpClient) GetOffers(ctx context.Context, fromNode, toTrader *types.No
 	}
 	var offers []types.AvailableOffer
 
-	url := util.BuildHttpURL(false, toTrader.IP, h.apiPort, discovery.OfferBaseEndpoint)
+	url := util.BuildHttpURL(h.https, toTrader.IP, h.apiPort, discovery.OfferBaseEndpoint)
 
 	h.httpClient.Timeout = 10 * time.Second // TODO: Hack to avoid early timeouts -> Run container sequence of calls should be assynchronous
 	err, httpCode := util.DoHttpRequestJSON(ctx, h.httpClient, url, http.MethodGet, getOffersMsg, &offers)
@@ -147,7 +196,7 @@ func (h *httpClient) AdvertiseOffersNeighbor(ctx context.Context, fromTrader, to
 		NeighborOffering: *traderOffering,
 	}
 
-	url := util.BuildHttpURL(false, toNeighborTrader.IP, h.apiPort, discovery.NeighborOfferBaseEndpoint)
+	url := util.BuildHttpURL(h.https, toNeighborTrader.IP, h.apiPort, discovery.NeighborOfferBaseEndpoint)
 
 	err, httpCode := util.DoHttpRequestJSON(ctx, h.httpClient, url, http.MethodPatch, neighborOfferMsg, nil)
 	if err != nil {
@@ -161,6 +210,80 @@ func (h *httpClient) AdvertiseOffersNeighbor(ctx context.Context, fromTrader, to
 	}
 }
 
+func (h *httpClient) ReplicateOffers(ctx context.Context, fromTrader, toTrader *types.Node, offers []types.ReplicatedOffer) error {
+	log.Infof("--> REPLICATE OFFERS From: %s, Offers: %d, To: <%s;%s>", fromTrader.GUID[0:12], len(offers),
+		toTrader.IP, toTrader.GUID[0:12])
+
+	if err := h.checkCapability(toTrader.IP, version.CapabilityOfferReplication); err != nil {
+		return err
+	}
+
+	replicateOffersMsg := util.ReplicateOffersMsg{
+		FromTrader: *fromTrader,
+		ToTrader:   *toTrader,
+		Offers:     offers,
+	}
+
+	url := util.BuildHttpURL(h.https, toTrader.IP, h.apiPort, discovery.ReplicaOfferBaseEndpoint)
+
+	err, _ := util.DoHttpRequestJSON(ctx, h.httpClient, url, http.MethodPut, replicateOffersMsg, nil)
+	if err != nil {
+		return NewRemoteClientError(err)
+	}
+
+	return nil
+}
+
+func (h *httpClient) HandOverOffers(ctx context.Context, fromTrader, toTrader *types.Node, offers []types.ReplicatedOffer) error {
+	log.Infof("--> HAND OVER OFFERS From: %s, Offers: %d, To: <%s;%s>", fromTrader.GUID[0:12], len(offers),
+		toTrader.IP, toTrader.GUID[0:12])
+
+	if err := h.checkCapability(toTrader.IP, version.CapabilityOfferHandOver); err != nil {
+		return err
+	}
+
+	handOverOffersMsg := util.HandOverOffersMsg{
+		FromTrader: *fromTrader,
+		ToTrader:   *toTrader,
+		Offers:     offers,
+	}
+
+	url := util.BuildHttpURL(h.https, toTrader.IP, h.apiPort, discovery.HandOverOfferBaseEndpoint)
+
+	err, _ := util.DoHttpRequestJSON(ctx, h.httpClient, url, http.MethodPut, handOverOffersMsg, nil)
+	if err != nil {
+		return NewRemoteClientError(err)
+	}
+
+	return nil
+}
+
+func (h *httpClient) ChangeOfferTrader(ctx context.Context, fromTrader, toSupplier, newTrader *types.Node,
+	offer *types.Offer) error {
+
+	log.Infof("--> CHANGE OFFER TRADER From: %s, ID: %d, NewTrader: <%s;%s>, To: %s", fromTrader.GUID[0:12],
+		offer.ID, newTrader.IP, newTrader.GUID[0:12], toSupplier.IP)
+
+	if err := h.checkCapability(toSupplier.IP, version.CapabilityOfferHandOver); err != nil {
+		return err
+	}
+
+	changeOfferTraderMsg := util.ChangeOfferTraderMsg{
+		FromTrader: *fromTrader,
+		NewTrader:  *newTrader,
+		Offer:      *offer,
+	}
+
+	url := util.BuildHttpURL(h.https, toSupplier.IP, h.apiPort, discovery.OfferTraderEndpoint)
+
+	err, _ := util.DoHttpRequestJSON(ctx, h.httpClient, url, http.MethodPut, changeOfferTraderMsg, nil)
+	if err != nil {
+		return NewRemoteClientError(err)
+	}
+
+	return nil
+}
+
 func (h *httpClient) LaunchContainer(ctx context.Context, fromBuyer, toSupplier *types.Node, offer *types.Offer,
 	containersConfigs []types.ContainerConfig) ([]types.ContainerStatus, error) {
 
@@ -172,37 +295,134 @@ func (h *httpClient) LaunchContainer(ctx context.Context, fromBuyer, toSupplier
 
 	launchContainerMsg := util.LaunchContainerMsg{
 		FromBuyer:         *fromBuyer,
+		UserID:            types.UserID(ctx),
 		Offer:             *offer,
 		ContainersConfigs: containersConfigs,
 	}
 
-	var contStatusResp []types.ContainerStatus
+	var launchResp json.RawMessage
 
-	url := util.BuildHttpURL(false, toSupplier.IP, h.apiPort, containers.BaseEndpoint)
+	url := util.BuildHttpURL(h.https, toSupplier.IP, h.apiPort, containers.BaseEndpoint)
 
 	h.httpClient.Timeout = 600 * time.Second // TODO: Hack to avoid early timeouts -> Run container sequence of calls should be assynchronous
-	err, httpCode := util.DoHttpRequestJSON(ctx, h.httpClient, url, http.MethodPost, launchContainerMsg, &contStatusResp)
+	err, httpCode := util.DoHttpRequestJSON(ctx, h.httpClient, url, http.MethodPost, launchContainerMsg, &launchResp)
 	if err != nil {
 		return nil, NewRemoteClientError(err)
 	}
 
-	if httpCode == http.StatusOK {
+	switch httpCode {
+	case http.StatusOK:
+		var contStatusResp []types.ContainerStatus
+		if err := json.Unmarshal(launchResp, &contStatusResp); err != nil {
+			return nil, NewRemoteClientError(err)
+		}
 		return contStatusResp, nil
-	} else {
+	case http.StatusForbidden: // Supplier's image policy rejected the container's image.
+		imageRejectedErr := &types.ImageRejectedError{}
+		if err := json.Unmarshal(launchResp, imageRejectedErr); err != nil {
+			return nil, NewRemoteClientError(err)
+		}
+		return nil, imageRejectedErr
+	default:
 		return nil, NewRemoteClientError(errors.New("impossible launch container"))
 	}
 }
 
-func (h *httpClient) StopLocalContainer(ctx context.Context, toSupplier *types.Node, containerID string) error {
+func (h *httpClient) RescheduleContainer(ctx context.Context, fromSupplier, toBuyer *types.Node, containerID string) error {
+	log.Infof("--> RESCHEDULE From: %s, ID: %s, BuyerIP: %s", fromSupplier.IP, containerID, toBuyer.IP)
+
+	rescheduleContainerMsg := util.RescheduleContainerMsg{
+		FromSupplier: *fromSupplier,
+		ContainerID:  containerID,
+	}
+
+	url := util.BuildHttpURL(h.https, toBuyer.IP, h.apiPort, scheduling.RescheduleEndpoint)
+
+	err, httpCode := util.DoHttpRequestJSON(ctx, h.httpClient, url, http.MethodPost, rescheduleContainerMsg, nil)
+	if err != nil {
+		return NewRemoteClientError(err)
+	}
+
+	if httpCode == http.StatusOK {
+		return nil
+	} else {
+		return NewRemoteClientError(errors.New("impossible reschedule container"))
+	}
+}
+
+func (h *httpClient) AdoptContainer(ctx context.Context, fromSupplier, toBuyer *types.Node, userID string,
+	containerStatus *types.ContainerStatus) error {
+	log.Infof("--> ADOPT From: %s, ID: %s, User: %s, BuyerIP: %s", fromSupplier.IP, containerStatus.ContainerID,
+		userID, toBuyer.IP)
+
+	adoptContainerMsg := util.AdoptContainerMsg{
+		FromSupplier:    *fromSupplier,
+		UserID:          userID,
+		ContainerStatus: *containerStatus,
+	}
+
+	url := util.BuildHttpURL(h.https, toBuyer.IP, h.apiPort, scheduling.AdoptEndpoint)
+
+	body, err, httpCode := util.DoHttpRequestJSONBody(ctx, h.httpClient, url, http.MethodPost, adoptContainerMsg)
+	if err != nil {
+		return NewRemoteClientError(err)
+	}
+
+	switch httpCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusTooManyRequests: // The container does not fit in the user's quota.
+		quotaExceededErr := &types.QuotaExceededError{}
+		if err := json.Unmarshal(body, quotaExceededErr); err != nil {
+			return NewRemoteClientError(err)
+		}
+		return quotaExceededErr
+	default:
+		return NewRemoteClientError(errors.New("impossible adopt container"))
+	}
+}
+
+func (h *httpClient) StopLocalContainer(ctx context.Context, fromBuyer, toSupplier *types.Node, containerID string) error {
 	log.Infof("--> STOP ID: %s, SuppIP: %s", containerID, toSupplier.IP)
 
 	stopLocalContainerMsg := util.StopLocalContainerMsg{
+		FromBuyer:   *fromBuyer,
 		ContainerID: containerID,
+		UserID:      types.UserID(ctx),
 	}
 
-	url := util.BuildHttpURL(false, toSupplier.IP, h.apiPort, containers.BaseEndpoint)
+	url := util.BuildHttpURL(h.https, toSupplier.IP, h.apiPort, containers.BaseEndpoint)
 
-	err, httpCode := util.DoHttpRequestJSON(ctx, h.httpClient, url, http.MethodDelete, stopLocalContainerMsg, nil)
+	body, err, httpCode := util.DoHttpRequestJSONBody(ctx, h.httpClient, url, http.MethodDelete, stopLocalContainerMsg)
+	if err != nil {
+		return NewRemoteClientError(err)
+	}
+
+	switch httpCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound: // The supplier does not have the container (e.g. it exited).
+		containerNotFoundErr := &types.ContainerNotFoundError{}
+		if err := json.Unmarshal(body, containerNotFoundErr); err != nil {
+			return NewRemoteClientError(err)
+		}
+		return containerNotFoundErr
+	default:
+		return NewRemoteClientError(errors.New("impossible stop container"))
+	}
+}
+
+func (h *httpClient) AdvertiseImage(ctx context.Context, fromNode, toNode *types.Node, imageKey string) error {
+	log.Infof("--> ADVERTISE IMAGE From: %s, Img: %s, To: %s", fromNode.IP, imageKey, toNode.IP)
+
+	advertiseImageMsg := util.AdvertiseImageMsg{
+		FromNode: *fromNode,
+		ImageKey: imageKey,
+	}
+
+	url := util.BuildHttpURL(h.https, toNode.IP, h.apiPort, images.HoldersBaseEndpoint)
+
+	err, httpCode := util.DoHttpRequestJSON(ctx, h.httpClient, url, http.MethodPost, advertiseImageMsg, nil)
 	if err != nil {
 		return NewRemoteClientError(err)
 	}
@@ -210,7 +430,209 @@ func (h *httpClient) StopLocalContainer(ctx context.Context, toSupplier *types.N
 	if httpCode == http.StatusOK {
 		return nil
 	} else {
-		return NewRemoteClientError(errors.New("impossible stop container"))
+		return NewRemoteClientError(errors.New("impossible advertise image"))
+	}
+}
+
+func (h *httpClient) GetImageHolders(ctx context.Context, fromNode, toNode *types.Node, imageKey string) ([]types.Node, error) {
+	log.Infof("--> IMAGE HOLDERS From: %s, Img: %s, To: %s", fromNode.IP, imageKey, toNode.IP)
+
+	imageHoldersMsg := util.ImageHoldersMsg{
+		FromNode: *fromNode,
+		ImageKey: imageKey,
+	}
+	var holders []types.Node
+
+	url := util.BuildHttpURL(h.https, toNode.IP, h.apiPort, images.HoldersBaseEndpoint)
+
+	err, httpCode := util.DoHttpRequestJSON(ctx, h.httpClient, url, http.MethodGet, imageHoldersMsg, &holders)
+	if err != nil {
+		return nil, NewRemoteClientError(err)
+	}
+
+	if httpCode == http.StatusOK {
+		return holders, nil
+	} else {
+		return nil, NewRemoteClientError(errors.New("impossible obtain image holders"))
+	}
+}
+
+func (h *httpClient) DownloadImage(ctx context.Context, toHolder *types.Node, imageKey string) (io.ReadCloser, error) {
+	log.Infof("--> DOWNLOAD IMAGE Img: %s, To: %s", imageKey, toHolder.IP)
+
+	exportImageMsg := util.ExportImageMsg{
+		ImageKey: imageKey,
+	}
+
+	url := util.BuildHttpURL(h.https, toHolder.IP, h.apiPort, images.ArchiveBaseEndpoint)
+
+	req, err := http.NewRequest(http.MethodGet, url, util.ToJSONBuffer(exportImageMsg))
+	if err != nil {
+		return nil, NewRemoteClientError(err)
+	}
+
+	resp, err := h.streamClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, NewRemoteClientError(err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, NewRemoteClientError(errors.New("impossible download image"))
+	}
+	return resp.Body, nil
+}
+
+func (h *httpClient) ReserveQuota(ctx context.Context, fromNode, toNode *types.Node, userID string,
+	resources types.QuotaUsage) (*types.UserQuota, error) {
+	log.Infof("--> RESERVE QUOTA From: %s, User: %s, Res: <%d;%d;%d>, To: %s", fromNode.IP, userID,
+		resources.Containers, resources.CPUs, resources.Memory, toNode.IP)
+
+	quotaMsg := util.QuotaMsg{
+		FromNode:  *fromNode,
+		UserID:    userID,
+		Resources: resources,
+	}
+
+	url := util.BuildHttpURL(h.https, toNode.IP, h.apiPort, quota.UsageEndpoint)
+
+	body, err, httpCode := util.DoHttpRequestJSONBody(ctx, h.httpClient, url, http.MethodPost, quotaMsg)
+	if err != nil {
+		return nil, NewRemoteClientError(err)
+	}
+
+	switch httpCode {
+	case http.StatusOK:
+		userQuota := &types.UserQuota{}
+		if err := json.Unmarshal(body, userQuota); err != nil {
+			return nil, NewRemoteClientError(err)
+		}
+		return userQuota, nil
+	case http.StatusTooManyRequests: // The reservation exceeds the user's quota.
+		quotaExceededErr := &types.QuotaExceededError{}
+		if err := json.Unmarshal(body, quotaExceededErr); err != nil {
+			return nil, NewRemoteClientError(err)
+		}
+		return nil, quotaExceededErr
+	default:
+		return nil, NewRemoteClientError(errors.New("impossible reserve quota"))
+	}
+}
+
+func (h *httpClient) ReplicateQuota(ctx context.Context, fromNode, toNode *types.Node, userID string, resources types.QuotaUsage) error {
+	log.Infof("--> REPLICATE QUOTA From: %s, User: %s, Res: <%d;%d;%d>, To: %s", fromNode.IP, userID,
+		resources.Containers, resources.CPUs, resources.Memory, toNode.IP)
+
+	quotaMsg := util.QuotaMsg{
+		FromNode:  *fromNode,
+		UserID:    userID,
+		Resources: resources,
+	}
+
+	url := util.BuildHttpURL(h.https, toNode.IP, h.apiPort, quota.ReplicaEndpoint)
+
+	err, httpCode := util.DoHttpRequestJSON(ctx, h.httpClient, url, http.MethodPost, quotaMsg, nil)
+	if err != nil {
+		return NewRemoteClientError(err)
+	}
+
+	if httpCode == http.StatusOK {
+		return nil
+	} else {
+		return NewRemoteClientError(errors.New("impossible replicate quota"))
+	}
+}
+
+func (h *httpClient) GetQuota(ctx context.Context, fromNode, toNode *types.Node, userID string) (*types.UserQuota, error) {
+	log.Infof("--> GET QUOTA From: %s, User: %s, To: %s", fromNode.IP, userID, toNode.IP)
+
+	quotaMsg := util.QuotaMsg{
+		FromNode: *fromNode,
+		UserID:   userID,
+	}
+	var userQuota types.UserQuota
+
+	url := util.BuildHttpURL(h.https, toNode.IP, h.apiPort, quota.UsageEndpoint)
+
+	err, httpCode := util.DoHttpRequestJSON(ctx, h.httpClient, url, http.MethodGet, quotaMsg, &userQuota)
+	if err != nil {
+		return nil, NewRemoteClientError(err)
+	}
+
+	if httpCode == http.StatusOK {
+		return &userQuota, nil
+	} else {
+		return nil, NewRemoteClientError(errors.New("impossible obtain quota"))
+	}
+}
+
+func (h *httpClient) ExchangeReceipt(ctx context.Context, fromBuyer, toSupplier *types.Node,
+	receipt *types.CreditReceipt) (*types.CreditReceipt, error) {
+	log.Infof("--> EXCHANGE RECEIPT From: %s, ID: %s, Credits: %.2f, To: %s", fromBuyer.IP, receipt.ID,
+		receipt.Credits, toSupplier.IP)
+
+	receiptMsg := util.ReceiptMsg{
+		FromNode: *fromBuyer,
+		Receipt:  *receipt,
+	}
+	var countersigned types.CreditReceipt
+
+	url := util.BuildHttpURL(h.https, toSupplier.IP, h.apiPort, credits.ReceiptEndpoint)
+
+	err, httpCode := util.DoHttpRequestJSON(ctx, h.httpClient, url, http.MethodPost, receiptMsg, &countersigned)
+	if err != nil {
+		return nil, NewRemoteClientError(err)
+	}
+
+	if httpCode == http.StatusOK {
+		return &countersigned, nil
+	} else {
+		return nil, NewRemoteClientError(errors.New("impossible exchange receipt"))
+	}
+}
+
+func (h *httpClient) PostReceipt(ctx context.Context, fromNode, toNode *types.Node, receipt *types.CreditReceipt) error {
+	log.Infof("--> POST RECEIPT From: %s, ID: %s, To: %s", fromNode.IP, receipt.ID, toNode.IP)
+
+	receiptMsg := util.ReceiptMsg{
+		FromNode: *fromNode,
+		Receipt:  *receipt,
+	}
+
+	url := util.BuildHttpURL(h.https, toNode.IP, h.apiPort, credits.AccountEndpoint)
+
+	err, httpCode := util.DoHttpRequestJSON(ctx, h.httpClient, url, http.MethodPost, receiptMsg, nil)
+	if err != nil {
+		return NewRemoteClientError(err)
+	}
+
+	if httpCode == http.StatusOK {
+		return nil
+	} else {
+		return NewRemoteClientError(errors.New("impossible post receipt"))
+	}
+}
+
+func (h *httpClient) GetBalance(ctx context.Context, fromNode, toNode *types.Node, nodeIP string) (float64, error) {
+	log.Infof("--> BALANCE From: %s, Node: %s, To: %s", fromNode.IP, nodeIP, toNode.IP)
+
+	balanceMsg := util.BalanceMsg{
+		FromNode: *fromNode,
+		NodeIP:   nodeIP,
+	}
+	var balance float64
+
+	url := util.BuildHttpURL(h.https, toNode.IP, h.apiPort, credits.AccountEndpoint)
+
+	err, httpCode := util.DoHttpRequestJSON(ctx, h.httpClient, url, http.MethodGet, balanceMsg, &balance)
+	if err != nil {
+		return 0, NewRemoteClientError(err)
+	}
+
+	if httpCode == http.StatusOK {
+		return balance, nil
+	} else {
+		return 0, NewRemoteClientError(errors.New("impossible obtain balance"))
 	}
 }
 
@@ -218,7 +640,7 @@ func (h *httpClient) ObtainConfiguration(ctx context.Context, systemsNode *types
 	log.Infof("--> OBTAIN CONFIGS To: %s", systemsNode.IP)
 	var systemsNodeConfigsResp configuration.Configuration
 
-	url := util.BuildHttpURL(false, systemsNode.IP, h.apiPort, configREST.BaseEndpoint)
+	url := util.BuildHttpURL(h.https, systemsNode.IP, h.apiPort, configREST.BaseEndpoint)
 
 	err, httpCode := util.DoHttpRequestJSON(ctx, h.httpClient, url, http.MethodGet, nil, &systemsNodeConfigsResp)
 	if err != nil {
@@ -0,0 +1,625 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/strabox/caravela/api"
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/configuration"
+	"github.com/strabox/caravela/node/external"
+	"io"
+	"sync"
+	"time"
+)
+
+// LatencyHook returns the latency of a message sent between two nodes.
+type LatencyHook func(fromIP, toIP string) time.Duration
+
+// LossHook returns true if a message sent between two nodes must be lost.
+type LossHook func(fromIP, toIP, message string) bool
+
+// CounterHook is called for every message sent between two nodes (including the lost ones).
+type CounterHook func(fromIP, toIP, message string)
+
+// Reachability tells if a node can communicate with other node, e.g. the in-memory overlay's ring knows the
+// nodes that failed and the network partitions.
+type Reachability interface {
+	Reachable(fromIP, toIP string) bool
+}
+
+// MemoryNetwork routes the messages between nodes that live in the same process, without any network
+// communication. The nodes are registered by IP and each one uses its own client (see Client) to contact the others.
+// It keeps the context values that travel with the requests and has hooks to simulate the latency and the loss
+// of messages in each link.
+type MemoryNetwork struct {
+	nodes        map[string]api.LocalNode // Registered nodes by IP
+	reachability Reachability             // Links between the nodes (nil means all the nodes are reachable)
+	latency      LatencyHook              // Latency of each link (nil means no latency)
+	loss         LossHook                 // Loss of messages in each link (nil means no loss)
+	counter      CounterHook              // Extra counter of messages (nil means none)
+	messages     map[string]int64         // Number of messages sent by message type
+	mutex        sync.RWMutex             // Mutex to manage the network
+}
+
+// NewMemoryNetwork creates a new in-memory network without any node.
+func NewMemoryNetwork() *MemoryNetwork {
+	return &MemoryNetwork{
+		nodes:        make(map[string]api.LocalNode),
+		reachability: nil,
+		latency:      nil,
+		loss:         nil,
+		counter:      nil,
+		messages:     make(map[string]int64),
+		mutex:        sync.RWMutex{},
+	}
+}
+
+// NewMemoryNetworkOver creates a new in-memory network, without any node, whose links follow the given
+// reachability, e.g. the ring of the in-memory overlay (memory.Ring) used by the nodes. This way the failures and
+// partitions injected into the overlay also drop the messages between the nodes.
+func NewMemoryNetworkOver(reachability Reachability) *MemoryNetwork {
+	network := NewMemoryNetwork()
+	network.reachability = reachability
+	return network
+}
+
+// Register makes a node reachable through the network by its IP.
+func (n *MemoryNetwork) Register(ip string, node api.LocalNode) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.nodes[ip] = node
+}
+
+// Unregister makes a node unreachable, like if it crashed.
+func (n *MemoryNetwork) Unregister(ip string) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	delete(n.nodes, ip)
+}
+
+// SetLatencyHook sets the hook that gives the latency of each link.
+func (n *MemoryNetwork) SetLatencyHook(latency LatencyHook) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.latency = latency
+}
+
+// SetLossHook sets the hook that decides which messages are lost (besides the ones between unreachable nodes).
+func (n *MemoryNetwork) SetLossHook(loss LossHook) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.loss = loss
+}
+
+// SetCounterHook sets an extra hook that is called for every message sent.
+func (n *MemoryNetwork) SetCounterHook(counter CounterHook) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.counter = counter
+}
+
+// Messages returns the number of messages sent, by message type, since the network was created (or reset).
+func (n *MemoryNetwork) Messages() map[string]int64 {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+
+	res := make(map[string]int64, len(n.messages))
+	for message, count := range n.messages {
+		res[message] = count
+	}
+	return res
+}
+
+// ResetMessages resets the counters of messages sent.
+func (n *MemoryNetwork) ResetMessages() {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.messages = make(map[string]int64)
+}
+
+// Client returns the client used by the node with the given IP to contact the other nodes.
+func (n *MemoryNetwork) Client(ip string) external.Caravela {
+	return &memoryClient{
+		network: n,
+		localIP: ip,
+	}
+}
+
+// send delivers a message, applying the hooks of the link, and returns the destination node.
+func (n *MemoryNetwork) send(ctx context.Context, fromIP, toIP, message string) (api.LocalNode, error) {
+	n.mutex.Lock()
+	n.messages[message]++
+	counter, loss, latency, reachability := n.counter, n.loss, n.latency, n.reachability
+	node, exist := n.nodes[toIP]
+	n.mutex.Unlock()
+
+	if counter != nil {
+		counter(fromIP, toIP, message)
+	}
+
+	if latency != nil {
+		select {
+		case <-time.After(latency(fromIP, toIP)):
+		case <-ctx.Done():
+			return nil, NewRemoteClientError(ctx.Err())
+		}
+	}
+
+	if !exist || (reachability != nil && !reachability.Reachable(fromIP, toIP)) ||
+		(loss != nil && loss(fromIP, toIP, message)) {
+		return nil, NewRemoteClientError(fmt.Errorf("No connection to %s", toIP))
+	}
+	return node, nil
+}
+
+// remoteContext is the context of a request in the destination node. It keeps the deadline and the cancellation
+// of the request's context but only the values that are sent with the request.
+type remoteContext struct {
+	context.Context
+	values context.Context
+}
+
+func (r *remoteContext) Value(key interface{}) interface{} {
+	return r.values.Value(key)
+}
+
+// newRemoteContext returns the context of the request in the destination node. The user ID is only sent in the
+// requests that act in the name of an user.
+func newRemoteContext(ctx context.Context, userID string) context.Context {
+	values := context.Background()
+	if partitionsState := types.SysPartitionsState(ctx); partitionsState != nil {
+		values = context.WithValue(values, types.PartitionsStateKey, append([]types.PartitionState(nil), partitionsState...))
+	}
+	if nodeGUID := types.NodeGUID(ctx); nodeGUID != "" {
+		values = context.WithValue(values, types.NodeGUIDKey, nodeGUID)
+	}
+	if userID != "" {
+		values = context.WithValue(values, types.UserIDKey, userID)
+	}
+	return &remoteContext{Context: ctx, values: values}
+}
+
+// clone copies a message (through its JSON representation), like if it was sent through the network, so the
+// nodes never share memory.
+func clone(src, dst interface{}) error {
+	msgBytes, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(msgBytes, dst)
+}
+
+// remoteError converts the error returned by the destination node like if it was sent through the network.
+func remoteError(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch err.(type) {
+	case *types.ImageRejectedError, *types.QuotaExceededError, *types.ContainerNotFoundError: // Typed errors are sent to the client.
+		return err
+	}
+	return NewRemoteClientError(err)
+}
+
+// memoryClient is used by a node to contact the other nodes of an in-memory network.
+type memoryClient struct {
+	network *MemoryNetwork
+	localIP string // IP of the node that uses the client
+}
+
+func (m *memoryClient) CreateOffer(ctx context.Context, fromNode, toNode *types.Node, offer *types.Offer) error {
+	node, err := m.network.send(ctx, m.localIP, toNode.IP, "CREATE_OFFER")
+	if err != nil {
+		return err
+	}
+
+	var fromNodeCopy, toNodeCopy types.Node
+	var offerCopy types.Offer
+	if err := cloneAll(fromNode, &fromNodeCopy, toNode, &toNodeCopy, offer, &offerCopy); err != nil {
+		return NewRemoteClientError(err)
+	}
+
+	node.CreateOffer(newRemoteContext(ctx, ""), &fromNodeCopy, &toNodeCopy, &offerCopy)
+	return nil
+}
+
+func (m *memoryClient) RefreshOffer(ctx context.Context, fromTrader, toSupp *types.Node, offer *types.Offer) (bool, error) {
+	node, err := m.network.send(ctx, m.localIP, toSupp.IP, "REFRESH_OFFER")
+	if err != nil {
+		return false, err
+	}
+
+	var fromTraderCopy types.Node
+	var offerCopy types.Offer
+	if err := cloneAll(fromTrader, &fromTraderCopy, offer, &offerCopy); err != nil {
+		return false, NewRemoteClientError(err)
+	}
+
+	return node.RefreshOffer(newRemoteContext(ctx, ""), &fromTraderCopy, &offerCopy), nil
+}
+
+func (m *memoryClient) UpdateOffer(ctx context.Context, fromSupplier, toTrader *types.Node, offer *types.Offer) error {
+	node, err := m.network.send(ctx, m.localIP, toTrader.IP, "UPDATE_OFFER")
+	if err != nil {
+		return err
+	}
+
+	var fromSupplierCopy, toTraderCopy types.Node
+	var offerCopy types.Offer
+	if err := cloneAll(fromSupplier, &fromSupplierCopy, toTrader, &toTraderCopy, offer, &offerCopy); err != nil {
+		return NewRemoteClientError(err)
+	}
+
+	node.UpdateOffer(newRemoteContext(ctx, ""), &fromSupplierCopy, &toTraderCopy, &offerCopy)
+	return nil
+}
+
+func (m *memoryClient) RemoveOffer(ctx context.Context, fromSupp, toTrader *types.Node, offer *types.Offer) error {
+	node, err := m.network.send(ctx, m.localIP, toTrader.IP, "REMOVE_OFFER")
+	if err != nil {
+		return err
+	}
+
+	var fromSuppCopy, toTraderCopy types.Node
+	var offerCopy types.Offer
+	if err := cloneAll(fromSupp, &fromSuppCopy, toTrader, &toTraderCopy, offer, &offerCopy); err != nil {
+		return NewRemoteClientError(err)
+	}
+
+	node.RemoveOffer(newRemoteContext(ctx, ""), &fromSuppCopy, &toTraderCopy, &offerCopy)
+	return nil
+}
+
+func (m *memoryClient) GetOffers(ctx context.Context, fromNode, toTrader *types.Node, relay bool) ([]types.AvailableOffer, error) {
+	node, err := m.network.send(ctx, m.localIP, toTrader.IP, "GET_OFFERS")
+	if err != nil {
+		return nil, err
+	}
+
+	var fromNodeCopy, toTraderCopy types.Node
+	if err := cloneAll(fromNode, &fromNodeCopy, toTrader, &toTraderCopy); err != nil {
+		return nil, NewRemoteClientError(err)
+	}
+
+	var offers []types.AvailableOffer
+	res := node.GetOffers(newRemoteContext(ctx, ""), &fromNodeCopy, &toTraderCopy, relay)
+	if err := clone(res, &offers); err != nil {
+		return nil, NewRemoteClientError(err)
+	}
+	return offers, nil
+}
+
+func (m *memoryClient) AdvertiseOffersNeighbor(ctx context.Context, fromTrader, toNeighborTrader, traderOffering *types.Node) error {
+	node, err := m.network.send(ctx, m.localIP, toNeighborTrader.IP, "NEIGHBOR_OFFERS")
+	if err != nil {
+		return err
+	}
+
+	var fromTraderCopy, toNeighborTraderCopy, traderOfferingCopy types.Node
+	err = cloneAll(fromTrader, &fromTraderCopy, toNeighborTrader, &toNeighborTraderCopy, traderOffering, &traderOfferingCopy)
+	if err != nil {
+		return NewRemoteClientError(err)
+	}
+
+	node.AdvertiseOffersNeighbor(newRemoteContext(ctx, ""), &fromTraderCopy, &toNeighborTraderCopy, &traderOfferingCopy)
+	return nil
+}
+
+func (m *memoryClient) ReplicateOffers(ctx context.Context, fromTrader, toTrader *types.Node, offers []types.ReplicatedOffer) error {
+	node, err := m.network.send(ctx, m.localIP, toTrader.IP, "REPLICATE_OFFERS")
+	if err != nil {
+		return err
+	}
+
+	var fromTraderCopy, toTraderCopy types.Node
+	var offersCopy []types.ReplicatedOffer
+	if err := cloneAll(fromTrader, &fromTraderCopy, toTrader, &toTraderCopy, offers, &offersCopy); err != nil {
+		return NewRemoteClientError(err)
+	}
+
+	node.ReplicateOffers(newRemoteContext(ctx, ""), &fromTraderCopy, &toTraderCopy, offersCopy)
+	return nil
+}
+
+func (m *memoryClient) HandOverOffers(ctx context.Context, fromTrader, toTrader *types.Node, offers []types.ReplicatedOffer) error {
+	node, err := m.network.send(ctx, m.localIP, toTrader.IP, "HAND_OVER_OFFERS")
+	if err != nil {
+		return err
+	}
+
+	var fromTraderCopy, toTraderCopy types.Node
+	var offersCopy []types.ReplicatedOffer
+	if err := cloneAll(fromTrader, &fromTraderCopy, toTrader, &toTraderCopy, offers, &offersCopy); err != nil {
+		return NewRemoteClientError(err)
+	}
+
+	node.HandOverOffers(newRemoteContext(ctx, ""), &fromTraderCopy, &toTraderCopy, offersCopy)
+	return nil
+}
+
+func (m *memoryClient) ChangeOfferTrader(ctx context.Context, fromTrader, toSupplier, newTrader *types.Node,
+	offer *types.Offer) error {
+
+	node, err := m.network.send(ctx, m.localIP, toSupplier.IP, "CHANGE_OFFER_TRADER")
+	if err != nil {
+		return err
+	}
+
+	var fromTraderCopy, newTraderCopy types.Node
+	var offerCopy types.Offer
+	if err := cloneAll(fromTrader, &fromTraderCopy, newTrader, &newTraderCopy, offer, &offerCopy); err != nil {
+		return NewRemoteClientError(err)
+	}
+
+	node.ChangeOfferTrader(newRemoteContext(ctx, ""), &fromTraderCopy, &newTraderCopy, &offerCopy)
+	return nil
+}
+
+func (m *memoryClient) LaunchContainer(ctx context.Context, fromBuyer, toSupplier *types.Node, offer *types.Offer,
+	containersConfigs []types.ContainerConfig) ([]types.ContainerStatus, error) {
+
+	node, err := m.network.send(ctx, m.localIP, toSupplier.IP, "LAUNCH_CONTAINER")
+	if err != nil {
+		return nil, err
+	}
+
+	var fromBuyerCopy types.Node
+	var offerCopy types.Offer
+	var containersConfigsCopy []types.ContainerConfig
+	err = cloneAll(fromBuyer, &fromBuyerCopy, offer, &offerCopy, containersConfigs, &containersConfigsCopy)
+	if err != nil {
+		return nil, NewRemoteClientError(err)
+	}
+
+	res, err := node.LaunchContainers(newRemoteContext(ctx, types.UserID(ctx)), &fromBuyerCopy, &offerCopy,
+		containersConfigsCopy)
+	if err != nil {
+		return nil, remoteError(err)
+	}
+
+	var containersStatus []types.ContainerStatus
+	if err := clone(res, &containersStatus); err != nil {
+		return nil, NewRemoteClientError(err)
+	}
+	return containersStatus, nil
+}
+
+func (m *memoryClient) RescheduleContainer(ctx context.Context, fromSupplier, toBuyer *types.Node, containerID string) error {
+	node, err := m.network.send(ctx, m.localIP, toBuyer.IP, "RESCHEDULE_CONTAINER")
+	if err != nil {
+		return err
+	}
+
+	var fromSupplierCopy types.Node
+	if err := clone(fromSupplier, &fromSupplierCopy); err != nil {
+		return NewRemoteClientError(err)
+	}
+
+	return remoteError(node.RescheduleContainer(newRemoteContext(ctx, ""), &fromSupplierCopy, containerID))
+}
+
+func (m *memoryClient) AdoptContainer(ctx context.Context, fromSupplier, toBuyer *types.Node, userID string,
+	containerStatus *types.ContainerStatus) error {
+
+	node, err := m.network.send(ctx, m.localIP, toBuyer.IP, "ADOPT_CONTAINER")
+	if err != nil {
+		return err
+	}
+
+	var fromSupplierCopy types.Node
+	var containerStatusCopy types.ContainerStatus
+	if err := cloneAll(fromSupplier, &fromSupplierCopy, containerStatus, &containerStatusCopy); err != nil {
+		return NewRemoteClientError(err)
+	}
+
+	return remoteError(node.AdoptContainer(newRemoteContext(ctx, userID), &fromSupplierCopy, &containerStatusCopy))
+}
+
+func (m *memoryClient) StopLocalContainer(ctx context.Context, fromBuyer, toSupplier *types.Node, containerID string) error {
+	node, err := m.network.send(ctx, m.localIP, toSupplier.IP, "STOP_CONTAINER")
+	if err != nil {
+		return err
+	}
+
+	var fromBuyerCopy types.Node
+	if err := clone(fromBuyer, &fromBuyerCopy); err != nil {
+		return NewRemoteClientError(err)
+	}
+
+	return remoteError(node.StopLocalContainer(newRemoteContext(ctx, types.UserID(ctx)), &fromBuyerCopy, containerID))
+}
+
+func (m *memoryClient) AdvertiseImage(ctx context.Context, fromNode, toNode *types.Node, imageKey string) error {
+	node, err := m.network.send(ctx, m.localIP, toNode.IP, "ADVERTISE_IMAGE")
+	if err != nil {
+		return err
+	}
+
+	var fromNodeCopy types.Node
+	if err := clone(fromNode, &fromNodeCopy); err != nil {
+		return NewRemoteClientError(err)
+	}
+
+	node.AdvertiseImage(newRemoteContext(ctx, ""), &fromNodeCopy, imageKey)
+	return nil
+}
+
+func (m *memoryClient) GetImageHolders(ctx context.Context, fromNode, toNode *types.Node, imageKey string) ([]types.Node, error) {
+	node, err := m.network.send(ctx, m.localIP, toNode.IP, "IMAGE_HOLDERS")
+	if err != nil {
+		return nil, err
+	}
+
+	var fromNodeCopy types.Node
+	if err := clone(fromNode, &fromNodeCopy); err != nil {
+		return nil, NewRemoteClientError(err)
+	}
+
+	var holders []types.Node
+	if err := clone(node.ImageHolders(newRemoteContext(ctx, ""), &fromNodeCopy, imageKey), &holders); err != nil {
+		return nil, NewRemoteClientError(err)
+	}
+	return holders, nil
+}
+
+func (m *memoryClient) DownloadImage(ctx context.Context, toHolder *types.Node, imageKey string) (io.ReadCloser, error) {
+	node, err := m.network.send(ctx, m.localIP, toHolder.IP, "DOWNLOAD_IMAGE")
+	if err != nil {
+		return nil, err
+	}
+
+	imageArchive, err := node.ExportImage(newRemoteContext(ctx, ""), imageKey)
+	if err != nil {
+		return nil, NewRemoteClientError(err)
+	}
+	return imageArchive, nil
+}
+
+func (m *memoryClient) ReserveQuota(ctx context.Context, fromNode, toNode *types.Node, userID string,
+	resources types.QuotaUsage) (*types.UserQuota, error) {
+
+	node, err := m.network.send(ctx, m.localIP, toNode.IP, "RESERVE_QUOTA")
+	if err != nil {
+		return nil, err
+	}
+
+	var fromNodeCopy types.Node
+	var resourcesCopy types.QuotaUsage
+	if err := cloneAll(fromNode, &fromNodeCopy, resources, &resourcesCopy); err != nil {
+		return nil, NewRemoteClientError(err)
+	}
+
+	res, err := node.ReserveQuota(newRemoteContext(ctx, ""), &fromNodeCopy, userID, resourcesCopy)
+	if err != nil {
+		return nil, remoteError(err)
+	}
+
+	userQuota := &types.UserQuota{}
+	if err := clone(res, userQuota); err != nil {
+		return nil, NewRemoteClientError(err)
+	}
+	return userQuota, nil
+}
+
+func (m *memoryClient) ReplicateQuota(ctx context.Context, fromNode, toNode *types.Node, userID string, resources types.QuotaUsage) error {
+	node, err := m.network.send(ctx, m.localIP, toNode.IP, "REPLICATE_QUOTA")
+	if err != nil {
+		return err
+	}
+
+	var fromNodeCopy types.Node
+	var resourcesCopy types.QuotaUsage
+	if err := cloneAll(fromNode, &fromNodeCopy, resources, &resourcesCopy); err != nil {
+		return NewRemoteClientError(err)
+	}
+
+	node.ReplicateQuota(newRemoteContext(ctx, ""), &fromNodeCopy, userID, resourcesCopy)
+	return nil
+}
+
+func (m *memoryClient) GetQuota(ctx context.Context, fromNode, toNode *types.Node, userID string) (*types.UserQuota, error) {
+	node, err := m.network.send(ctx, m.localIP, toNode.IP, "GET_QUOTA")
+	if err != nil {
+		return nil, err
+	}
+
+	var fromNodeCopy types.Node
+	if err := clone(fromNode, &fromNodeCopy); err != nil {
+		return nil, NewRemoteClientError(err)
+	}
+
+	userQuota := &types.UserQuota{}
+	if err := clone(node.UserQuota(newRemoteContext(ctx, ""), &fromNodeCopy, userID), userQuota); err != nil {
+		return nil, NewRemoteClientError(err)
+	}
+	return userQuota, nil
+}
+
+func (m *memoryClient) ExchangeReceipt(ctx context.Context, fromBuyer, toSupplier *types.Node,
+	receipt *types.CreditReceipt) (*types.CreditReceipt, error) {
+
+	node, err := m.network.send(ctx, m.localIP, toSupplier.IP, "EXCHANGE_RECEIPT")
+	if err != nil {
+		return nil, err
+	}
+
+	var fromBuyerCopy types.Node
+	var receiptCopy types.CreditReceipt
+	if err := cloneAll(fromBuyer, &fromBuyerCopy, receipt, &receiptCopy); err != nil {
+		return nil, NewRemoteClientError(err)
+	}
+
+	res, err := node.ExchangeReceipt(newRemoteContext(ctx, ""), &fromBuyerCopy, &receiptCopy)
+	if err != nil {
+		return nil, remoteError(err)
+	}
+
+	countersigned := &types.CreditReceipt{}
+	if err := clone(res, countersigned); err != nil {
+		return nil, NewRemoteClientError(err)
+	}
+	return countersigned, nil
+}
+
+func (m *memoryClient) PostReceipt(ctx context.Context, fromNode, toNode *types.Node, receipt *types.CreditReceipt) error {
+	node, err := m.network.send(ctx, m.localIP, toNode.IP, "POST_RECEIPT")
+	if err != nil {
+		return err
+	}
+
+	var fromNodeCopy types.Node
+	var receiptCopy types.CreditReceipt
+	if err := cloneAll(fromNode, &fromNodeCopy, receipt, &receiptCopy); err != nil {
+		return NewRemoteClientError(err)
+	}
+
+	return remoteError(node.PostReceipt(newRemoteContext(ctx, ""), &fromNodeCopy, &receiptCopy))
+}
+
+func (m *memoryClient) GetBalance(ctx context.Context, fromNode, toNode *types.Node, nodeIP string) (float64, error) {
+	node, err := m.network.send(ctx, m.localIP, toNode.IP, "GET_BALANCE")
+	if err != nil {
+		return 0, err
+	}
+
+	var fromNodeCopy types.Node
+	if err := clone(fromNode, &fromNodeCopy); err != nil {
+		return 0, NewRemoteClientError(err)
+	}
+
+	return node.Balance(newRemoteContext(ctx, ""), &fromNodeCopy, nodeIP), nil
+}
+
+func (m *memoryClient) ObtainConfiguration(ctx context.Context, systemsNode *types.Node) (*configuration.Configuration, error) {
+	node, err := m.network.send(ctx, m.localIP, systemsNode.IP, "OBTAIN_CONFIGS")
+	if err != nil {
+		return nil, err
+	}
+
+	systemsNodeConfigs := &configuration.Configuration{}
+	if err := clone(node.Configuration(newRemoteContext(ctx, "")), systemsNodeConfigs); err != nil {
+		return nil, NewRemoteClientError(err)
+	}
+	return systemsNodeConfigs, nil
+}
+
+// cloneAll clones pairs of messages (source and destination).
+func cloneAll(msgs ...interface{}) error {
+	if len(msgs)%2 != 0 {
+		return errors.New("messages to clone must be in pairs")
+	}
+	for i := 0; i < len(msgs); i += 2 {
+		if err := clone(msgs[i], msgs[i+1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package remote
+
+import (
+	"context"
+	"github.com/strabox/caravela/api/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMemoryNetwork_RemoteContext_Values(t *testing.T) {
+	partitionsState := []types.PartitionState{{PartitionResources: types.Resources{CPUs: 1, Memory: 256}, Hits: 3}}
+	ctx := context.WithValue(context.Background(), types.PartitionsStateKey, partitionsState)
+	ctx = context.WithValue(ctx, types.NodeGUIDKey, "guid")
+	ctx = context.WithValue(ctx, types.UserIDKey, "user")
+	ctx, cancel := context.WithCancel(ctx)
+
+	remoteCtx := newRemoteContext(ctx, "")
+	assert.Equal(t, partitionsState, types.SysPartitionsState(remoteCtx), "Partitions state not sent")
+	assert.Equal(t, "guid", types.NodeGUID(remoteCtx), "Node's GUID not sent")
+	assert.Equal(t, "", types.UserID(remoteCtx), "User ID sent in a request without user")
+	assert.Equal(t, "user", types.UserID(newRemoteContext(ctx, "user")), "User ID not sent")
+
+	cancel()
+	assert.Error(t, remoteCtx.Err(), "Cancellation not propagated")
+}
+
+func TestMemoryNetwork_Send_Hooks(t *testing.T) {
+	network := NewMemoryNetwork()
+	network.Register("10.0.0.2", nil)
+
+	counted := 0
+	network.SetCounterHook(func(fromIP, toIP, message string) { counted++ })
+	network.SetLossHook(func(fromIP, toIP, message string) bool { return toIP == "10.0.0.3" })
+
+	_, err := network.send(context.Background(), "10.0.0.1", "10.0.0.2", "GET_OFFERS")
+	assert.NoError(t, err)
+	_, err = network.send(context.Background(), "10.0.0.1", "10.0.0.3", "GET_OFFERS")
+	assert.Error(t, err, "Lost message delivered")
+	assert.Equal(t, CaravelaInstanceUnavailable, err.(*Error).Code, "Lost message must look like an unavailable node")
+	_, err = network.send(context.Background(), "10.0.0.1", "10.0.0.4", "CREATE_OFFER")
+	assert.Error(t, err, "Message delivered to an unregistered node")
+
+	assert.Equal(t, 3, counted, "Wrong number of messages counted by the hook")
+	assert.Equal(t, map[string]int64{"GET_OFFERS": 2, "CREATE_OFFER": 1}, network.Messages(), "Wrong messages counters")
+	network.ResetMessages()
+	assert.Empty(t, network.Messages(), "Messages counters not reset")
+}
@@ -1,9 +1,11 @@
 package containers
 
 import (
+	"context"
 	log "github.com/Sirupsen/logrus"
 	"github.com/gorilla/mux"
 	"github.com/strabox/caravela/api/rest/util"
+	"github.com/strabox/caravela/api/types"
 	"net/http"
 )
 
@@ -23,8 +25,13 @@ func stopLocalContainer(w http.ResponseWriter, req *http.Request) (interface{},
 	if err != nil {
 		return nil, err
 	}
-	log.Infof("<-- STOP Local Container ID: %s", stopContainerMsg.ContainerID)
+	log.Infof("<-- STOP Local Container ID: %s, Buyer: %s", stopContainerMsg.ContainerID, stopContainerMsg.FromBuyer.IP)
 
-	err = nodeContainersAPI.StopLocalContainer(req.Context(), stopContainerMsg.ContainerID)
+	if err := util.CheckNodeIdentity(req, &stopContainerMsg.FromBuyer); err != nil {
+		return nil, err
+	}
+
+	ctx := context.WithValue(req.Context(), types.UserIDKey, stopContainerMsg.UserID)
+	err = nodeContainersAPI.StopLocalContainer(ctx, &stopContainerMsg.FromBuyer, stopContainerMsg.ContainerID)
 	return nil, err
 }
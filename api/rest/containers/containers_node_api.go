@@ -1,8 +1,11 @@
 package containers
 
-import "context"
+import (
+	"context"
+	"github.com/strabox/caravela/api/types"
+)
 
 // Containers API necessary to forward the REST calls
 type Containers interface {
-	StopLocalContainer(ctx context.Context, containerID string) error
+	StopLocalContainer(ctx context.Context, fromBuyer *types.Node, containerID string) error
 }
@@ -0,0 +1,68 @@
+package credits
+
+import (
+	log "github.com/Sirupsen/logrus"
+	"github.com/gorilla/mux"
+	"github.com/strabox/caravela/api/rest/util"
+	"net/http"
+)
+
+const baseEndpoint = "/credits"
+const ReceiptEndpoint = baseEndpoint + "/receipt"
+const AccountEndpoint = baseEndpoint + "/account"
+
+var nodeCreditsAPI Credits = nil
+
+func Init(router *mux.Router, nodeCredits Credits) {
+	nodeCreditsAPI = nodeCredits
+	router.Handle(ReceiptEndpoint, util.AppHandler(exchangeReceipt)).Methods(http.MethodPost)
+	router.Handle(AccountEndpoint, util.AppHandler(postReceipt)).Methods(http.MethodPost)
+	router.Handle(AccountEndpoint, util.AppHandler(balance)).Methods(http.MethodGet)
+}
+
+func exchangeReceipt(w http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var receiptMsg util.ReceiptMsg
+
+	err := util.ReceiveJSONFromHttp(w, req, &receiptMsg)
+	if err != nil {
+		return nil, err
+	}
+	if err := util.CheckNodeIdentity(req, &receiptMsg.FromNode); err != nil {
+		return nil, err
+	}
+	log.Infof("<-- EXCHANGE RECEIPT ID: %s, Credits: %.2f, From: %s", receiptMsg.Receipt.ID,
+		receiptMsg.Receipt.Credits, receiptMsg.FromNode.IP)
+
+	return nodeCreditsAPI.ExchangeReceipt(req.Context(), &receiptMsg.FromNode, &receiptMsg.Receipt)
+}
+
+func postReceipt(w http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var receiptMsg util.ReceiptMsg
+
+	err := util.ReceiveJSONFromHttp(w, req, &receiptMsg)
+	if err != nil {
+		return nil, err
+	}
+	if err := util.CheckNodeIdentity(req, &receiptMsg.FromNode); err != nil {
+		return nil, err
+	}
+	log.Infof("<-- POST RECEIPT ID: %s, Buyer: %s, Supplier: %s, From: %s", receiptMsg.Receipt.ID,
+		receiptMsg.Receipt.BuyerIP, receiptMsg.Receipt.SupplierIP, receiptMsg.FromNode.IP)
+
+	return nil, nodeCreditsAPI.PostReceipt(req.Context(), &receiptMsg.FromNode, &receiptMsg.Receipt)
+}
+
+func balance(w http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var balanceMsg util.BalanceMsg
+
+	err := util.ReceiveJSONFromHttp(w, req, &balanceMsg)
+	if err != nil {
+		return nil, err
+	}
+	if err := util.CheckNodeIdentity(req, &balanceMsg.FromNode); err != nil {
+		return nil, err
+	}
+	log.Infof("<-- BALANCE Node: %s, From: %s", balanceMsg.NodeIP, balanceMsg.FromNode.IP)
+
+	return nodeCreditsAPI.Balance(req.Context(), &balanceMsg.FromNode, balanceMsg.NodeIP), nil
+}
@@ -0,0 +1,13 @@
+package credits
+
+import (
+	"context"
+	"github.com/strabox/caravela/api/types"
+)
+
+// Credits API necessary to forward the REST calls
+type Credits interface {
+	ExchangeReceipt(ctx context.Context, fromBuyer *types.Node, receipt *types.CreditReceipt) (*types.CreditReceipt, error)
+	PostReceipt(ctx context.Context, fromNode *types.Node, receipt *types.CreditReceipt) error
+	Balance(ctx context.Context, fromNode *types.Node, nodeIP string) float64
+}
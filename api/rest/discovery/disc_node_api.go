@@ -13,4 +13,7 @@ type Discovery interface {
 	RemoveOffer(ctx context.Context, fromSupp, toTrader *types.Node, offer *types.Offer)
 	GetOffers(ctx context.Context, fromNode, toTrader *types.Node, relay bool) []types.AvailableOffer
 	AdvertiseOffersNeighbor(ctx context.Context, fromTrader, toNeighborTrader, traderOffering *types.Node)
+	ReplicateOffers(ctx context.Context, fromTrader, toTrader *types.Node, offers []types.ReplicatedOffer)
+	HandOverOffers(ctx context.Context, fromTrader, toTrader *types.Node, offers []types.ReplicatedOffer)
+	ChangeOfferTrader(ctx context.Context, fromTrader, newTrader *types.Node, offer *types.Offer)
 }
@@ -10,6 +10,9 @@ import (
 const baseEndpoint = "/discovery"
 const OfferBaseEndpoint = baseEndpoint + "/offer"
 const NeighborOfferBaseEndpoint = baseEndpoint + "/neighbor/offer"
+const ReplicaOfferBaseEndpoint = baseEndpoint + "/replica/offer"
+const HandOverOfferBaseEndpoint = baseEndpoint + "/handover/offer"
+const OfferTraderEndpoint = OfferBaseEndpoint + "/trader"
 
 var nodeDiscoveryAPI Discovery = nil
 
@@ -21,6 +24,9 @@ func Init(router *mux.Router, nodeDiscovery Discovery) {
 	router.Handle(OfferBaseEndpoint, util.AppHandler(removeOffer)).Methods(http.MethodDelete)
 	router.Handle(OfferBaseEndpoint, util.AppHandler(getOffers)).Methods(http.MethodGet)
 	router.Handle(NeighborOfferBaseEndpoint, util.AppHandler(neighborOffers)).Methods(http.MethodPatch)
+	router.Handle(ReplicaOfferBaseEndpoint, util.AppHandler(replicateOffers)).Methods(http.MethodPut)
+	router.Handle(HandOverOfferBaseEndpoint, util.AppHandler(handOverOffers)).Methods(http.MethodPut)
+	router.Handle(OfferTraderEndpoint, util.AppHandler(changeOfferTrader)).Methods(http.MethodPut)
 }
 
 func createOffer(w http.ResponseWriter, req *http.Request) (interface{}, error) {
@@ -30,6 +36,9 @@ func createOffer(w http.ResponseWriter, req *http.Request) (interface{}, error)
 	if err != nil {
 		return nil, err
 	}
+	if err := util.CheckNodeIdentity(req, &createOfferMsg.FromNode); err != nil {
+		return nil, err
+	}
 	log.Infof("<-- CREATE OFFER To: %s, ID: %d, Amt: %d, Res: <%d,%d>, From: %s",
 		createOfferMsg.ToNode.GUID[0:12], createOfferMsg.Offer.ID, createOfferMsg.Offer.Amount,
 		createOfferMsg.Offer.FreeResources.CPUs, createOfferMsg.Offer.FreeResources.Memory, createOfferMsg.FromNode.IP)
@@ -45,6 +54,9 @@ func refreshOffer(w http.ResponseWriter, req *http.Request) (interface{}, error)
 	if err != nil {
 		return nil, err
 	}
+	if err := util.CheckNodeIdentity(req, &refreshOfferMsg.FromTrader); err != nil {
+		return nil, err
+	}
 
 	log.Infof("<-- REFRESH OFFER ID: %d, From: %s", refreshOfferMsg.Offer.ID,
 		refreshOfferMsg.FromTrader.GUID[0:12])
@@ -60,6 +72,9 @@ func updateOffer(w http.ResponseWriter, req *http.Request) (interface{}, error)
 	if err != nil {
 		return nil, err
 	}
+	if err := util.CheckNodeIdentity(req, &updateOfferMsg.FromSupplier); err != nil {
+		return nil, err
+	}
 
 	log.Infof("<-- UPDATE OFFER ID: %d, From: %s, To: %s", updateOfferMsg.Offer.ID,
 		updateOfferMsg.FromSupplier.IP, updateOfferMsg.ToTrader.GUID[0:12])
@@ -75,6 +90,9 @@ func removeOffer(w http.ResponseWriter, req *http.Request) (interface{}, error)
 	if err != nil {
 		return nil, err
 	}
+	if err := util.CheckNodeIdentity(req, &offerRemoveMsg.FromSupplier); err != nil {
+		return nil, err
+	}
 
 	log.Infof("<-- REMOVE OFFER To: %s, ID: %d, From: %s", offerRemoveMsg.ToTrader.GUID[0:12],
 		offerRemoveMsg.Offer.ID, offerRemoveMsg.FromSupplier.IP)
@@ -90,6 +108,9 @@ func getOffers(w http.ResponseWriter, req *http.Request) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := util.CheckNodeIdentity(req, &getOffersMsg.FromNode); err != nil {
+		return nil, err
+	}
 	log.Infof("<-- GET OFFERS To: %s", getOffersMsg.ToTrader.GUID[0:12])
 
 	return nodeDiscoveryAPI.GetOffers(req.Context(), &getOffersMsg.FromNode, &getOffersMsg.ToTrader, getOffersMsg.Relay), nil
@@ -102,6 +123,9 @@ func neighborOffers(w http.ResponseWriter, req *http.Request) (interface{}, erro
 	if err != nil {
 		return nil, err
 	}
+	if err := util.CheckNodeIdentity(req, &neighborOffersMsg.FromNeighbor); err != nil {
+		return nil, err
+	}
 	log.Infof("<-- NEIGHBOR OFFERS To: %s, TraderOffering: <%s;%s>",
 		neighborOffersMsg.ToNeighbor.GUID[0:12], neighborOffersMsg.NeighborOffering.IP, neighborOffersMsg.NeighborOffering.GUID[0:12])
 
@@ -110,3 +134,61 @@ func neighborOffers(w http.ResponseWriter, req *http.Request) (interface{}, erro
 
 	return nil, nil
 }
+
+func replicateOffers(w http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var replicateOffersMsg util.ReplicateOffersMsg
+
+	err := util.ReceiveJSONFromHttp(w, req, &replicateOffersMsg)
+	if err != nil {
+		return nil, err
+	}
+	if err := util.CheckNodeIdentity(req, &replicateOffersMsg.FromTrader); err != nil {
+		return nil, err
+	}
+	log.Infof("<-- REPLICATE OFFERS To: %s, Offers: %d, From: <%s;%s>", replicateOffersMsg.ToTrader.GUID[0:12],
+		len(replicateOffersMsg.Offers), replicateOffersMsg.FromTrader.IP, replicateOffersMsg.FromTrader.GUID[0:12])
+
+	nodeDiscoveryAPI.ReplicateOffers(req.Context(), &replicateOffersMsg.FromTrader, &replicateOffersMsg.ToTrader,
+		replicateOffersMsg.Offers)
+
+	return nil, nil
+}
+
+func handOverOffers(w http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var handOverOffersMsg util.HandOverOffersMsg
+
+	err := util.ReceiveJSONFromHttp(w, req, &handOverOffersMsg)
+	if err != nil {
+		return nil, err
+	}
+	if err := util.CheckNodeIdentity(req, &handOverOffersMsg.FromTrader); err != nil {
+		return nil, err
+	}
+	log.Infof("<-- HAND OVER OFFERS To: %s, Offers: %d, From: <%s;%s>", handOverOffersMsg.ToTrader.GUID[0:12],
+		len(handOverOffersMsg.Offers), handOverOffersMsg.FromTrader.IP, handOverOffersMsg.FromTrader.GUID[0:12])
+
+	nodeDiscoveryAPI.HandOverOffers(req.Context(), &handOverOffersMsg.FromTrader, &handOverOffersMsg.ToTrader,
+		handOverOffersMsg.Offers)
+
+	return nil, nil
+}
+
+func changeOfferTrader(w http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var changeOfferTraderMsg util.ChangeOfferTraderMsg
+
+	err := util.ReceiveJSONFromHttp(w, req, &changeOfferTraderMsg)
+	if err != nil {
+		return nil, err
+	}
+	if err := util.CheckNodeIdentity(req, &changeOfferTraderMsg.FromTrader); err != nil {
+		return nil, err
+	}
+	log.Infof("<-- CHANGE OFFER TRADER ID: %d, NewTrader: <%s;%s>, From: %s", changeOfferTraderMsg.Offer.ID,
+		changeOfferTraderMsg.NewTrader.IP, changeOfferTraderMsg.NewTrader.GUID[0:12],
+		changeOfferTraderMsg.FromTrader.GUID[0:12])
+
+	nodeDiscoveryAPI.ChangeOfferTrader(req.Context(), &changeOfferTraderMsg.FromTrader, &changeOfferTraderMsg.NewTrader,
+		&changeOfferTraderMsg.Offer)
+
+	return nil, nil
+}
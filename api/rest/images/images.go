@@ -0,0 +1,76 @@
+package images
+
+import (
+	log "github.com/Sirupsen/logrus"
+	"github.com/gorilla/mux"
+	"github.com/strabox/caravela/api/rest/util"
+	"io"
+	"net/http"
+)
+
+const baseEndpoint = "/images"
+const HoldersBaseEndpoint = baseEndpoint + "/holders"
+const ArchiveBaseEndpoint = baseEndpoint + "/archive"
+
+var nodeImagesAPI Images = nil
+
+func Init(router *mux.Router, nodeImages Images) {
+	nodeImagesAPI = nodeImages
+	router.Handle(HoldersBaseEndpoint, util.AppHandler(advertiseImage)).Methods(http.MethodPost)
+	router.Handle(HoldersBaseEndpoint, util.AppHandler(imageHolders)).Methods(http.MethodGet)
+	router.HandleFunc(ArchiveBaseEndpoint, exportImage).Methods(http.MethodGet)
+}
+
+func advertiseImage(w http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var advertiseImageMsg util.AdvertiseImageMsg
+
+	err := util.ReceiveJSONFromHttp(w, req, &advertiseImageMsg)
+	if err != nil {
+		return nil, err
+	}
+	if err := util.CheckNodeIdentity(req, &advertiseImageMsg.FromNode); err != nil {
+		return nil, err
+	}
+	log.Infof("<-- ADVERTISE IMAGE Img: %s, From: %s", advertiseImageMsg.ImageKey, advertiseImageMsg.FromNode.IP)
+
+	nodeImagesAPI.AdvertiseImage(req.Context(), &advertiseImageMsg.FromNode, advertiseImageMsg.ImageKey)
+	return nil, nil
+}
+
+func imageHolders(w http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var imageHoldersMsg util.ImageHoldersMsg
+
+	err := util.ReceiveJSONFromHttp(w, req, &imageHoldersMsg)
+	if err != nil {
+		return nil, err
+	}
+	if err := util.CheckNodeIdentity(req, &imageHoldersMsg.FromNode); err != nil {
+		return nil, err
+	}
+	log.Infof("<-- IMAGE HOLDERS Img: %s, From: %s", imageHoldersMsg.ImageKey, imageHoldersMsg.FromNode.IP)
+
+	return nodeImagesAPI.ImageHolders(req.Context(), &imageHoldersMsg.FromNode, imageHoldersMsg.ImageKey), nil
+}
+
+// exportImage streams the image archive, so it can't use the JSON based util.AppHandler.
+func exportImage(w http.ResponseWriter, req *http.Request) {
+	var exportImageMsg util.ExportImageMsg
+
+	err := util.ReceiveJSONFromHttp(w, req, &exportImageMsg)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	log.Infof("<-- EXPORT IMAGE Img: %s", exportImageMsg.ImageKey)
+
+	imageArchive, err := nodeImagesAPI.ExportImage(req.Context(), exportImageMsg.ImageKey)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer imageArchive.Close()
+
+	w.Header().Set("Content-Type", "application/x-tar")
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, imageArchive)
+}
@@ -0,0 +1,14 @@
+package images
+
+import (
+	"context"
+	"github.com/strabox/caravela/api/types"
+	"io"
+)
+
+// Images API necessary to forward the REST calls
+type Images interface {
+	AdvertiseImage(ctx context.Context, fromNode *types.Node, imageKey string)
+	ImageHolders(ctx context.Context, fromNode *types.Node, imageKey string) []types.Node
+	ExportImage(ctx context.Context, imageKey string) (io.ReadCloser, error)
+}
@@ -0,0 +1,69 @@
+package quota
+
+import (
+	log "github.com/Sirupsen/logrus"
+	"github.com/gorilla/mux"
+	"github.com/strabox/caravela/api/rest/util"
+	"net/http"
+)
+
+const baseEndpoint = "/quota"
+const UsageEndpoint = baseEndpoint + "/usage"
+const ReplicaEndpoint = baseEndpoint + "/replica"
+
+var nodeQuotaAPI Quota = nil
+
+func Init(router *mux.Router, nodeQuota Quota) {
+	nodeQuotaAPI = nodeQuota
+	router.Handle(UsageEndpoint, util.AppHandler(reserveQuota)).Methods(http.MethodPost)
+	router.Handle(UsageEndpoint, util.AppHandler(userQuota)).Methods(http.MethodGet)
+	router.Handle(ReplicaEndpoint, util.AppHandler(replicateQuota)).Methods(http.MethodPost)
+}
+
+func reserveQuota(w http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var quotaMsg util.QuotaMsg
+
+	err := util.ReceiveJSONFromHttp(w, req, &quotaMsg)
+	if err != nil {
+		return nil, err
+	}
+	if err := util.CheckNodeIdentity(req, &quotaMsg.FromNode); err != nil {
+		return nil, err
+	}
+	log.Infof("<-- RESERVE QUOTA User: %s, Res: <%d;%d;%d>, From: %s", quotaMsg.UserID, quotaMsg.Resources.Containers,
+		quotaMsg.Resources.CPUs, quotaMsg.Resources.Memory, quotaMsg.FromNode.IP)
+
+	return nodeQuotaAPI.ReserveQuota(req.Context(), &quotaMsg.FromNode, quotaMsg.UserID, quotaMsg.Resources)
+}
+
+func replicateQuota(w http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var quotaMsg util.QuotaMsg
+
+	err := util.ReceiveJSONFromHttp(w, req, &quotaMsg)
+	if err != nil {
+		return nil, err
+	}
+	if err := util.CheckNodeIdentity(req, &quotaMsg.FromNode); err != nil {
+		return nil, err
+	}
+	log.Infof("<-- REPLICATE QUOTA User: %s, Res: <%d;%d;%d>, From: %s", quotaMsg.UserID,
+		quotaMsg.Resources.Containers, quotaMsg.Resources.CPUs, quotaMsg.Resources.Memory, quotaMsg.FromNode.IP)
+
+	nodeQuotaAPI.ReplicateQuota(req.Context(), &quotaMsg.FromNode, quotaMsg.UserID, quotaMsg.Resources)
+	return nil, nil
+}
+
+func userQuota(w http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var quotaMsg util.QuotaMsg
+
+	err := util.ReceiveJSONFromHttp(w, req, &quotaMsg)
+	if err != nil {
+		return nil, err
+	}
+	if err := util.CheckNodeIdentity(req, &quotaMsg.FromNode); err != nil {
+		return nil, err
+	}
+	log.Infof("<-- GET QUOTA User: %s, From: %s", quotaMsg.UserID, quotaMsg.FromNode.IP)
+
+	return nodeQuotaAPI.UserQuota(req.Context(), &quotaMsg.FromNode, quotaMsg.UserID), nil
+}
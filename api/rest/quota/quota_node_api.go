@@ -0,0 +1,13 @@
+package quota
+
+import (
+	"context"
+	"github.com/strabox/caravela/api/types"
+)
+
+// Quota API necessary to forward the REST calls
+type Quota interface {
+	ReserveQuota(ctx context.Context, fromNode *types.Node, userID string, resources types.QuotaUsage) (*types.UserQuota, error)
+	ReplicateQuota(ctx context.Context, fromNode *types.Node, userID string, resources types.QuotaUsage)
+	UserQuota(ctx context.Context, fromNode *types.Node, userID string) *types.UserQuota
+}
@@ -8,30 +8,64 @@ import (
 	"github.com/strabox/caravela/api"
 	"github.com/strabox/caravela/api/rest/configuration"
 	"github.com/strabox/caravela/api/rest/containers"
+	"github.com/strabox/caravela/api/rest/credits"
 	"github.com/strabox/caravela/api/rest/discovery"
+	"github.com/strabox/caravela/api/rest/images"
+	"github.com/strabox/caravela/api/rest/quota"
 	"github.com/strabox/caravela/api/rest/scheduling"
+	securityREST "github.com/strabox/caravela/api/rest/security"
 	"github.com/strabox/caravela/api/rest/user"
+	restUtil "github.com/strabox/caravela/api/rest/util"
+	"github.com/strabox/caravela/api/security"
 	"github.com/strabox/caravela/util"
+	"net"
 	"net/http"
 )
 
+// Address where the user's API is served when the nodes use mutual TLS (only reachable from the local host).
+const localUserAPIAddress = "127.0.0.1"
+
 // HttpServer handles the REST API requests in each node and redirects it to the local Node,
 // where is the logic's core.
 type HttpServer struct {
-	router     *mux.Router
-	httpServer *http.Server
+	router      *mux.Router // Routes the requests of other nodes.
+	usersRouter *mux.Router // Routes the requests of the users (that do not negotiate the protocol).
+	httpServer  *http.Server
+	localServer *http.Server // Serves the user's API to the local host when the nodes use mutual TLS.
+
+	credentials *security.Credentials // Node's credentials (nil if the nodes do not use mutual TLS).
+	authority   *security.Authority   // Cluster's CA, only if this node issues certificates to joining nodes.
 }
 
 // NewServer creates a new API HttpServer that receives the requests for the local node.
-func NewServer(port int) *HttpServer {
-	router := mux.NewRouter()
-	return &HttpServer{
-		router: router, // HTTP request endpoint router
+// With credentials the server only accepts requests from the nodes with a certificate issued by the cluster's CA.
+// The protocol is negotiated, through the given protocols, in all the requests of other nodes.
+func NewServer(hostIP string, port int, credentials *security.Credentials, authority *security.Authority,
+	protocols *restUtil.Protocols) *HttpServer {
+	router, usersRouter := mux.NewRouter(), mux.NewRouter()
+	res := &HttpServer{
+		router:      router, // HTTP request endpoint router
+		usersRouter: usersRouter,
 		httpServer: &http.Server{ // Filled when server is started
 			Addr:    fmt.Sprintf(":%d", port),
-			Handler: router,
+			Handler: routeRequests(protocols.Handler(router), usersRouter),
 		},
+		credentials: credentials,
+		authority:   authority,
+	}
+
+	if credentials != nil {
+		res.httpServer.Addr = net.JoinHostPort(hostIP, fmt.Sprintf("%d", port))
+		res.httpServer.Handler = requirePeerCertificate(routeRequests(protocols.Handler(router), usersRouter))
+		res.httpServer.TLSConfig = credentials.ServerTLSConfig()
+		if hostIP != localUserAPIAddress {
+			res.localServer = &http.Server{
+				Addr:    net.JoinHostPort(localUserAPIAddress, fmt.Sprintf("%d", port)),
+				Handler: mux.NewRouter(),
+			}
+		}
 	}
+	return res
 }
 
 // Start initializes the endpoints and starts the http web server.
@@ -41,21 +75,76 @@ func (server *HttpServer) Start(node api.LocalNode) error {
 	// Initialize all the API rest endpoints
 	configuration.Init(server.router, node)
 	containers.Init(server.router, node)
+	credits.Init(server.router, node)
 	discovery.Init(server.router, node)
+	images.Init(server.router, node)
+	quota.Init(server.router, node)
 	scheduling.Init(server.router, node)
-	user.Init(server.router, node)
+	user.Init(server.usersRouter, node)
+	if server.authority != nil {
+		securityREST.Init(server.router, server.authority)
+	}
 
 	// Starts the http web server
 	go func() {
-		if err := server.httpServer.ListenAndServe(); err != nil {
+		var err error
+		if server.credentials != nil {
+			err = server.httpServer.ListenAndServeTLS("", "")
+		} else {
+			err = server.httpServer.ListenAndServe()
+		}
+		if err != nil {
 			log.Infof(util.LogTag("[API]")+" REST API server STOPPED: %s", err)
 		}
 	}()
 
+	if server.localServer != nil {
+		user.Init(server.localServer.Handler.(*mux.Router), node)
+		go func() {
+			if err := server.localServer.ListenAndServe(); err != nil {
+				log.Infof(util.LogTag("[API]")+" REST API local server STOPPED: %s", err)
+			}
+		}()
+	}
+
 	return nil
 }
 
 // Stop the http web server
 func (server *HttpServer) Stop() {
 	go server.httpServer.Shutdown(context.Background())
+	if server.localServer != nil {
+		go server.localServer.Shutdown(context.Background())
+	}
+}
+
+// routeRequests serves the users' requests through the users' router and the requests of other nodes through the
+// nodes' handler.
+func routeRequests(nodesHandler http.Handler, usersRouter *mux.Router) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var match mux.RouteMatch
+		if usersRouter.Match(req, &match) {
+			usersRouter.ServeHTTP(w, req)
+			return
+		}
+		nodesHandler.ServeHTTP(w, req)
+	})
+}
+
+// requirePeerCertificate only lets through the requests of nodes with a verified certificate (except the joining
+// nodes asking for a certificate) and records the identity of the sender in the request's context.
+func requirePeerCertificate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == securityREST.CertificateEndpoint {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		peerIP, ok := security.PeerIP(req.TLS)
+		if !ok {
+			http.Error(w, "node certificate required", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, req.WithContext(restUtil.WithPeerIP(req.Context(), peerIP)))
+	})
 }
@@ -1,18 +1,25 @@
 package scheduling
 
 import (
+	"context"
 	log "github.com/Sirupsen/logrus"
 	"github.com/gorilla/mux"
 	"github.com/strabox/caravela/api/rest/containers"
 	"github.com/strabox/caravela/api/rest/util"
+	"github.com/strabox/caravela/api/types"
 	"net/http"
 )
 
+const RescheduleEndpoint = containers.BaseEndpoint + "/reschedule"
+const AdoptEndpoint = containers.BaseEndpoint + "/adopt"
+
 var nodeSchedulingAPI Scheduling = nil
 
 func Init(router *mux.Router, nodeScheduling Scheduling) {
 	nodeSchedulingAPI = nodeScheduling
 	router.Handle(containers.BaseEndpoint, util.AppHandler(launchContainer)).Methods(http.MethodPost)
+	router.Handle(RescheduleEndpoint, util.AppHandler(rescheduleContainer)).Methods(http.MethodPost)
+	router.Handle(AdoptEndpoint, util.AppHandler(adoptContainer)).Methods(http.MethodPost)
 }
 
 func launchContainer(w http.ResponseWriter, req *http.Request) (interface{}, error) {
@@ -23,13 +30,17 @@ func launchContainer(w http.ResponseWriter, req *http.Request) (interface{}, err
 	if err != nil {
 		return nil, err
 	}
+	if err := util.CheckNodeIdentity(req, &launchContainerMsg.FromBuyer); err != nil {
+		return nil, err
+	}
 	for i, contConfig := range launchContainerMsg.ContainersConfigs {
 		log.Infof("<-- LAUNCH [%d] From: %s, ID: %d, Img: %s, PortMaps: %v, Args: %v, Res: <<%d;%d>;%d>",
 			i, launchContainerMsg.FromBuyer.IP, launchContainerMsg.Offer.ID, contConfig.ImageKey,
 			contConfig.PortMappings, contConfig.Args, contConfig.Resources.CPUClass, contConfig.Resources.CPUs, contConfig.Resources.Memory)
 	}
 
-	containersStatus, err := nodeSchedulingAPI.LaunchContainers(req.Context(), &launchContainerMsg.FromBuyer,
+	ctx := context.WithValue(req.Context(), types.UserIDKey, launchContainerMsg.UserID)
+	containersStatus, err := nodeSchedulingAPI.LaunchContainers(ctx, &launchContainerMsg.FromBuyer,
 		&launchContainerMsg.Offer, launchContainerMsg.ContainersConfigs)
 	if err != nil {
 		return nil, err
@@ -37,3 +48,37 @@ func launchContainer(w http.ResponseWriter, req *http.Request) (interface{}, err
 
 	return containersStatus, err
 }
+
+func rescheduleContainer(w http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var rescheduleContainerMsg util.RescheduleContainerMsg
+
+	err := util.ReceiveJSONFromHttp(w, req, &rescheduleContainerMsg)
+	if err != nil {
+		return nil, err
+	}
+	if err := util.CheckNodeIdentity(req, &rescheduleContainerMsg.FromSupplier); err != nil {
+		return nil, err
+	}
+	log.Infof("<-- RESCHEDULE From: %s, ID: %s", rescheduleContainerMsg.FromSupplier.IP,
+		rescheduleContainerMsg.ContainerID)
+
+	return nil, nodeSchedulingAPI.RescheduleContainer(req.Context(), &rescheduleContainerMsg.FromSupplier,
+		rescheduleContainerMsg.ContainerID)
+}
+
+func adoptContainer(w http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var adoptContainerMsg util.AdoptContainerMsg
+
+	err := util.ReceiveJSONFromHttp(w, req, &adoptContainerMsg)
+	if err != nil {
+		return nil, err
+	}
+	if err := util.CheckNodeIdentity(req, &adoptContainerMsg.FromSupplier); err != nil {
+		return nil, err
+	}
+	log.Infof("<-- ADOPT From: %s, ID: %s, User: %s", adoptContainerMsg.FromSupplier.IP,
+		adoptContainerMsg.ContainerStatus.ContainerID, adoptContainerMsg.UserID)
+
+	ctx := context.WithValue(req.Context(), types.UserIDKey, adoptContainerMsg.UserID)
+	return nil, nodeSchedulingAPI.AdoptContainer(ctx, &adoptContainerMsg.FromSupplier, &adoptContainerMsg.ContainerStatus)
+}
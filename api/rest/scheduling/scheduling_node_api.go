@@ -8,4 +8,6 @@ import (
 type Scheduling interface {
 	LaunchContainers(ctx context.Context, fromBuyer *types.Node, offer *types.Offer,
 		containerConfig []types.ContainerConfig) ([]types.ContainerStatus, error)
+	RescheduleContainer(ctx context.Context, fromSupplier *types.Node, containerID string) error
+	AdoptContainer(ctx context.Context, fromSupplier *types.Node, containerStatus *types.ContainerStatus) error
 }
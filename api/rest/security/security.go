@@ -0,0 +1,46 @@
+package security
+
+import (
+	log "github.com/Sirupsen/logrus"
+	"github.com/gorilla/mux"
+	"github.com/strabox/caravela/api/rest/util"
+	"net"
+	"net/http"
+)
+
+const baseEndpoint = "/security"
+const CertificateEndpoint = baseEndpoint + "/certificate"
+
+var nodeSecurityAPI Security = nil
+
+func Init(router *mux.Router, nodeSecurity Security) {
+	nodeSecurityAPI = nodeSecurity
+	router.Handle(CertificateEndpoint, util.AppHandler(issueCertificate)).Methods(http.MethodPost)
+}
+
+func issueCertificate(w http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var certificateRequestMsg util.CertificateRequestMsg
+
+	err := util.ReceiveJSONFromHttp(w, req, &certificateRequestMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	requesterIP, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("<-- ISSUE CERTIFICATE From: %s", requesterIP)
+
+	certificate, ca, proof, err := nodeSecurityAPI.IssueCertificate(requesterIP, certificateRequestMsg.CSR,
+		certificateRequestMsg.Proof)
+	if err != nil {
+		return nil, err
+	}
+
+	return util.CertificateResponseMsg{
+		Certificate: certificate,
+		CA:          ca,
+		Proof:       proof,
+	}, nil
+}
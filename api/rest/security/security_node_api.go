@@ -0,0 +1,6 @@
+package security
+
+// Security is implemented by the nodes that issue certificates to the joining nodes.
+type Security interface {
+	IssueCertificate(requesterIP string, csr []byte, proof []byte) ([]byte, []byte, []byte, error)
+}
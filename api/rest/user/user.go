@@ -1,25 +1,58 @@
 package user
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	log "github.com/Sirupsen/logrus"
 	"github.com/gorilla/mux"
 	"github.com/strabox/caravela/api/rest/util"
 	"github.com/strabox/caravela/api/types"
 	"net/http"
+	"strings"
+	"time"
 )
 
 const baseEndpoint = "/user"
 const ContainerBaseEndpoint = baseEndpoint + "/container"
+const QuotaEndpoint = baseEndpoint + "/quota"
 const ExitEndpoint = baseEndpoint + "/exit"
+const AccountingEndpoint = "/accounting"
+const ReputationEndpoint = "/reputation"
+const DrainEndpoint = "/node/drain"
+const FaultsEndpoint = "/node/faults"
 
 var userNodeAPI User = nil
 
 func Init(router *mux.Router, userNode User) {
 	userNodeAPI = userNode
-	router.Handle(ContainerBaseEndpoint, util.AppHandler(runContainer)).Methods(http.MethodPost)
-	router.Handle(ContainerBaseEndpoint, util.AppHandler(stopContainers)).Methods(http.MethodDelete)
-	router.Handle(ContainerBaseEndpoint, util.AppHandler(listContainers)).Methods(http.MethodGet)
-	router.Handle(ExitEndpoint, util.AppHandler(exit)).Methods(http.MethodGet)
+	router.Handle(ContainerBaseEndpoint, authenticate(util.AppHandler(runContainer))).Methods(http.MethodPost)
+	router.Handle(ContainerBaseEndpoint, authenticate(util.AppHandler(stopContainers))).Methods(http.MethodDelete)
+	router.Handle(ContainerBaseEndpoint, authenticate(util.AppHandler(listContainers))).Methods(http.MethodGet)
+	router.Handle(QuotaEndpoint, authenticate(util.AppHandler(quota))).Methods(http.MethodGet)
+	router.Handle(AccountingEndpoint, authenticate(util.AppHandler(accounting))).Methods(http.MethodGet)
+	router.Handle(ReputationEndpoint, authenticate(util.AppHandler(reputations))).Methods(http.MethodGet)
+	router.Handle(DrainEndpoint, authenticate(util.AppHandler(drain))).Methods(http.MethodPost)
+	router.Handle(DrainEndpoint, authenticate(util.AppHandler(undrain))).Methods(http.MethodDelete)
+	router.Handle(FaultsEndpoint, authenticate(util.AppHandler(faults))).Methods(http.MethodGet)
+	router.Handle(FaultsEndpoint, authenticate(util.AppHandler(setFaults))).Methods(http.MethodPut)
+	router.Handle(ExitEndpoint, authenticate(util.AppHandler(exit))).Methods(http.MethodGet)
+}
+
+// authenticate identifies the user through the API token (Authorization: Bearer <token>) and records it in the
+// request's context.
+func authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+
+		userID, ok := userNodeAPI.Authenticate(req.Context(), token)
+		if !ok {
+			log.Infof("<-- UNAUTHENTICATED user request")
+			http.Error(w, "invalid API token", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), types.UserIDKey, userID)))
+	})
 }
 
 func runContainer(w http.ResponseWriter, req *http.Request) (interface{}, error) {
@@ -60,9 +93,105 @@ func listContainers(_ http.ResponseWriter, req *http.Request) (interface{}, erro
 	return userNodeAPI.ListContainers(req.Context()), nil
 }
 
+func quota(_ http.ResponseWriter, req *http.Request) (interface{}, error) {
+	log.Infof("<-- QUOTA User: %s", types.UserID(req.Context()))
+
+	return userNodeAPI.Quota(req.Context())
+}
+
+// accounting returns the resources used by the containers running in this node (as supplier) since the time given
+// in the since query parameter (RFC3339). Only the administrators can see the usage of all the users.
+func accounting(_ http.ResponseWriter, req *http.Request) (interface{}, error) {
+	userID := types.UserID(req.Context())
+	log.Infof("<-- ACCOUNTING User: %s, Since: %s", userID, req.URL.Query().Get("since"))
+
+	since := time.Time{}
+	if sinceParam := req.URL.Query().Get("since"); sinceParam != "" {
+		var err error
+		if since, err = time.Parse(time.RFC3339, sinceParam); err != nil {
+			return nil, fmt.Errorf("invalid since time: %s", err)
+		}
+	}
+
+	records, err := userNodeAPI.Accounting(req.Context(), since)
+	if err != nil || userNodeAPI.IsAdmin(req.Context(), userID) {
+		return records, err
+	}
+
+	userRecords := make([]types.UsageRecord, 0)
+	for _, record := range records {
+		if record.UserID == userID {
+			userRecords = append(userRecords, record)
+		}
+	}
+	return userRecords, nil
+}
+
+func reputations(_ http.ResponseWriter, req *http.Request) (interface{}, error) {
+	log.Infof("<-- REPUTATIONS")
+
+	return userNodeAPI.Reputations(req.Context()), nil
+}
+
+func drain(w http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var drainRequest types.DrainRequest
+
+	err := util.ReceiveJSONFromHttp(w, req, &drainRequest)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("<-- DRAIN Leave: %t, Timeout: %s", drainRequest.Leave, drainRequest.Timeout)
+
+	if !userNodeAPI.IsAdmin(req.Context(), types.UserID(req.Context())) {
+		return nil, errors.New("only administrators can drain the node")
+	}
+
+	return userNodeAPI.Drain(req.Context(), drainRequest.Leave, drainRequest.Timeout)
+}
+
+func undrain(_ http.ResponseWriter, req *http.Request) (interface{}, error) {
+	log.Infof("<-- UNDRAIN")
+
+	if !userNodeAPI.IsAdmin(req.Context(), types.UserID(req.Context())) {
+		return nil, errors.New("only administrators can undrain the node")
+	}
+
+	return nil, userNodeAPI.Undrain(req.Context())
+}
+
+func faults(_ http.ResponseWriter, req *http.Request) (interface{}, error) {
+	log.Infof("<-- FAULTS")
+
+	if !userNodeAPI.IsAdmin(req.Context(), types.UserID(req.Context())) {
+		return nil, errors.New("only administrators can see the injected faults")
+	}
+
+	return userNodeAPI.Faults(req.Context())
+}
+
+func setFaults(w http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var faults types.Faults
+
+	err := util.ReceiveJSONFromHttp(w, req, &faults)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("<-- SET FAULTS Enabled: %t, Rules: %d", faults.Enabled, len(faults.Rules))
+
+	if !userNodeAPI.IsAdmin(req.Context(), types.UserID(req.Context())) {
+		return nil, errors.New("only administrators can inject faults")
+	}
+
+	return nil, userNodeAPI.SetFaults(req.Context(), &faults)
+}
+
 func exit(_ http.ResponseWriter, req *http.Request) (interface{}, error) {
 	log.Infof("<-- EXITING CARAVELA")
 
+	if !userNodeAPI.IsAdmin(req.Context(), types.UserID(req.Context())) {
+		return nil, errors.New("only administrators can shut down the node")
+	}
+
 	userNodeAPI.Stop(req.Context())
 	return nil, nil
 }
@@ -3,11 +3,21 @@ package user
 import (
 	"context"
 	"github.com/strabox/caravela/api/types"
+	"time"
 )
 
 type User interface {
 	SubmitContainers(ctx context.Context, containersConfigs []types.ContainerConfig) ([]types.ContainerStatus, error)
 	ListContainers(ctx context.Context) []types.ContainerStatus
 	StopContainers(ctx context.Context, containersIDs []string) error
+	Quota(ctx context.Context) (*types.UserQuota, error)
+	Accounting(ctx context.Context, since time.Time) ([]types.UsageRecord, error)
+	Reputations(ctx context.Context) []types.SupplierReputation
+	Drain(ctx context.Context, leave bool, timeout time.Duration) (*types.DrainStatus, error)
+	Undrain(ctx context.Context) error
+	Faults(ctx context.Context) (*types.Faults, error)
+	SetFaults(ctx context.Context, faults *types.Faults) error
 	Stop(ctx context.Context)
+	Authenticate(ctx context.Context, token string) (string, bool)
+	IsAdmin(ctx context.Context, userID string) bool
 }
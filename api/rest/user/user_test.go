@@ -0,0 +1,46 @@
+package user
+
+import (
+	"context"
+	"github.com/strabox/caravela/api/types"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// userNodeStub authenticates the users with the tokens given, the other methods of the node are not used.
+type userNodeStub struct {
+	User
+	tokens map[string]string // Token->UserID
+}
+
+func (u *userNodeStub) Authenticate(_ context.Context, token string) (string, bool) {
+	userID, ok := u.tokens[token]
+	return userID, ok
+}
+
+func TestAuthenticate(t *testing.T) {
+	userNodeAPI = &userNodeStub{tokens: map[string]string{"alice-token": "alice"}}
+	requestUserID := ""
+	handler := authenticate(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requestUserID = types.UserID(req.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, ContainerBaseEndpoint, nil)
+	req.Header.Set("Authorization", "Bearer alice-token")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "alice", requestUserID, "User not recorded in the request's context")
+
+	req = httptest.NewRequest(http.MethodGet, ContainerBaseEndpoint, nil)
+	req.Header.Set("Authorization", "Bearer wrong-token")
+	recorder = httptest.NewRecorder()
+	requestUserID = "none"
+	handler.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code, "Invalid token accepted")
+	assert.Equal(t, "none", requestUserID, "Request with invalid token handled")
+}
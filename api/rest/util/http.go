@@ -7,6 +7,7 @@ import (
 	"fmt"
 	log "github.com/Sirupsen/logrus"
 	"github.com/strabox/caravela/util"
+	"io/ioutil"
 	"net/http"
 )
 
@@ -63,6 +64,33 @@ func DoHttpRequestJSON(ctx context.Context, httpClient *http.Client, url string,
 	}
 }
 
+// DoHttpRequestJSONBody sends an HTTP request with a JSON body and returns the raw response's body, useful
+// when the response's content depends on the HTTP status code.
+func DoHttpRequestJSONBody(ctx context.Context, httpClient *http.Client, url string, httpMethod string,
+	jsonToSend interface{}) ([]byte, error, int) {
+
+	req, err := http.NewRequest(httpMethod, url, ToJSONBuffer(jsonToSend))
+	if err != nil {
+		log.Errorf(util.LogTag("DoHttp")+"Error building request: %s", err)
+		return nil, err, -1
+	}
+	req = req.WithContext(ctx)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		log.Errorf(util.LogTag("DoHttp")+"HTTP error: %s", err)
+		return nil, err, -1
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		log.Errorf(util.LogTag("DoHttp")+"Reading response body error: %s", err)
+		return nil, err, resp.StatusCode
+	}
+	return body, nil, resp.StatusCode
+}
+
 // Encodes a golang struct into a buffer using JSON format.
 func ToJSONBuffer(jsonToEncode interface{}) *bytes.Buffer {
 	if jsonToEncode == nil {
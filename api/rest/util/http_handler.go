@@ -1,6 +1,7 @@
 package util
 
 import (
+	"github.com/strabox/caravela/api/types"
 	"net/http"
 )
 
@@ -10,6 +11,19 @@ type AppHandler func(http.ResponseWriter, *http.Request) (interface{}, error)
 // ServeHTTP generalizes an HTTP handler, handling generic logic to write responses and treat errors.
 func (fn AppHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if responseJSON, err := fn(w, r); err != nil { // Handler returned an error processing the HTTP request
+		if imageRejectedErr, ok := err.(*types.ImageRejectedError); ok { // Typed error, the reason goes in the body.
+			w.WriteHeader(http.StatusForbidden)
+			w.Write(ToJSONBytes(imageRejectedErr))
+			return
+		} else if quotaExceededErr, ok := err.(*types.QuotaExceededError); ok {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write(ToJSONBytes(quotaExceededErr))
+			return
+		} else if containerNotFoundErr, ok := err.(*types.ContainerNotFoundError); ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write(ToJSONBytes(containerNotFoundErr))
+			return
+		}
 		http.Error(w, err.Error(), http.StatusBadRequest)
 	} else { // All fine processing the HTTP request
 		w.WriteHeader(http.StatusOK)
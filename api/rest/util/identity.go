@@ -0,0 +1,40 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"github.com/strabox/caravela/api/types"
+	"net/http"
+)
+
+// peerIPKey is the key of the request's context value with the IP of the node that sent the request (verified
+// through its certificate).
+type peerIPKey struct{}
+
+// WithPeerIP returns a copy of the context holding the verified IP of the node that sent the request.
+func WithPeerIP(ctx context.Context, peerIP string) context.Context {
+	return context.WithValue(ctx, peerIPKey{}, peerIP)
+}
+
+// CheckNodeIdentity verifies if the node claimed in a message is the node that sent the request.
+// Without mutual TLS (no verified identity) there is nothing to verify. When the message does not claim the node's IP
+// it is filled with the verified one, so the handlers never trust an empty (unverified) sender.
+func CheckNodeIdentity(req *http.Request, claimedNode *types.Node) error {
+	peerIP, ok := VerifiedPeerIP(req)
+	if !ok {
+		return nil
+	}
+
+	if claimedNode.IP == "" {
+		claimedNode.IP = peerIP
+	} else if claimedNode.IP != peerIP {
+		return fmt.Errorf("node %s claimed to be %s", peerIP, claimedNode.IP)
+	}
+	return nil
+}
+
+// VerifiedPeerIP returns the IP of the node that sent the request, verified through its certificate.
+func VerifiedPeerIP(req *http.Request) (string, bool) {
+	peerIP, ok := req.Context().Value(peerIPKey{}).(string)
+	return peerIP, ok
+}
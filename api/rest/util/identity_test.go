@@ -0,0 +1,28 @@
+package util
+
+import (
+	"github.com/strabox/caravela/api/types"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCheckNodeIdentity_VerifiedPeer(t *testing.T) {
+	req := httptest.NewRequest("POST", "/discovery/offer", nil)
+	req = req.WithContext(WithPeerIP(req.Context(), "10.0.0.2"))
+
+	emptyClaim := types.Node{GUID: "abc"}
+	assert.NoError(t, CheckNodeIdentity(req, &emptyClaim))
+	assert.Equal(t, "10.0.0.2", emptyClaim.IP, "Empty claimed IP must be replaced by the verified one")
+
+	assert.NoError(t, CheckNodeIdentity(req, &types.Node{IP: "10.0.0.2"}))
+	assert.Error(t, CheckNodeIdentity(req, &types.Node{IP: "10.0.0.3"}), "Node impersonated other node")
+}
+
+func TestCheckNodeIdentity_NotVerified(t *testing.T) {
+	req := httptest.NewRequest("POST", "/discovery/offer", nil)
+
+	claim := types.Node{IP: "10.0.0.3"}
+	assert.NoError(t, CheckNodeIdentity(req, &claim), "Without mutual TLS there is nothing to verify")
+	assert.Equal(t, "10.0.0.3", claim.IP)
+}
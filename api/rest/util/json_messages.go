@@ -46,13 +46,30 @@ type GetOffersMsg struct {
 // Launch container struct/JSON used in the REST APIs.
 type LaunchContainerMsg struct {
 	FromBuyer         types.Node              `json:"FB"`
+	UserID            string                  `json:"UID,omitempty"` // User that owns the containers.
 	Offer             types.Offer             `json:"O"`
 	ContainersConfigs []types.ContainerConfig `json:"CC"`
 }
 
 // Stop container struct/JSON used in the REST APIs
 type StopLocalContainerMsg struct {
-	ContainerID string `json:"CId"`
+	FromBuyer   types.Node `json:"FromBuyer"`
+	ContainerID string     `json:"CId"`
+	UserID      string     `json:"UID,omitempty"` // User that owns the container.
+}
+
+// Reschedule container struct/JSON used in the REST APIs when a draining supplier asks a buyer to move a container.
+type RescheduleContainerMsg struct {
+	FromSupplier types.Node `json:"FS"`
+	ContainerID  string     `json:"CId"`
+}
+
+// Adopt container struct/JSON used in the REST APIs when a restarted supplier re-establishes the ownership of a
+// container, that kept running, with its buyer.
+type AdoptContainerMsg struct {
+	FromSupplier    types.Node            `json:"FS"`
+	UserID          string                `json:"UID,omitempty"` // User that owns the container.
+	ContainerStatus types.ContainerStatus `json:"CS"`
 }
 
 // Neighbor offer's message struct/JSON used in the REST APIs.
@@ -61,3 +78,73 @@ type NeighborOffersMsg struct {
 	ToNeighbor       types.Node `json:"TN"`
 	NeighborOffering types.Node `json:"NO"`
 }
+
+// Replicate offers struct/JSON used in the REST APIs when a trader replicates its offers into a successor.
+type ReplicateOffersMsg struct {
+	FromTrader types.Node              `json:"FT"`
+	ToTrader   types.Node              `json:"TT"`
+	Offers     []types.ReplicatedOffer `json:"O"`
+}
+
+// Hand over offers struct/JSON used in the REST APIs when a leaving trader transfers its offers into its successor.
+type HandOverOffersMsg struct {
+	FromTrader types.Node              `json:"FT"`
+	ToTrader   types.Node              `json:"TT"`
+	Offers     []types.ReplicatedOffer `json:"O"`
+}
+
+// Change offer trader struct/JSON used in the REST APIs when a leaving trader tells a supplier its offer's new trader.
+type ChangeOfferTraderMsg struct {
+	FromTrader types.Node  `json:"FT"`
+	NewTrader  types.Node  `json:"NT"`
+	Offer      types.Offer `json:"O"`
+}
+
+// Advertise image struct/JSON used in the REST APIs when a node says that it holds an image.
+type AdvertiseImageMsg struct {
+	FromNode types.Node `json:"FN"`
+	ImageKey string     `json:"IK"`
+}
+
+// Image holders struct/JSON used in the REST APIs to obtain the nodes that hold an image.
+type ImageHoldersMsg struct {
+	FromNode types.Node `json:"FN"`
+	ImageKey string     `json:"IK"`
+}
+
+// Export image struct/JSON used in the REST APIs to download an image from a node that holds it.
+type ExportImageMsg struct {
+	ImageKey string `json:"IK"`
+}
+
+// Quota struct/JSON used in the REST APIs to reserve, replicate or obtain a user's quota usage.
+type QuotaMsg struct {
+	FromNode  types.Node       `json:"FN"`
+	UserID    string           `json:"UID"`
+	Resources types.QuotaUsage `json:"Res"` // Resources reserved or replicated (negative when released).
+}
+
+// Receipt struct/JSON used in the REST APIs to exchange and post the credits' receipts.
+type ReceiptMsg struct {
+	FromNode types.Node          `json:"FN"`
+	Receipt  types.CreditReceipt `json:"R"`
+}
+
+// Balance struct/JSON used in the REST APIs to obtain the balance of a node's account.
+type BalanceMsg struct {
+	FromNode types.Node `json:"FN"`
+	NodeIP   string     `json:"NIP"`
+}
+
+// Certificate request struct/JSON used in the REST APIs when a joining node asks for its certificate.
+type CertificateRequestMsg struct {
+	CSR   []byte `json:"CSR"` // Certificate request (DER) for the joining node's IP.
+	Proof []byte `json:"P"`   // Proof of the bootstrap token knowledge bound to the certificate request.
+}
+
+// Certificate response struct/JSON used in the REST APIs to give the certificate to a joining node.
+type CertificateResponseMsg struct {
+	Certificate []byte `json:"C"`  // Node's certificate (PEM).
+	CA          []byte `json:"CA"` // Cluster's CA certificate (PEM).
+	Proof       []byte `json:"P"`  // Proof of the bootstrap token knowledge bound to the certificates.
+}
@@ -0,0 +1,213 @@
+package util
+
+import (
+	"fmt"
+	"github.com/strabox/caravela/version"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Headers, present in all the requests and responses between nodes, with the protocol spoken by the node that
+// sent them.
+const ProtocolVersionHeader = "Caravela-Protocol-Version"
+const MinProtocolVersionHeader = "Caravela-Min-Protocol-Version"
+const CapabilitiesHeader = "Caravela-Capabilities"
+
+// Time that the protocol of other node is kept, the node can be upgraded (or downgraded) meanwhile.
+const peerProtocolExpiration = 10 * time.Minute
+
+// PeerProtocol is the protocol version, the oldest version understood and the capabilities of other node.
+type PeerProtocol struct {
+	Version      int
+	MinVersion   int
+	Capabilities []string
+}
+
+// Supports returns true if the node supports the given capability.
+func (p *PeerProtocol) Supports(capability string) bool {
+	for _, peerCapability := range p.Capabilities {
+		if peerCapability == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// peerProtocolEntry is the protocol of other node and the time when it was learned.
+type peerProtocolEntry struct {
+	protocol *PeerProtocol
+	learned  time.Time
+}
+
+// Protocols negotiates the protocol with the other nodes: it advertises the local node's protocol, rejects the
+// nodes that run an incompatible one and keeps the protocol of the nodes that were contacted (or that contacted
+// the local node) for a while.
+type Protocols struct {
+	peers      map[string]*peerProtocolEntry // Protocol of the other nodes by IP
+	expiration time.Duration                 // Time that the protocol of other node is kept
+	now        func() time.Time              // Clock (replaced in the tests)
+	mutex      sync.RWMutex                  // Mutex to manage the peers' protocols
+}
+
+// NewProtocols creates a new protocols negotiator that does not know the protocol of any node.
+func NewProtocols() *Protocols {
+	return &Protocols{
+		peers:      make(map[string]*peerProtocolEntry),
+		expiration: peerProtocolExpiration,
+		now:        time.Now,
+		mutex:      sync.RWMutex{},
+	}
+}
+
+// Of returns the protocol of a node, false if it is not known (or it was learned long ago).
+func (p *Protocols) Of(ip string) (*PeerProtocol, bool) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	entry, exist := p.peers[ip]
+	if !exist || p.now().Sub(entry.learned) > p.expiration {
+		return nil, false
+	}
+	return entry.protocol, true
+}
+
+// Supports returns false only if the node is known to not support the capability.
+func (p *Protocols) Supports(ip, capability string) bool {
+	peerProtocol, exist := p.Of(ip)
+	return !exist || peerProtocol.Supports(capability)
+}
+
+func (p *Protocols) record(ip string, peerProtocol *PeerProtocol) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for peerIP, entry := range p.peers { // Forget the nodes that were not heard for a while.
+		if p.now().Sub(entry.learned) > p.expiration {
+			delete(p.peers, peerIP)
+		}
+	}
+	p.peers[ip] = &peerProtocolEntry{protocol: peerProtocol, learned: p.now()}
+}
+
+// Handler advertises the local node's protocol in all the responses and rejects the requests of the nodes that
+// run an incompatible protocol version.
+func (p *Protocols) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		setProtocolHeaders(w.Header())
+
+		peerIP, _, err := net.SplitHostPort(req.RemoteAddr)
+		if err != nil {
+			peerIP = req.RemoteAddr
+		}
+
+		peerProtocol, err := parseProtocol(req.Header)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		p.record(peerIP, peerProtocol)
+		if err := checkCompatible(peerIP, peerProtocol); err != nil {
+			http.Error(w, err.Error(), http.StatusUpgradeRequired)
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// Transport advertises the local node's protocol in all the requests sent through the given transport and fails
+// the requests to the nodes that run an incompatible protocol version (or that rejected the local node's one).
+func (p *Protocols) Transport(transport http.RoundTripper) http.RoundTripper {
+	return &protocolTransport{protocols: p, transport: transport}
+}
+
+// protocolTransport is a http.RoundTripper that negotiates the protocol with the nodes contacted.
+type protocolTransport struct {
+	protocols *Protocols
+	transport http.RoundTripper
+}
+
+func (t *protocolTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	protocolReq := req.WithContext(req.Context()) // The original request must not be modified.
+	protocolReq.Header = make(http.Header, len(req.Header)+3)
+	for key, values := range req.Header {
+		protocolReq.Header[key] = values
+	}
+	setProtocolHeaders(protocolReq.Header)
+
+	resp, err := t.transport.RoundTrip(protocolReq)
+	if err != nil {
+		return nil, err
+	}
+
+	peerIP := req.URL.Hostname()
+	if resp.StatusCode == http.StatusUpgradeRequired {
+		reason, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("node %s rejected the protocol version %d: %s", peerIP, version.ProtocolVersion,
+			strings.TrimSpace(string(reason)))
+	}
+
+	peerProtocol, err := parseProtocol(resp.Header)
+	if err != nil {
+		resp.Body.Close()
+		return nil, fmt.Errorf("node %s answered with %s", peerIP, err)
+	}
+	t.protocols.record(peerIP, peerProtocol)
+	if err := checkCompatible(peerIP, peerProtocol); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp, nil
+}
+
+// setProtocolHeaders advertises the local node's protocol in the headers of a request/response.
+func setProtocolHeaders(header http.Header) {
+	header.Set(ProtocolVersionHeader, strconv.Itoa(version.ProtocolVersion))
+	header.Set(MinProtocolVersionHeader, strconv.Itoa(version.MinProtocolVersion))
+	header.Set(CapabilitiesHeader, strings.Join(version.Capabilities(), ","))
+}
+
+// parseProtocol obtains the protocol of a node from the headers of its request/response. The nodes that do not
+// send the headers predate the protocol versions (version 1 without capabilities).
+func parseProtocol(header http.Header) (*PeerProtocol, error) {
+	versionHeader := header.Get(ProtocolVersionHeader)
+	if versionHeader == "" {
+		return &PeerProtocol{Version: 1, MinVersion: 1, Capabilities: make([]string, 0)}, nil
+	}
+
+	peerVersion, err := strconv.Atoi(versionHeader)
+	if err != nil {
+		return nil, fmt.Errorf("invalid protocol version %q", versionHeader)
+	}
+	peerMinVersion := peerVersion
+	if minVersionHeader := header.Get(MinProtocolVersionHeader); minVersionHeader != "" {
+		if peerMinVersion, err = strconv.Atoi(minVersionHeader); err != nil || peerMinVersion > peerVersion {
+			return nil, fmt.Errorf("invalid minimum protocol version %q", minVersionHeader)
+		}
+	}
+	capabilities := make([]string, 0)
+	for _, capability := range strings.Split(header.Get(CapabilitiesHeader), ",") {
+		if capability = strings.TrimSpace(capability); capability != "" {
+			capabilities = append(capabilities, capability)
+		}
+	}
+	return &PeerProtocol{Version: peerVersion, MinVersion: peerMinVersion, Capabilities: capabilities}, nil
+}
+
+// checkCompatible verifies if the local node and other node understand each other's protocol.
+func checkCompatible(ip string, peerProtocol *PeerProtocol) error {
+	if peerProtocol.Version < version.MinProtocolVersion {
+		return fmt.Errorf("node %s runs protocol version %d, the oldest supported is %d", ip, peerProtocol.Version,
+			version.MinProtocolVersion)
+	}
+	if version.ProtocolVersion < peerProtocol.MinVersion {
+		return fmt.Errorf("node %s only supports protocol versions from %d, the local node runs %d", ip,
+			peerProtocol.MinVersion, version.ProtocolVersion)
+	}
+	return nil
+}
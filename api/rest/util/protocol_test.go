@@ -0,0 +1,119 @@
+package util
+
+import (
+	"github.com/strabox/caravela/version"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestProtocols_Handler_LegacyPeer(t *testing.T) {
+	handler := NewProtocols().Handler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/discovery/offer", nil))
+
+	assert.Equal(t, http.StatusUpgradeRequired, recorder.Code, "Request of a node without protocol accepted")
+	assert.NotEmpty(t, recorder.Header().Get(ProtocolVersionHeader), "Protocol version not advertised")
+	assert.NotEmpty(t, recorder.Header().Get(MinProtocolVersionHeader), "Minimum protocol version not advertised")
+}
+
+func TestProtocols_Handler_IncompatiblePeer(t *testing.T) {
+	protocols := NewProtocols()
+	handler := protocols.Handler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodGet, "/discovery/offer", nil)
+	req.Header.Set(ProtocolVersionHeader, "0")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusUpgradeRequired, recorder.Code, "Older protocol version accepted")
+
+	req = httptest.NewRequest(http.MethodGet, "/discovery/offer", nil)
+	req.RemoteAddr = "10.0.0.2:1234"
+	req.Header.Set(ProtocolVersionHeader, strconv.Itoa(version.ProtocolVersion+2))
+	req.Header.Set(MinProtocolVersionHeader, strconv.Itoa(version.ProtocolVersion+1))
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusUpgradeRequired, recorder.Code, "Node that does not understand the local one accepted")
+	peerProtocol, known := protocols.Of("10.0.0.2")
+	assert.True(t, known, "Protocol of the node not recorded")
+	assert.Equal(t, version.ProtocolVersion+1, peerProtocol.MinVersion)
+
+	req = httptest.NewRequest(http.MethodGet, "/discovery/offer", nil)
+	req.Header.Set(ProtocolVersionHeader, strconv.Itoa(version.ProtocolVersion+1))
+	req.Header.Set(MinProtocolVersionHeader, strconv.Itoa(version.ProtocolVersion))
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code, "Newer node that understands the local one rejected")
+}
+
+func TestProtocols_Transport(t *testing.T) {
+	server := httptest.NewServer(NewProtocols().Handler(http.HandlerFunc(func(w http.ResponseWriter,
+		req *http.Request) {
+	})))
+	defer server.Close()
+	legacyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	defer legacyServer.Close()
+	protocols := NewProtocols()
+	client := &http.Client{Transport: protocols.Transport(http.DefaultTransport)}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Node with the same protocol rejected")
+	peerProtocol, known := protocols.Of("127.0.0.1")
+	assert.True(t, known, "Protocol of the node contacted not recorded")
+	assert.True(t, peerProtocol.Supports(version.CapabilityOfferHandOver))
+
+	_, err = client.Get(legacyServer.URL)
+	assert.Error(t, err, "Node without protocol accepted")
+	assert.False(t, protocols.Supports("127.0.0.1", version.CapabilityOfferHandOver),
+		"Capabilities of the node without protocol")
+}
+
+func TestProtocols_Of_Expired(t *testing.T) {
+	protocols := NewProtocols()
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	protocols.now = func() time.Time { return now }
+	protocols.record("10.0.0.2", &PeerProtocol{Version: 1, MinVersion: 1, Capabilities: make([]string, 0)})
+
+	_, known := protocols.Of("10.0.0.2")
+	assert.True(t, known)
+
+	now = now.Add(peerProtocolExpiration + time.Second)
+	_, known = protocols.Of("10.0.0.2")
+	assert.False(t, known, "Protocol of a node not heard for a while is kept")
+	assert.True(t, protocols.Supports("10.0.0.2", version.CapabilityOfferHandOver),
+		"Node with an expired protocol should be contacted again")
+
+	protocols.record("10.0.0.3", &PeerProtocol{Version: 2, MinVersion: 2, Capabilities: make([]string, 0)})
+	assert.Len(t, protocols.peers, 1, "Expired protocols not forgotten")
+}
+
+func TestParseProtocol_Capabilities(t *testing.T) {
+	header := http.Header{}
+	setProtocolHeaders(header)
+
+	peerProtocol, err := parseProtocol(header)
+	assert.NoError(t, err)
+	assert.Equal(t, version.ProtocolVersion, peerProtocol.Version, "Wrong protocol version")
+	assert.Equal(t, version.MinProtocolVersion, peerProtocol.MinVersion, "Wrong minimum protocol version")
+	assert.True(t, peerProtocol.Supports(version.CapabilityOfferReplication), "Capability not parsed")
+
+	legacyProtocol, err := parseProtocol(http.Header{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, legacyProtocol.Version, "Legacy nodes run the version 1")
+	assert.False(t, legacyProtocol.Supports(version.CapabilityOfferHandOver), "Legacy nodes have no capabilities")
+
+	header.Set(MinProtocolVersionHeader, strconv.Itoa(version.ProtocolVersion+1))
+	_, err = parseProtocol(header)
+	assert.Error(t, err, "Minimum protocol version higher than the version accepted")
+}
@@ -0,0 +1,1867 @@
+// Protocol buffers definitions of the CARAVELA's node-to-node messages sent through the gRPC transport, an
+// alternative to the JSON messages of the REST API (api/rest/util/json_messages.go).
+//
+// The request's context values travel as gRPC metadata (see metadata.go):
+//   caravela-partitions-state-bin -> PartitionsState (binary)
+//   caravela-node-guid            -> GUID of the node that sent the request
+//   caravela-user-id              -> user that owns the containers (launch/stop)
+//
+// Generate caravela.pb.go (protoc-gen-go v1.30.0) with:
+//   protoc --go_out=paths=source_relative:. caravela.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        (unknown)
+// source: caravela.proto
+
+package rpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Empty struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_caravela_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_caravela_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_caravela_proto_rawDescGZIP(), []int{0}
+}
+
+type Node struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IP   string `protobuf:"bytes,1,opt,name=IP,proto3" json:"IP,omitempty"`
+	GUID string `protobuf:"bytes,2,opt,name=GUID,proto3" json:"GUID,omitempty"`
+}
+
+func (x *Node) Reset() {
+	*x = Node{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_caravela_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Node) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Node) ProtoMessage() {}
+
+func (x *Node) ProtoReflect() protoreflect.Message {
+	mi := &file_caravela_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Node.ProtoReflect.Descriptor instead.
+func (*Node) Descriptor() ([]byte, []int) {
+	return file_caravela_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Node) GetIP() string {
+	if x != nil {
+		return x.IP
+	}
+	return ""
+}
+
+func (x *Node) GetGUID() string {
+	if x != nil {
+		return x.GUID
+	}
+	return ""
+}
+
+type Resources struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CPUClass uint32 `protobuf:"varint,1,opt,name=CPUClass,proto3" json:"CPUClass,omitempty"`
+	CPUs     int32  `protobuf:"varint,2,opt,name=CPUs,proto3" json:"CPUs,omitempty"`
+	Memory   int32  `protobuf:"varint,3,opt,name=Memory,proto3" json:"Memory,omitempty"`
+}
+
+func (x *Resources) Reset() {
+	*x = Resources{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_caravela_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Resources) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Resources) ProtoMessage() {}
+
+func (x *Resources) ProtoReflect() protoreflect.Message {
+	mi := &file_caravela_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Resources.ProtoReflect.Descriptor instead.
+func (*Resources) Descriptor() ([]byte, []int) {
+	return file_caravela_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Resources) GetCPUClass() uint32 {
+	if x != nil {
+		return x.CPUClass
+	}
+	return 0
+}
+
+func (x *Resources) GetCPUs() int32 {
+	if x != nil {
+		return x.CPUs
+	}
+	return 0
+}
+
+func (x *Resources) GetMemory() int32 {
+	if x != nil {
+		return x.Memory
+	}
+	return 0
+}
+
+type Offer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ID                int64      `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Amount            int32      `protobuf:"varint,2,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	FreeResources     *Resources `protobuf:"bytes,3,opt,name=FreeResources,proto3" json:"FreeResources,omitempty"`
+	UsedResources     *Resources `protobuf:"bytes,4,opt,name=UsedResources,proto3" json:"UsedResources,omitempty"`
+	ContainersRunning int32      `protobuf:"varint,5,opt,name=ContainersRunning,proto3" json:"ContainersRunning,omitempty"`
+	ImagesFilter      []byte     `protobuf:"bytes,6,opt,name=ImagesFilter,proto3" json:"ImagesFilter,omitempty"` // Bloom filter of the images cached in the supplier.
+}
+
+func (x *Offer) Reset() {
+	*x = Offer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_caravela_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Offer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Offer) ProtoMessage() {}
+
+func (x *Offer) ProtoReflect() protoreflect.Message {
+	mi := &file_caravela_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Offer.ProtoReflect.Descriptor instead.
+func (*Offer) Descriptor() ([]byte, []int) {
+	return file_caravela_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Offer) GetID() int64 {
+	if x != nil {
+		return x.ID
+	}
+	return 0
+}
+
+func (x *Offer) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Offer) GetFreeResources() *Resources {
+	if x != nil {
+		return x.FreeResources
+	}
+	return nil
+}
+
+func (x *Offer) GetUsedResources() *Resources {
+	if x != nil {
+		return x.UsedResources
+	}
+	return nil
+}
+
+func (x *Offer) GetContainersRunning() int32 {
+	if x != nil {
+		return x.ContainersRunning
+	}
+	return 0
+}
+
+func (x *Offer) GetImagesFilter() []byte {
+	if x != nil {
+		return x.ImagesFilter
+	}
+	return nil
+}
+
+type AvailableOffer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Offer      *Offer `protobuf:"bytes,1,opt,name=Offer,proto3" json:"Offer,omitempty"`
+	SupplierIP string `protobuf:"bytes,2,opt,name=SupplierIP,proto3" json:"SupplierIP,omitempty"`
+}
+
+func (x *AvailableOffer) Reset() {
+	*x = AvailableOffer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_caravela_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AvailableOffer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AvailableOffer) ProtoMessage() {}
+
+func (x *AvailableOffer) ProtoReflect() protoreflect.Message {
+	mi := &file_caravela_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AvailableOffer.ProtoReflect.Descriptor instead.
+func (*AvailableOffer) Descriptor() ([]byte, []int) {
+	return file_caravela_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AvailableOffer) GetOffer() *Offer {
+	if x != nil {
+		return x.Offer
+	}
+	return nil
+}
+
+func (x *AvailableOffer) GetSupplierIP() string {
+	if x != nil {
+		return x.SupplierIP
+	}
+	return ""
+}
+
+type PartitionState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PartitionResources *Resources `protobuf:"bytes,1,opt,name=PartitionResources,proto3" json:"PartitionResources,omitempty"`
+	Hits               int32      `protobuf:"varint,2,opt,name=Hits,proto3" json:"Hits,omitempty"`
+}
+
+func (x *PartitionState) Reset() {
+	*x = PartitionState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_caravela_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PartitionState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PartitionState) ProtoMessage() {}
+
+func (x *PartitionState) ProtoReflect() protoreflect.Message {
+	mi := &file_caravela_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PartitionState.ProtoReflect.Descriptor instead.
+func (*PartitionState) Descriptor() ([]byte, []int) {
+	return file_caravela_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PartitionState) GetPartitionResources() *Resources {
+	if x != nil {
+		return x.PartitionResources
+	}
+	return nil
+}
+
+func (x *PartitionState) GetHits() int32 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+// Sent in the caravela-partitions-state-bin metadata.
+type PartitionsState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Partitions []*PartitionState `protobuf:"bytes,1,rep,name=Partitions,proto3" json:"Partitions,omitempty"`
+}
+
+func (x *PartitionsState) Reset() {
+	*x = PartitionsState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_caravela_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PartitionsState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PartitionsState) ProtoMessage() {}
+
+func (x *PartitionsState) ProtoReflect() protoreflect.Message {
+	mi := &file_caravela_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PartitionsState.ProtoReflect.Descriptor instead.
+func (*PartitionsState) Descriptor() ([]byte, []int) {
+	return file_caravela_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PartitionsState) GetPartitions() []*PartitionState {
+	if x != nil {
+		return x.Partitions
+	}
+	return nil
+}
+
+type CreateOfferMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromNode *Node  `protobuf:"bytes,1,opt,name=FromNode,proto3" json:"FromNode,omitempty"`
+	ToNode   *Node  `protobuf:"bytes,2,opt,name=ToNode,proto3" json:"ToNode,omitempty"`
+	Offer    *Offer `protobuf:"bytes,3,opt,name=Offer,proto3" json:"Offer,omitempty"`
+}
+
+func (x *CreateOfferMsg) Reset() {
+	*x = CreateOfferMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_caravela_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateOfferMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOfferMsg) ProtoMessage() {}
+
+func (x *CreateOfferMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_caravela_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOfferMsg.ProtoReflect.Descriptor instead.
+func (*CreateOfferMsg) Descriptor() ([]byte, []int) {
+	return file_caravela_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateOfferMsg) GetFromNode() *Node {
+	if x != nil {
+		return x.FromNode
+	}
+	return nil
+}
+
+func (x *CreateOfferMsg) GetToNode() *Node {
+	if x != nil {
+		return x.ToNode
+	}
+	return nil
+}
+
+func (x *CreateOfferMsg) GetOffer() *Offer {
+	if x != nil {
+		return x.Offer
+	}
+	return nil
+}
+
+type RefreshOfferMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromTrader *Node  `protobuf:"bytes,1,opt,name=FromTrader,proto3" json:"FromTrader,omitempty"`
+	Offer      *Offer `protobuf:"bytes,2,opt,name=Offer,proto3" json:"Offer,omitempty"`
+}
+
+func (x *RefreshOfferMsg) Reset() {
+	*x = RefreshOfferMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_caravela_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RefreshOfferMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshOfferMsg) ProtoMessage() {}
+
+func (x *RefreshOfferMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_caravela_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshOfferMsg.ProtoReflect.Descriptor instead.
+func (*RefreshOfferMsg) Descriptor() ([]byte, []int) {
+	return file_caravela_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RefreshOfferMsg) GetFromTrader() *Node {
+	if x != nil {
+		return x.FromTrader
+	}
+	return nil
+}
+
+func (x *RefreshOfferMsg) GetOffer() *Offer {
+	if x != nil {
+		return x.Offer
+	}
+	return nil
+}
+
+type RefreshOfferResponseMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Refreshed bool `protobuf:"varint,1,opt,name=Refreshed,proto3" json:"Refreshed,omitempty"`
+}
+
+func (x *RefreshOfferResponseMsg) Reset() {
+	*x = RefreshOfferResponseMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_caravela_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RefreshOfferResponseMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshOfferResponseMsg) ProtoMessage() {}
+
+func (x *RefreshOfferResponseMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_caravela_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshOfferResponseMsg.ProtoReflect.Descriptor instead.
+func (*RefreshOfferResponseMsg) Descriptor() ([]byte, []int) {
+	return file_caravela_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RefreshOfferResponseMsg) GetRefreshed() bool {
+	if x != nil {
+		return x.Refreshed
+	}
+	return false
+}
+
+type UpdateOfferMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromSupplier *Node  `protobuf:"bytes,1,opt,name=FromSupplier,proto3" json:"FromSupplier,omitempty"`
+	ToTrader     *Node  `protobuf:"bytes,2,opt,name=ToTrader,proto3" json:"ToTrader,omitempty"`
+	Offer        *Offer `protobuf:"bytes,3,opt,name=Offer,proto3" json:"Offer,omitempty"`
+}
+
+func (x *UpdateOfferMsg) Reset() {
+	*x = UpdateOfferMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_caravela_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateOfferMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOfferMsg) ProtoMessage() {}
+
+func (x *UpdateOfferMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_caravela_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOfferMsg.ProtoReflect.Descriptor instead.
+func (*UpdateOfferMsg) Descriptor() ([]byte, []int) {
+	return file_caravela_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateOfferMsg) GetFromSupplier() *Node {
+	if x != nil {
+		return x.FromSupplier
+	}
+	return nil
+}
+
+func (x *UpdateOfferMsg) GetToTrader() *Node {
+	if x != nil {
+		return x.ToTrader
+	}
+	return nil
+}
+
+func (x *UpdateOfferMsg) GetOffer() *Offer {
+	if x != nil {
+		return x.Offer
+	}
+	return nil
+}
+
+type OfferRemoveMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromSupplier *Node  `protobuf:"bytes,1,opt,name=FromSupplier,proto3" json:"FromSupplier,omitempty"`
+	ToTrader     *Node  `protobuf:"bytes,2,opt,name=ToTrader,proto3" json:"ToTrader,omitempty"`
+	Offer        *Offer `protobuf:"bytes,3,opt,name=Offer,proto3" json:"Offer,omitempty"`
+}
+
+func (x *OfferRemoveMsg) Reset() {
+	*x = OfferRemoveMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_caravela_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OfferRemoveMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfferRemoveMsg) ProtoMessage() {}
+
+func (x *OfferRemoveMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_caravela_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfferRemoveMsg.ProtoReflect.Descriptor instead.
+func (*OfferRemoveMsg) Descriptor() ([]byte, []int) {
+	return file_caravela_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *OfferRemoveMsg) GetFromSupplier() *Node {
+	if x != nil {
+		return x.FromSupplier
+	}
+	return nil
+}
+
+func (x *OfferRemoveMsg) GetToTrader() *Node {
+	if x != nil {
+		return x.ToTrader
+	}
+	return nil
+}
+
+func (x *OfferRemoveMsg) GetOffer() *Offer {
+	if x != nil {
+		return x.Offer
+	}
+	return nil
+}
+
+type GetOffersMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromNode *Node `protobuf:"bytes,1,opt,name=FromNode,proto3" json:"FromNode,omitempty"`
+	ToTrader *Node `protobuf:"bytes,2,opt,name=ToTrader,proto3" json:"ToTrader,omitempty"`
+	Relay    bool  `protobuf:"varint,3,opt,name=Relay,proto3" json:"Relay,omitempty"`
+}
+
+func (x *GetOffersMsg) Reset() {
+	*x = GetOffersMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_caravela_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetOffersMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOffersMsg) ProtoMessage() {}
+
+func (x *GetOffersMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_caravela_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOffersMsg.ProtoReflect.Descriptor instead.
+func (*GetOffersMsg) Descriptor() ([]byte, []int) {
+	return file_caravela_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetOffersMsg) GetFromNode() *Node {
+	if x != nil {
+		return x.FromNode
+	}
+	return nil
+}
+
+func (x *GetOffersMsg) GetToTrader() *Node {
+	if x != nil {
+		return x.ToTrader
+	}
+	return nil
+}
+
+func (x *GetOffersMsg) GetRelay() bool {
+	if x != nil {
+		return x.Relay
+	}
+	return false
+}
+
+type GetOffersResponseMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Offers []*AvailableOffer `protobuf:"bytes,1,rep,name=Offers,proto3" json:"Offers,omitempty"`
+}
+
+func (x *GetOffersResponseMsg) Reset() {
+	*x = GetOffersResponseMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_caravela_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetOffersResponseMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOffersResponseMsg) ProtoMessage() {}
+
+func (x *GetOffersResponseMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_caravela_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOffersResponseMsg.ProtoReflect.Descriptor instead.
+func (*GetOffersResponseMsg) Descriptor() ([]byte, []int) {
+	return file_caravela_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetOffersResponseMsg) GetOffers() []*AvailableOffer {
+	if x != nil {
+		return x.Offers
+	}
+	return nil
+}
+
+type PortMapping struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	HostPort      int32  `protobuf:"varint,1,opt,name=HostPort,proto3" json:"HostPort,omitempty"`
+	ContainerPort int32  `protobuf:"varint,2,opt,name=ContainerPort,proto3" json:"ContainerPort,omitempty"`
+	Protocol      string `protobuf:"bytes,3,opt,name=Protocol,proto3" json:"Protocol,omitempty"`
+}
+
+func (x *PortMapping) Reset() {
+	*x = PortMapping{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_caravela_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PortMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortMapping) ProtoMessage() {}
+
+func (x *PortMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_caravela_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortMapping.ProtoReflect.Descriptor instead.
+func (*PortMapping) Descriptor() ([]byte, []int) {
+	return file_caravela_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PortMapping) GetHostPort() int32 {
+	if x != nil {
+		return x.HostPort
+	}
+	return 0
+}
+
+func (x *PortMapping) GetContainerPort() int32 {
+	if x != nil {
+		return x.ContainerPort
+	}
+	return 0
+}
+
+func (x *PortMapping) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+type RegistryAuth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServerAddress string `protobuf:"bytes,1,opt,name=ServerAddress,proto3" json:"ServerAddress,omitempty"`
+	Username      string `protobuf:"bytes,2,opt,name=Username,proto3" json:"Username,omitempty"`
+	Password      string `protobuf:"bytes,3,opt,name=Password,proto3" json:"Password,omitempty"`
+}
+
+func (x *RegistryAuth) Reset() {
+	*x = RegistryAuth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_caravela_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegistryAuth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistryAuth) ProtoMessage() {}
+
+func (x *RegistryAuth) ProtoReflect() protoreflect.Message {
+	mi := &file_caravela_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistryAuth.ProtoReflect.Descriptor instead.
+func (*RegistryAuth) Descriptor() ([]byte, []int) {
+	return file_caravela_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RegistryAuth) GetServerAddress() string {
+	if x != nil {
+		return x.ServerAddress
+	}
+	return ""
+}
+
+func (x *RegistryAuth) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *RegistryAuth) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type ContainerConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name           string         `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	ImageKey       string         `protobuf:"bytes,2,opt,name=ImageKey,proto3" json:"ImageKey,omitempty"`
+	Args           []string       `protobuf:"bytes,3,rep,name=Args,proto3" json:"Args,omitempty"`
+	PortMappings   []*PortMapping `protobuf:"bytes,4,rep,name=PortMappings,proto3" json:"PortMappings,omitempty"`
+	Resources      *Resources     `protobuf:"bytes,5,opt,name=Resources,proto3" json:"Resources,omitempty"`
+	GroupPolicy    uint32         `protobuf:"varint,6,opt,name=GroupPolicy,proto3" json:"GroupPolicy,omitempty"`
+	RegistryAuth   *RegistryAuth  `protobuf:"bytes,7,opt,name=RegistryAuth,proto3" json:"RegistryAuth,omitempty"` // Only sent in the launch requests.
+	ImageSignature string         `protobuf:"bytes,8,opt,name=ImageSignature,proto3" json:"ImageSignature,omitempty"`
+}
+
+func (x *ContainerConfig) Reset() {
+	*x = ContainerConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_caravela_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ContainerConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerConfig) ProtoMessage() {}
+
+func (x *ContainerConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_caravela_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerConfig.ProtoReflect.Descriptor instead.
+func (*ContainerConfig) Descriptor() ([]byte, []int) {
+	return file_caravela_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ContainerConfig) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ContainerConfig) GetImageKey() string {
+	if x != nil {
+		return x.ImageKey
+	}
+	return ""
+}
+
+func (x *ContainerConfig) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *ContainerConfig) GetPortMappings() []*PortMapping {
+	if x != nil {
+		return x.PortMappings
+	}
+	return nil
+}
+
+func (x *ContainerConfig) GetResources() *Resources {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *ContainerConfig) GetGroupPolicy() uint32 {
+	if x != nil {
+		return x.GroupPolicy
+	}
+	return 0
+}
+
+func (x *ContainerConfig) GetRegistryAuth() *RegistryAuth {
+	if x != nil {
+		return x.RegistryAuth
+	}
+	return nil
+}
+
+func (x *ContainerConfig) GetImageSignature() string {
+	if x != nil {
+		return x.ImageSignature
+	}
+	return ""
+}
+
+type ContainerStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ContainerConfig *ContainerConfig `protobuf:"bytes,1,opt,name=ContainerConfig,proto3" json:"ContainerConfig,omitempty"`
+	SupplierIP      string           `protobuf:"bytes,2,opt,name=SupplierIP,proto3" json:"SupplierIP,omitempty"`
+	ContainerID     string           `protobuf:"bytes,3,opt,name=ContainerID,proto3" json:"ContainerID,omitempty"`
+	Status          string           `protobuf:"bytes,4,opt,name=Status,proto3" json:"Status,omitempty"`
+}
+
+func (x *ContainerStatus) Reset() {
+	*x = ContainerStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_caravela_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ContainerStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerStatus) ProtoMessage() {}
+
+func (x *ContainerStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_caravela_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerStatus.ProtoReflect.Descriptor instead.
+func (*ContainerStatus) Descriptor() ([]byte, []int) {
+	return file_caravela_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ContainerStatus) GetContainerConfig() *ContainerConfig {
+	if x != nil {
+		return x.ContainerConfig
+	}
+	return nil
+}
+
+func (x *ContainerStatus) GetSupplierIP() string {
+	if x != nil {
+		return x.SupplierIP
+	}
+	return ""
+}
+
+func (x *ContainerStatus) GetContainerID() string {
+	if x != nil {
+		return x.ContainerID
+	}
+	return ""
+}
+
+func (x *ContainerStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type LaunchContainerMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromBuyer         *Node              `protobuf:"bytes,1,opt,name=FromBuyer,proto3" json:"FromBuyer,omitempty"`
+	Offer             *Offer             `protobuf:"bytes,2,opt,name=Offer,proto3" json:"Offer,omitempty"`
+	ContainersConfigs []*ContainerConfig `protobuf:"bytes,3,rep,name=ContainersConfigs,proto3" json:"ContainersConfigs,omitempty"`
+}
+
+func (x *LaunchContainerMsg) Reset() {
+	*x = LaunchContainerMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_caravela_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LaunchContainerMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LaunchContainerMsg) ProtoMessage() {}
+
+func (x *LaunchContainerMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_caravela_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LaunchContainerMsg.ProtoReflect.Descriptor instead.
+func (*LaunchContainerMsg) Descriptor() ([]byte, []int) {
+	return file_caravela_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *LaunchContainerMsg) GetFromBuyer() *Node {
+	if x != nil {
+		return x.FromBuyer
+	}
+	return nil
+}
+
+func (x *LaunchContainerMsg) GetOffer() *Offer {
+	if x != nil {
+		return x.Offer
+	}
+	return nil
+}
+
+func (x *LaunchContainerMsg) GetContainersConfigs() []*ContainerConfig {
+	if x != nil {
+		return x.ContainersConfigs
+	}
+	return nil
+}
+
+type LaunchContainerResponseMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ContainersStatus []*ContainerStatus `protobuf:"bytes,1,rep,name=ContainersStatus,proto3" json:"ContainersStatus,omitempty"`
+}
+
+func (x *LaunchContainerResponseMsg) Reset() {
+	*x = LaunchContainerResponseMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_caravela_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LaunchContainerResponseMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LaunchContainerResponseMsg) ProtoMessage() {}
+
+func (x *LaunchContainerResponseMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_caravela_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LaunchContainerResponseMsg.ProtoReflect.Descriptor instead.
+func (*LaunchContainerResponseMsg) Descriptor() ([]byte, []int) {
+	return file_caravela_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *LaunchContainerResponseMsg) GetContainersStatus() []*ContainerStatus {
+	if x != nil {
+		return x.ContainersStatus
+	}
+	return nil
+}
+
+type StopLocalContainerMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromBuyer   *Node  `protobuf:"bytes,1,opt,name=FromBuyer,proto3" json:"FromBuyer,omitempty"`
+	ContainerID string `protobuf:"bytes,2,opt,name=ContainerID,proto3" json:"ContainerID,omitempty"`
+}
+
+func (x *StopLocalContainerMsg) Reset() {
+	*x = StopLocalContainerMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_caravela_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StopLocalContainerMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopLocalContainerMsg) ProtoMessage() {}
+
+func (x *StopLocalContainerMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_caravela_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopLocalContainerMsg.ProtoReflect.Descriptor instead.
+func (*StopLocalContainerMsg) Descriptor() ([]byte, []int) {
+	return file_caravela_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *StopLocalContainerMsg) GetFromBuyer() *Node {
+	if x != nil {
+		return x.FromBuyer
+	}
+	return nil
+}
+
+func (x *StopLocalContainerMsg) GetContainerID() string {
+	if x != nil {
+		return x.ContainerID
+	}
+	return ""
+}
+
+var File_caravela_proto protoreflect.FileDescriptor
+
+var file_caravela_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x08, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x22, 0x2a, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x49,
+	0x50, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x50, 0x12, 0x12, 0x0a, 0x04, 0x47,
+	0x55, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x47, 0x55, 0x49, 0x44, 0x22,
+	0x53, 0x0a, 0x09, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x43, 0x50, 0x55, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08,
+	0x43, 0x50, 0x55, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x43, 0x50, 0x55, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x43, 0x50, 0x55, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x4d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x22, 0xf7, 0x01, 0x0a, 0x05, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x12, 0x0e,
+	0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x49, 0x44, 0x12, 0x16,
+	0x0a, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x39, 0x0a, 0x0d, 0x46, 0x72, 0x65, 0x65, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x73, 0x52, 0x0d, 0x46, 0x72, 0x65, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x73, 0x12, 0x39, 0x0a, 0x0d, 0x55, 0x73, 0x65, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76,
+	0x65, 0x6c, 0x61, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x0d, 0x55,
+	0x73, 0x65, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x11,
+	0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x52, 0x75, 0x6e, 0x6e, 0x69, 0x6e,
+	0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x73, 0x52, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x22, 0x0a, 0x0c, 0x49, 0x6d,
+	0x61, 0x67, 0x65, 0x73, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0c, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x57,
+	0x0a, 0x0e, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72,
+	0x12, 0x25, 0x0a, 0x05, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e, 0x4f, 0x66, 0x66, 0x65, 0x72,
+	0x52, 0x05, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x75, 0x70, 0x70, 0x6c,
+	0x69, 0x65, 0x72, 0x49, 0x50, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x75, 0x70,
+	0x70, 0x6c, 0x69, 0x65, 0x72, 0x49, 0x50, 0x22, 0x69, 0x0a, 0x0e, 0x50, 0x61, 0x72, 0x74, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x43, 0x0a, 0x12, 0x50, 0x61, 0x72,
+	0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61,
+	0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x12, 0x50, 0x61, 0x72, 0x74,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x48, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x48, 0x69,
+	0x74, 0x73, 0x22, 0x4b, 0x0a, 0x0f, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x38, 0x0a, 0x0a, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x61, 0x72, 0x61,
+	0x76, 0x65, 0x6c, 0x61, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x52, 0x0a, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22,
+	0x8b, 0x01, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x4d,
+	0x73, 0x67, 0x12, 0x2a, 0x0a, 0x08, 0x46, 0x72, 0x6f, 0x6d, 0x4e, 0x6f, 0x64, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e,
+	0x4e, 0x6f, 0x64, 0x65, 0x52, 0x08, 0x46, 0x72, 0x6f, 0x6d, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x26,
+	0x0a, 0x06, 0x54, 0x6f, 0x4e, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e,
+	0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x06,
+	0x54, 0x6f, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x25, 0x0a, 0x05, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61,
+	0x2e, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x05, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x22, 0x68, 0x0a,
+	0x0f, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x4d, 0x73, 0x67,
+	0x12, 0x2e, 0x0a, 0x0a, 0x46, 0x72, 0x6f, 0x6d, 0x54, 0x72, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e,
+	0x4e, 0x6f, 0x64, 0x65, 0x52, 0x0a, 0x46, 0x72, 0x6f, 0x6d, 0x54, 0x72, 0x61, 0x64, 0x65, 0x72,
+	0x12, 0x25, 0x0a, 0x05, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e, 0x4f, 0x66, 0x66, 0x65, 0x72,
+	0x52, 0x05, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x22, 0x37, 0x0a, 0x17, 0x52, 0x65, 0x66, 0x72, 0x65,
+	0x73, 0x68, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d,
+	0x73, 0x67, 0x12, 0x1c, 0x0a, 0x09, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x65, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x65, 0x64,
+	0x22, 0x97, 0x01, 0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72,
+	0x4d, 0x73, 0x67, 0x12, 0x32, 0x0a, 0x0c, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x75, 0x70, 0x70, 0x6c,
+	0x69, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x63, 0x61, 0x72, 0x61,
+	0x76, 0x65, 0x6c, 0x61, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x0c, 0x46, 0x72, 0x6f, 0x6d, 0x53,
+	0x75, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x08, 0x54, 0x6f, 0x54, 0x72, 0x61,
+	0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x63, 0x61, 0x72, 0x61,
+	0x76, 0x65, 0x6c, 0x61, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x08, 0x54, 0x6f, 0x54, 0x72, 0x61,
+	0x64, 0x65, 0x72, 0x12, 0x25, 0x0a, 0x05, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e, 0x4f, 0x66,
+	0x66, 0x65, 0x72, 0x52, 0x05, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x22, 0x97, 0x01, 0x0a, 0x0e, 0x4f,
+	0x66, 0x66, 0x65, 0x72, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4d, 0x73, 0x67, 0x12, 0x32, 0x0a,
+	0x0c, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e, 0x4e,
+	0x6f, 0x64, 0x65, 0x52, 0x0c, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x65,
+	0x72, 0x12, 0x2a, 0x0a, 0x08, 0x54, 0x6f, 0x54, 0x72, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e, 0x4e,
+	0x6f, 0x64, 0x65, 0x52, 0x08, 0x54, 0x6f, 0x54, 0x72, 0x61, 0x64, 0x65, 0x72, 0x12, 0x25, 0x0a,
+	0x05, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x63,
+	0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x05, 0x4f,
+	0x66, 0x66, 0x65, 0x72, 0x22, 0x7c, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4f, 0x66, 0x66, 0x65, 0x72,
+	0x73, 0x4d, 0x73, 0x67, 0x12, 0x2a, 0x0a, 0x08, 0x46, 0x72, 0x6f, 0x6d, 0x4e, 0x6f, 0x64, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c,
+	0x61, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x08, 0x46, 0x72, 0x6f, 0x6d, 0x4e, 0x6f, 0x64, 0x65,
+	0x12, 0x2a, 0x0a, 0x08, 0x54, 0x6f, 0x54, 0x72, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e, 0x4e, 0x6f,
+	0x64, 0x65, 0x52, 0x08, 0x54, 0x6f, 0x54, 0x72, 0x61, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05,
+	0x52, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x52, 0x65, 0x6c,
+	0x61, 0x79, 0x22, 0x48, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x73, 0x67, 0x12, 0x30, 0x0a, 0x06, 0x4f, 0x66,
+	0x66, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x61, 0x72,
+	0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x4f,
+	0x66, 0x66, 0x65, 0x72, 0x52, 0x06, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x22, 0x6b, 0x0a, 0x0b,
+	0x50, 0x6f, 0x72, 0x74, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x48,
+	0x6f, 0x73, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x48,
+	0x6f, 0x73, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d,
+	0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x22, 0x6c, 0x0a, 0x0c, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x72, 0x79, 0x41, 0x75, 0x74, 0x68, 0x12, 0x24, 0x0a, 0x0d, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x55, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x55, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x50,
+	0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x50,
+	0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0xc9, 0x02, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x4e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x41,
+	0x72, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x41, 0x72, 0x67, 0x73, 0x12,
+	0x39, 0x0a, 0x0c, 0x50, 0x6f, 0x72, 0x74, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61,
+	0x2e, 0x50, 0x6f, 0x72, 0x74, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x52, 0x0c, 0x50, 0x6f,
+	0x72, 0x74, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x31, 0x0a, 0x09, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x73, 0x52, 0x09, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x20, 0x0a,
+	0x0b, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0b, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12,
+	0x3a, 0x0a, 0x0c, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x41, 0x75, 0x74, 0x68, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61,
+	0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x41, 0x75, 0x74, 0x68, 0x52, 0x0c, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x41, 0x75, 0x74, 0x68, 0x12, 0x26, 0x0a, 0x0e, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x22, 0xb0, 0x01, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x43, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e, 0x43, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0f, 0x43, 0x6f, 0x6e,
+	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1e, 0x0a, 0x0a,
+	0x53, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x49, 0x50, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x53, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x49, 0x50, 0x12, 0x20, 0x0a, 0x0b,
+	0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x44, 0x12, 0x16,
+	0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xb2, 0x01, 0x0a, 0x12, 0x4c, 0x61, 0x75, 0x6e, 0x63,
+	0x68, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x4d, 0x73, 0x67, 0x12, 0x2c, 0x0a,
+	0x09, 0x46, 0x72, 0x6f, 0x6d, 0x42, 0x75, 0x79, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
+	0x52, 0x09, 0x46, 0x72, 0x6f, 0x6d, 0x42, 0x75, 0x79, 0x65, 0x72, 0x12, 0x25, 0x0a, 0x05, 0x4f,
+	0x66, 0x66, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x63, 0x61, 0x72,
+	0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x05, 0x4f, 0x66, 0x66,
+	0x65, 0x72, 0x12, 0x47, 0x0a, 0x11, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x11, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x22, 0x63, 0x0a, 0x1a, 0x4c,
+	0x61, 0x75, 0x6e, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x73, 0x67, 0x12, 0x45, 0x0a, 0x10, 0x43, 0x6f, 0x6e,
+	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e, 0x43,
+	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x10,
+	0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x22, 0x67, 0x0a, 0x15, 0x53, 0x74, 0x6f, 0x70, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x43, 0x6f, 0x6e,
+	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x4d, 0x73, 0x67, 0x12, 0x2c, 0x0a, 0x09, 0x46, 0x72, 0x6f,
+	0x6d, 0x42, 0x75, 0x79, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x63,
+	0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x46, 0x72,
+	0x6f, 0x6d, 0x42, 0x75, 0x79, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x44, 0x32, 0xea, 0x03, 0x0a, 0x08, 0x43, 0x61,
+	0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x12, 0x38, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x4f, 0x66, 0x66, 0x65, 0x72, 0x12, 0x18, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x4d, 0x73, 0x67, 0x1a,
+	0x0f, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x12, 0x4c, 0x0a, 0x0c, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x4f, 0x66, 0x66, 0x65, 0x72,
+	0x12, 0x19, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e, 0x52, 0x65, 0x66, 0x72,
+	0x65, 0x73, 0x68, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x4d, 0x73, 0x67, 0x1a, 0x21, 0x2e, 0x63, 0x61,
+	0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x4f, 0x66,
+	0x66, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x73, 0x67, 0x12, 0x38,
+	0x0a, 0x0b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x12, 0x18, 0x2e,
+	0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4f,
+	0x66, 0x66, 0x65, 0x72, 0x4d, 0x73, 0x67, 0x1a, 0x0f, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65,
+	0x6c, 0x61, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x38, 0x0a, 0x0b, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x12, 0x18, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65,
+	0x6c, 0x61, 0x2e, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4d, 0x73,
+	0x67, 0x1a, 0x0f, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x12, 0x43, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x12,
+	0x16, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x66,
+	0x66, 0x65, 0x72, 0x73, 0x4d, 0x73, 0x67, 0x1a, 0x1e, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65,
+	0x6c, 0x61, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x73, 0x67, 0x12, 0x55, 0x0a, 0x0f, 0x4c, 0x61, 0x75, 0x6e, 0x63,
+	0x68, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x1c, 0x2e, 0x63, 0x61, 0x72,
+	0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e, 0x4c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x4d, 0x73, 0x67, 0x1a, 0x24, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76,
+	0x65, 0x6c, 0x61, 0x2e, 0x4c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x73, 0x67, 0x12, 0x46,
+	0x0a, 0x12, 0x53, 0x74, 0x6f, 0x70, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x12, 0x1f, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61, 0x2e,
+	0x53, 0x74, 0x6f, 0x70, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x4d, 0x73, 0x67, 0x1a, 0x0f, 0x2e, 0x63, 0x61, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x61,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x42, 0x25, 0x5a, 0x23, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x74, 0x72, 0x61, 0x62, 0x6f, 0x78, 0x2f, 0x63, 0x61, 0x72,
+	0x61, 0x76, 0x65, 0x6c, 0x61, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_caravela_proto_rawDescOnce sync.Once
+	file_caravela_proto_rawDescData = file_caravela_proto_rawDesc
+)
+
+func file_caravela_proto_rawDescGZIP() []byte {
+	file_caravela_proto_rawDescOnce.Do(func() {
+		file_caravela_proto_rawDescData = protoimpl.X.CompressGZIP(file_caravela_proto_rawDescData)
+	})
+	return file_caravela_proto_rawDescData
+}
+
+var file_caravela_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_caravela_proto_goTypes = []interface{}{
+	(*Empty)(nil),                      // 0: caravela.Empty
+	(*Node)(nil),                       // 1: caravela.Node
+	(*Resources)(nil),                  // 2: caravela.Resources
+	(*Offer)(nil),                      // 3: caravela.Offer
+	(*AvailableOffer)(nil),             // 4: caravela.AvailableOffer
+	(*PartitionState)(nil),             // 5: caravela.PartitionState
+	(*PartitionsState)(nil),            // 6: caravela.PartitionsState
+	(*CreateOfferMsg)(nil),             // 7: caravela.CreateOfferMsg
+	(*RefreshOfferMsg)(nil),            // 8: caravela.RefreshOfferMsg
+	(*RefreshOfferResponseMsg)(nil),    // 9: caravela.RefreshOfferResponseMsg
+	(*UpdateOfferMsg)(nil),             // 10: caravela.UpdateOfferMsg
+	(*OfferRemoveMsg)(nil),             // 11: caravela.OfferRemoveMsg
+	(*GetOffersMsg)(nil),               // 12: caravela.GetOffersMsg
+	(*GetOffersResponseMsg)(nil),       // 13: caravela.GetOffersResponseMsg
+	(*PortMapping)(nil),                // 14: caravela.PortMapping
+	(*RegistryAuth)(nil),               // 15: caravela.RegistryAuth
+	(*ContainerConfig)(nil),            // 16: caravela.ContainerConfig
+	(*ContainerStatus)(nil),            // 17: caravela.ContainerStatus
+	(*LaunchContainerMsg)(nil),         // 18: caravela.LaunchContainerMsg
+	(*LaunchContainerResponseMsg)(nil), // 19: caravela.LaunchContainerResponseMsg
+	(*StopLocalContainerMsg)(nil),      // 20: caravela.StopLocalContainerMsg
+}
+var file_caravela_proto_depIdxs = []int32{
+	2,  // 0: caravela.Offer.FreeResources:type_name -> caravela.Resources
+	2,  // 1: caravela.Offer.UsedResources:type_name -> caravela.Resources
+	3,  // 2: caravela.AvailableOffer.Offer:type_name -> caravela.Offer
+	2,  // 3: caravela.PartitionState.PartitionResources:type_name -> caravela.Resources
+	5,  // 4: caravela.PartitionsState.Partitions:type_name -> caravela.PartitionState
+	1,  // 5: caravela.CreateOfferMsg.FromNode:type_name -> caravela.Node
+	1,  // 6: caravela.CreateOfferMsg.ToNode:type_name -> caravela.Node
+	3,  // 7: caravela.CreateOfferMsg.Offer:type_name -> caravela.Offer
+	1,  // 8: caravela.RefreshOfferMsg.FromTrader:type_name -> caravela.Node
+	3,  // 9: caravela.RefreshOfferMsg.Offer:type_name -> caravela.Offer
+	1,  // 10: caravela.UpdateOfferMsg.FromSupplier:type_name -> caravela.Node
+	1,  // 11: caravela.UpdateOfferMsg.ToTrader:type_name -> caravela.Node
+	3,  // 12: caravela.UpdateOfferMsg.Offer:type_name -> caravela.Offer
+	1,  // 13: caravela.OfferRemoveMsg.FromSupplier:type_name -> caravela.Node
+	1,  // 14: caravela.OfferRemoveMsg.ToTrader:type_name -> caravela.Node
+	3,  // 15: caravela.OfferRemoveMsg.Offer:type_name -> caravela.Offer
+	1,  // 16: caravela.GetOffersMsg.FromNode:type_name -> caravela.Node
+	1,  // 17: caravela.GetOffersMsg.ToTrader:type_name -> caravela.Node
+	4,  // 18: caravela.GetOffersResponseMsg.Offers:type_name -> caravela.AvailableOffer
+	14, // 19: caravela.ContainerConfig.PortMappings:type_name -> caravela.PortMapping
+	2,  // 20: caravela.ContainerConfig.Resources:type_name -> caravela.Resources
+	15, // 21: caravela.ContainerConfig.RegistryAuth:type_name -> caravela.RegistryAuth
+	16, // 22: caravela.ContainerStatus.ContainerConfig:type_name -> caravela.ContainerConfig
+	1,  // 23: caravela.LaunchContainerMsg.FromBuyer:type_name -> caravela.Node
+	3,  // 24: caravela.LaunchContainerMsg.Offer:type_name -> caravela.Offer
+	16, // 25: caravela.LaunchContainerMsg.ContainersConfigs:type_name -> caravela.ContainerConfig
+	17, // 26: caravela.LaunchContainerResponseMsg.ContainersStatus:type_name -> caravela.ContainerStatus
+	1,  // 27: caravela.StopLocalContainerMsg.FromBuyer:type_name -> caravela.Node
+	7,  // 28: caravela.Caravela.CreateOffer:input_type -> caravela.CreateOfferMsg
+	8,  // 29: caravela.Caravela.RefreshOffer:input_type -> caravela.RefreshOfferMsg
+	10, // 30: caravela.Caravela.UpdateOffer:input_type -> caravela.UpdateOfferMsg
+	11, // 31: caravela.Caravela.RemoveOffer:input_type -> caravela.OfferRemoveMsg
+	12, // 32: caravela.Caravela.GetOffers:input_type -> caravela.GetOffersMsg
+	18, // 33: caravela.Caravela.LaunchContainer:input_type -> caravela.LaunchContainerMsg
+	20, // 34: caravela.Caravela.StopLocalContainer:input_type -> caravela.StopLocalContainerMsg
+	0,  // 35: caravela.Caravela.CreateOffer:output_type -> caravela.Empty
+	9,  // 36: caravela.Caravela.RefreshOffer:output_type -> caravela.RefreshOfferResponseMsg
+	0,  // 37: caravela.Caravela.UpdateOffer:output_type -> caravela.Empty
+	0,  // 38: caravela.Caravela.RemoveOffer:output_type -> caravela.Empty
+	13, // 39: caravela.Caravela.GetOffers:output_type -> caravela.GetOffersResponseMsg
+	19, // 40: caravela.Caravela.LaunchContainer:output_type -> caravela.LaunchContainerResponseMsg
+	0,  // 41: caravela.Caravela.StopLocalContainer:output_type -> caravela.Empty
+	35, // [35:42] is the sub-list for method output_type
+	28, // [28:35] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
+}
+
+func init() { file_caravela_proto_init() }
+func file_caravela_proto_init() {
+	if File_caravela_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_caravela_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Empty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_caravela_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Node); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_caravela_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Resources); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_caravela_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Offer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_caravela_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AvailableOffer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_caravela_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PartitionState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_caravela_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PartitionsState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_caravela_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateOfferMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_caravela_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RefreshOfferMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_caravela_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RefreshOfferResponseMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_caravela_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateOfferMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_caravela_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OfferRemoveMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_caravela_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetOffersMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_caravela_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetOffersResponseMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_caravela_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PortMapping); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_caravela_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegistryAuth); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_caravela_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContainerConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_caravela_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContainerStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_caravela_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LaunchContainerMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_caravela_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LaunchContainerResponseMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_caravela_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopLocalContainerMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_caravela_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   21,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_caravela_proto_goTypes,
+		DependencyIndexes: file_caravela_proto_depIdxs,
+		MessageInfos:      file_caravela_proto_msgTypes,
+	}.Build()
+	File_caravela_proto = out.File
+	file_caravela_proto_rawDesc = nil
+	file_caravela_proto_goTypes = nil
+	file_caravela_proto_depIdxs = nil
+}
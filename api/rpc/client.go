@@ -0,0 +1,253 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	log "github.com/Sirupsen/logrus"
+	"github.com/strabox/caravela/api/remote"
+	"github.com/strabox/caravela/api/security"
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/node/external"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcCredentials "google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"net"
+	"sync"
+	"time"
+)
+
+// Timeout of the launch messages, the supplier can take long pulling the containers' images.
+const launchContainerTimeout = 600 * time.Second
+
+// grpcClient sends the offers, launch and stop messages to the other nodes through gRPC, reusing one connection to
+// each node. The other messages are sent through the HTTP client.
+type grpcClient struct {
+	external.Caravela // HTTP client used in the messages without a gRPC definition.
+
+	port           int
+	requestTimeout time.Duration
+	dialOptions    []grpc.DialOption
+	conns          map[string]*grpc.ClientConn // Connections to the other nodes by IP
+	mutex          sync.Mutex                  // Mutex to manage the connections
+}
+
+// NewClient creates a client that contacts the other nodes through gRPC, and through the given HTTP client in the
+// messages without a gRPC definition. With credentials the nodes are contacted using mutual TLS.
+func NewClient(port int, requestTimeout time.Duration, credentials *security.Credentials,
+	httpClient external.Caravela) *grpcClient {
+	dialOptions := []grpc.DialOption{grpc.WithInsecure()}
+	if credentials != nil {
+		dialOptions = []grpc.DialOption{
+			grpc.WithTransportCredentials(grpcCredentials.NewTLS(credentials.ClientTLSConfig())),
+		}
+	}
+	return &grpcClient{
+		Caravela:       httpClient,
+		port:           port,
+		requestTimeout: requestTimeout,
+		dialOptions:    dialOptions,
+		conns:          make(map[string]*grpc.ClientConn),
+		mutex:          sync.Mutex{},
+	}
+}
+
+// conn returns the connection to a node, it is created in the first message sent to the node.
+func (g *grpcClient) conn(ip string) (*grpc.ClientConn, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if conn, exist := g.conns[ip]; exist {
+		return conn, nil
+	}
+	conn, err := grpc.Dial(net.JoinHostPort(ip, fmt.Sprintf("%d", g.port)), g.dialOptions...)
+	if err != nil {
+		return nil, err
+	}
+	g.conns[ip] = conn
+	return conn, nil
+}
+
+// forget closes the connection to a node that could not be reached, so the client does not keep reconnecting to the
+// nodes that left.
+func (g *grpcClient) forget(ip string, conn *grpc.ClientConn) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.conns[ip] == conn {
+		delete(g.conns, ip)
+		conn.Close()
+	}
+}
+
+// invoke sends a message to a node and waits for its response, with the request's context values in the metadata.
+func (g *grpcClient) invoke(ctx context.Context, ip, method string, msg, resp interface{},
+	timeout time.Duration) error {
+	conn, err := g.conn(ip)
+	if err != nil {
+		return remote.NewRemoteClientError(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ctx, err = outgoingContext(ctx)
+	if err != nil {
+		return remote.NewRemoteClientError(err)
+	}
+
+	if err := conn.Invoke(ctx, method, msg, resp); err != nil {
+		switch status.Code(err) {
+		case codes.Unavailable:
+			g.forget(ip, conn)
+			return remote.NewUnavailableRemoteClientError(err)
+		case codes.DeadlineExceeded:
+			return remote.NewUnavailableRemoteClientError(err)
+		}
+		return err
+	}
+	return nil
+}
+
+// typedError returns the typed error (sent in the status' message) of a message that failed with the given code,
+// or a remote client error.
+func typedError(err error, code codes.Code, typedErr error) error {
+	if errStatus, ok := status.FromError(err); ok && errStatus.Code() == code {
+		if jsonErr := json.Unmarshal([]byte(errStatus.Message()), typedErr); jsonErr != nil {
+			return remote.NewRemoteClientError(jsonErr)
+		}
+		return typedErr
+	}
+	return remoteError(err)
+}
+
+// remoteError converts the error of a message into a remote client error.
+func remoteError(err error) error {
+	if _, ok := err.(*remote.Error); ok {
+		return err
+	}
+	return remote.NewRemoteClientError(err)
+}
+
+// =============================== Discovery ===============================
+
+func (g *grpcClient) CreateOffer(ctx context.Context, fromNode, toNode *types.Node, offer *types.Offer) error {
+	log.Infof("--> CREATE OFFER From: %s, ID: %d, Amt: %d, Res: <%d;%d>, To: <%s;%s>",
+		fromNode.IP, offer.ID, offer.Amount, offer.FreeResources.CPUs, offer.FreeResources.Memory, toNode.IP, toNode.GUID[0:12])
+
+	createOfferMsg := &CreateOfferMsg{
+		FromNode: nodeMsg(fromNode),
+		ToNode:   nodeMsg(toNode),
+		Offer:    offerMsg(offer),
+	}
+
+	if err := g.invoke(ctx, toNode.IP, createOfferMethod, createOfferMsg, &Empty{}, g.requestTimeout); err != nil {
+		return remoteError(err)
+	}
+	return nil
+}
+
+func (g *grpcClient) RefreshOffer(ctx context.Context, fromTrader, toSupp *types.Node, offer *types.Offer) (bool, error) {
+	log.Infof("--> REFRESH OFFER From: %s, ID: %d, To: %s", fromTrader.GUID[0:12], offer.ID, toSupp.IP)
+
+	refreshOfferMsg := &RefreshOfferMsg{
+		FromTrader: nodeMsg(fromTrader),
+		Offer:      offerMsg(offer),
+	}
+	refreshOfferResp := &RefreshOfferResponseMsg{}
+
+	if err := g.invoke(ctx, toSupp.IP, refreshOfferMethod, refreshOfferMsg, refreshOfferResp, g.requestTimeout); err != nil {
+		return false, remoteError(err)
+	}
+	return refreshOfferResp.GetRefreshed(), nil
+}
+
+func (g *grpcClient) UpdateOffer(ctx context.Context, fromSupplier, toTrader *types.Node, offer *types.Offer) error {
+	log.Infof("--> UPDATE OFFER From: %s, ID: %d, To: %s", fromSupplier.IP, offer.ID, toTrader.GUID[0:12])
+
+	updateOfferMsg := &UpdateOfferMsg{
+		FromSupplier: nodeMsg(fromSupplier),
+		ToTrader:     nodeMsg(toTrader),
+		Offer:        offerMsg(offer),
+	}
+
+	if err := g.invoke(ctx, toTrader.IP, updateOfferMethod, updateOfferMsg, &Empty{}, g.requestTimeout); err != nil {
+		return remoteError(err)
+	}
+	return nil
+}
+
+func (g *grpcClient) RemoveOffer(ctx context.Context, fromSupp, toTrader *types.Node, offer *types.Offer) error {
+	log.Infof("--> REMOVE OFFER From: <%s;%s>, ID: %d, To: <%s;%s>",
+		fromSupp.IP, fromSupp.GUID, offer.ID, toTrader.IP, toTrader.GUID[0:12])
+
+	offerRemoveMsg := &OfferRemoveMsg{
+		FromSupplier: nodeMsg(fromSupp),
+		ToTrader:     nodeMsg(toTrader),
+		Offer:        offerMsg(offer),
+	}
+
+	if err := g.invoke(ctx, toTrader.IP, removeOfferMethod, offerRemoveMsg, &Empty{}, g.requestTimeout); err != nil {
+		return remoteError(err)
+	}
+	return nil
+}
+
+func (g *grpcClient) GetOffers(ctx context.Context, fromNode, toTrader *types.Node, relay bool) ([]types.AvailableOffer, error) {
+	log.Infof("--> GET OFFERS To: <%s;%s>, Relay: %t, From: %s", toTrader.IP, toTrader.GUID[0:12], relay, fromNode.GUID)
+
+	getOffersMsg := &GetOffersMsg{
+		FromNode: nodeMsg(fromNode),
+		ToTrader: nodeMsg(toTrader),
+		Relay:    relay,
+	}
+	getOffersResp := &GetOffersResponseMsg{}
+
+	if err := g.invoke(ctx, toTrader.IP, getOffersMethod, getOffersMsg, getOffersResp, g.requestTimeout); err != nil {
+		return nil, remoteError(err)
+	}
+	return availableOffers(getOffersResp.GetOffers()), nil
+}
+
+// =============================== Scheduling ===============================
+
+func (g *grpcClient) LaunchContainer(ctx context.Context, fromBuyer, toSupplier *types.Node, offer *types.Offer,
+	containersConfigs []types.ContainerConfig) ([]types.ContainerStatus, error) {
+
+	for i, contConfig := range containersConfigs {
+		log.Infof("--> LAUNCH [%d] From: %s, ID: %d, Img: %s, PortMaps: %v, Args: %v, Res: <%d;%d>, To: %s",
+			i, fromBuyer.IP, offer.ID, contConfig.ImageKey, contConfig.PortMappings, contConfig.Args,
+			contConfig.Resources.CPUs, contConfig.Resources.Memory, toSupplier.IP)
+	}
+
+	launchContainerMsg := &LaunchContainerMsg{
+		FromBuyer:         nodeMsg(fromBuyer),
+		Offer:             offerMsg(offer),
+		ContainersConfigs: containersConfigsMsg(containersConfigs),
+	}
+	launchContainerResp := &LaunchContainerResponseMsg{}
+
+	err := g.invoke(ctx, toSupplier.IP, launchContainerMethod, launchContainerMsg, launchContainerResp,
+		launchContainerTimeout)
+	if err != nil { // The supplier's image policy can reject the container's image.
+		return nil, typedError(err, codes.PermissionDenied, &types.ImageRejectedError{})
+	}
+	return containersStatus(launchContainerResp.GetContainersStatus()), nil
+}
+
+// =============================== Containers ===============================
+
+func (g *grpcClient) StopLocalContainer(ctx context.Context, fromBuyer, toSupplier *types.Node, containerID string) error {
+	log.Infof("--> STOP ID: %s, SuppIP: %s", containerID, toSupplier.IP)
+
+	stopContainerMsg := &StopLocalContainerMsg{
+		FromBuyer:   nodeMsg(fromBuyer),
+		ContainerID: containerID,
+	}
+
+	err := g.invoke(ctx, toSupplier.IP, stopLocalContainerMethod, stopContainerMsg, &Empty{}, g.requestTimeout)
+	if err != nil { // The supplier does not have the container (e.g. it exited).
+		return typedError(err, codes.NotFound, &types.ContainerNotFoundError{})
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package rpc
+
+import (
+	"context"
+	"github.com/strabox/caravela/api"
+	"github.com/strabox/caravela/api/remote"
+	"github.com/strabox/caravela/api/types"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"testing"
+	"time"
+)
+
+// localNodeStub answers the messages received by the gRPC server and keeps the context of the last one.
+type localNodeStub struct {
+	api.LocalNode
+	offers  []types.AvailableOffer
+	err     error
+	lastCtx context.Context
+}
+
+func (l *localNodeStub) GetOffers(ctx context.Context, _, _ *types.Node, _ bool) []types.AvailableOffer {
+	l.lastCtx = ctx
+	return l.offers
+}
+
+func (l *localNodeStub) LaunchContainers(ctx context.Context, _ *types.Node, _ *types.Offer,
+	contConfigs []types.ContainerConfig) ([]types.ContainerStatus, error) {
+	l.lastCtx = ctx
+	if l.err != nil {
+		return nil, l.err
+	}
+	contsStatus := make([]types.ContainerStatus, 0, len(contConfigs))
+	for _, contConfig := range contConfigs {
+		contsStatus = append(contsStatus, types.ContainerStatus{ContainerConfig: contConfig, SupplierIP: "127.0.0.1",
+			ContainerID: "cont-" + contConfig.Name, Status: "Running"})
+	}
+	return contsStatus, nil
+}
+
+func (l *localNodeStub) StopLocalContainer(ctx context.Context, _ *types.Node, _ string) error {
+	l.lastCtx = ctx
+	return l.err
+}
+
+// restServerStub does not serve the REST API, only the gRPC messages are sent in the tests.
+type restServerStub struct{}
+
+func (r *restServerStub) Start(api.LocalNode) error {
+	return nil
+}
+
+func (r *restServerStub) Stop() {}
+
+const testBuyerIP = "127.0.0.2"
+
+var testOffer = &types.Offer{ID: 1, Amount: 1, FreeResources: types.Resources{CPUs: 2, Memory: 512}}
+
+// startServer starts a gRPC server, in a free port, that redirects the messages to the given node.
+func startServer(t *testing.T, node api.LocalNode) (*Server, int) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	server := NewServer("127.0.0.1", port, nil, &restServerStub{})
+	if err := server.Start(node); err != nil {
+		t.Fatal(err)
+	}
+	return server, port
+}
+
+func TestClient_GetOffers(t *testing.T) {
+	node := &localNodeStub{offers: []types.AvailableOffer{{Offer: *testOffer, SupplierIP: "10.0.0.9"}}}
+	server, port := startServer(t, node)
+	defer server.Stop()
+	client := NewClient(port, time.Second, nil, nil)
+	partitionsState := []types.PartitionState{{PartitionResources: types.Resources{CPUs: 1, Memory: 256}, Hits: 3}}
+	ctx := context.WithValue(context.Background(), types.PartitionsStateKey, partitionsState)
+	ctx = context.WithValue(ctx, types.NodeGUIDKey, "guid")
+
+	offers, err := client.GetOffers(ctx, &types.Node{IP: testBuyerIP, GUID: "guid"},
+		&types.Node{IP: "127.0.0.1", GUID: "0123456789abcdef"}, false)
+
+	assert.Nil(t, err)
+	assert.Equal(t, node.offers, offers)
+	assert.Equal(t, partitionsState, types.SysPartitionsState(node.lastCtx), "Partitions state not sent")
+	assert.Equal(t, "guid", types.NodeGUID(node.lastCtx), "Node's GUID not sent")
+}
+
+func TestClient_LaunchContainer(t *testing.T) {
+	node := &localNodeStub{}
+	server, port := startServer(t, node)
+	defer server.Stop()
+	client := NewClient(port, time.Second, nil, nil)
+	contConfigs := []types.ContainerConfig{{Name: "web", ImageKey: "nginx", Args: []string{"-g"},
+		PortMappings: []types.PortMapping{{HostPort: 8080, ContainerPort: 80, Protocol: "tcp"}},
+		Resources:    types.Resources{CPUs: 1, Memory: 256}, GroupPolicy: types.CoLocationGroupPolicy,
+		RegistryAuth: &types.RegistryAuth{ServerAddress: "registry", Username: "user", Password: "pass"}}}
+	ctx := context.WithValue(context.Background(), types.UserIDKey, "alice")
+
+	for i := 0; i < 2; i++ {
+		contsStatus, err := client.LaunchContainer(ctx, &types.Node{IP: testBuyerIP}, &types.Node{IP: "127.0.0.1"},
+			testOffer, contConfigs)
+
+		assert.Nil(t, err)
+		if assert.Len(t, contsStatus, 1) {
+			assert.Equal(t, contConfigs[0], contsStatus[0].ContainerConfig)
+			assert.Equal(t, "cont-web", contsStatus[0].ContainerID)
+		}
+		assert.Equal(t, "alice", types.UserID(node.lastCtx), "User's ID not sent")
+	}
+	assert.Len(t, client.conns, 1, "Connection to the supplier not reused")
+}
+
+func TestClient_LaunchContainer_ImageRejected(t *testing.T) {
+	node := &localNodeStub{err: types.NewImageRejectedError("nginx", "not signed")}
+	server, port := startServer(t, node)
+	defer server.Stop()
+	client := NewClient(port, time.Second, nil, nil)
+
+	_, err := client.LaunchContainer(context.Background(), &types.Node{IP: testBuyerIP}, &types.Node{IP: "127.0.0.1"},
+		testOffer, []types.ContainerConfig{{ImageKey: "nginx"}})
+
+	assert.Equal(t, node.err, err)
+}
+
+func TestClient_StopLocalContainer_NotFound(t *testing.T) {
+	node := &localNodeStub{err: types.NewContainerNotFoundError("cont-web", true)}
+	server, port := startServer(t, node)
+	defer server.Stop()
+	client := NewClient(port, time.Second, nil, nil)
+
+	err := client.StopLocalContainer(context.Background(), &types.Node{IP: testBuyerIP}, &types.Node{IP: "127.0.0.1"},
+		"cont-web")
+
+	assert.Equal(t, node.err, err)
+}
+
+func TestClient_Unavailable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close() // Nobody listening in the port.
+	client := NewClient(port, time.Second, nil, nil)
+
+	err = client.StopLocalContainer(context.Background(), &types.Node{IP: testBuyerIP}, &types.Node{IP: "127.0.0.1"},
+		"cont-web")
+
+	if assert.IsType(t, &remote.Error{}, err) {
+		assert.True(t, err.(*remote.Error).Unavailable())
+	}
+	assert.Empty(t, client.conns, "Connection to the unreachable node kept")
+}
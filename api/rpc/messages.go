@@ -0,0 +1,182 @@
+package rpc
+
+import (
+	"github.com/strabox/caravela/api/types"
+)
+
+// Conversions between the messages of the gRPC transport and the types used by the nodes.
+
+func nodeMsg(node *types.Node) *Node {
+	return &Node{IP: node.IP, GUID: node.GUID}
+}
+
+func (m *Node) node() *types.Node {
+	return &types.Node{IP: m.GetIP(), GUID: m.GetGUID()}
+}
+
+func resourcesMsg(resources types.Resources) *Resources {
+	return &Resources{CPUClass: uint32(resources.CPUClass), CPUs: int32(resources.CPUs), Memory: int32(resources.Memory)}
+}
+
+func (m *Resources) resources() types.Resources {
+	return types.Resources{CPUClass: types.CPUClass(m.GetCPUClass()), CPUs: int(m.GetCPUs()), Memory: int(m.GetMemory())}
+}
+
+func offerMsg(offer *types.Offer) *Offer {
+	return &Offer{
+		ID:                offer.ID,
+		Amount:            int32(offer.Amount),
+		FreeResources:     resourcesMsg(offer.FreeResources),
+		UsedResources:     resourcesMsg(offer.UsedResources),
+		ContainersRunning: int32(offer.ContainersRunning),
+		ImagesFilter:      offer.ImagesFilter,
+	}
+}
+
+func (m *Offer) offer() *types.Offer {
+	return &types.Offer{
+		ID:                m.GetID(),
+		Amount:            int(m.GetAmount()),
+		FreeResources:     m.GetFreeResources().resources(),
+		UsedResources:     m.GetUsedResources().resources(),
+		ContainersRunning: int(m.GetContainersRunning()),
+		ImagesFilter:      m.GetImagesFilter(),
+	}
+}
+
+func availableOffersMsg(offers []types.AvailableOffer) []*AvailableOffer {
+	res := make([]*AvailableOffer, 0, len(offers))
+	for i := range offers {
+		res = append(res, &AvailableOffer{Offer: offerMsg(&offers[i].Offer), SupplierIP: offers[i].SupplierIP})
+	}
+	return res
+}
+
+func availableOffers(msgs []*AvailableOffer) []types.AvailableOffer {
+	if len(msgs) == 0 {
+		return nil
+	}
+	res := make([]types.AvailableOffer, 0, len(msgs))
+	for _, msg := range msgs {
+		res = append(res, types.AvailableOffer{Offer: *msg.GetOffer().offer(), SupplierIP: msg.GetSupplierIP()})
+	}
+	return res
+}
+
+func partitionsStateMsg(partitionsState []types.PartitionState) *PartitionsState {
+	res := &PartitionsState{Partitions: make([]*PartitionState, 0, len(partitionsState))}
+	for _, partitionState := range partitionsState {
+		res.Partitions = append(res.Partitions, &PartitionState{
+			PartitionResources: resourcesMsg(partitionState.PartitionResources),
+			Hits:               int32(partitionState.Hits),
+		})
+	}
+	return res
+}
+
+func (m *PartitionsState) partitionsState() []types.PartitionState {
+	res := make([]types.PartitionState, 0, len(m.GetPartitions()))
+	for _, partitionState := range m.GetPartitions() {
+		res = append(res, types.PartitionState{
+			PartitionResources: partitionState.GetPartitionResources().resources(),
+			Hits:               int(partitionState.GetHits()),
+		})
+	}
+	return res
+}
+
+func containerConfigMsg(contConfig *types.ContainerConfig) *ContainerConfig {
+	res := &ContainerConfig{
+		Name:           contConfig.Name,
+		ImageKey:       contConfig.ImageKey,
+		Args:           contConfig.Args,
+		PortMappings:   make([]*PortMapping, 0, len(contConfig.PortMappings)),
+		Resources:      resourcesMsg(contConfig.Resources),
+		GroupPolicy:    uint32(contConfig.GroupPolicy),
+		ImageSignature: contConfig.ImageSignature,
+	}
+	for _, portMap := range contConfig.PortMappings {
+		res.PortMappings = append(res.PortMappings, &PortMapping{
+			HostPort:      int32(portMap.HostPort),
+			ContainerPort: int32(portMap.ContainerPort),
+			Protocol:      portMap.Protocol,
+		})
+	}
+	if contConfig.RegistryAuth != nil {
+		res.RegistryAuth = &RegistryAuth{
+			ServerAddress: contConfig.RegistryAuth.ServerAddress,
+			Username:      contConfig.RegistryAuth.Username,
+			Password:      contConfig.RegistryAuth.Password,
+		}
+	}
+	return res
+}
+
+func (m *ContainerConfig) containerConfig() types.ContainerConfig {
+	res := types.ContainerConfig{
+		Name:           m.GetName(),
+		ImageKey:       m.GetImageKey(),
+		Args:           m.GetArgs(),
+		PortMappings:   make([]types.PortMapping, 0, len(m.GetPortMappings())),
+		Resources:      m.GetResources().resources(),
+		GroupPolicy:    types.GroupPolicy(m.GetGroupPolicy()),
+		ImageSignature: m.GetImageSignature(),
+	}
+	for _, portMap := range m.GetPortMappings() {
+		res.PortMappings = append(res.PortMappings, types.PortMapping{
+			HostPort:      int(portMap.GetHostPort()),
+			ContainerPort: int(portMap.GetContainerPort()),
+			Protocol:      portMap.GetProtocol(),
+		})
+	}
+	if registryAuth := m.GetRegistryAuth(); registryAuth != nil {
+		res.RegistryAuth = &types.RegistryAuth{
+			ServerAddress: registryAuth.GetServerAddress(),
+			Username:      registryAuth.GetUsername(),
+			Password:      registryAuth.GetPassword(),
+		}
+	}
+	return res
+}
+
+func containersConfigsMsg(contConfigs []types.ContainerConfig) []*ContainerConfig {
+	res := make([]*ContainerConfig, 0, len(contConfigs))
+	for i := range contConfigs {
+		res = append(res, containerConfigMsg(&contConfigs[i]))
+	}
+	return res
+}
+
+func containersConfigs(msgs []*ContainerConfig) []types.ContainerConfig {
+	res := make([]types.ContainerConfig, 0, len(msgs))
+	for _, msg := range msgs {
+		res = append(res, msg.containerConfig())
+	}
+	return res
+}
+
+func containersStatusMsg(contsStatus []types.ContainerStatus) []*ContainerStatus {
+	res := make([]*ContainerStatus, 0, len(contsStatus))
+	for i := range contsStatus {
+		res = append(res, &ContainerStatus{
+			ContainerConfig: containerConfigMsg(&contsStatus[i].ContainerConfig),
+			SupplierIP:      contsStatus[i].SupplierIP,
+			ContainerID:     contsStatus[i].ContainerID,
+			Status:          contsStatus[i].Status,
+		})
+	}
+	return res
+}
+
+func containersStatus(msgs []*ContainerStatus) []types.ContainerStatus {
+	res := make([]types.ContainerStatus, 0, len(msgs))
+	for _, msg := range msgs {
+		res = append(res, types.ContainerStatus{
+			ContainerConfig: msg.GetContainerConfig().containerConfig(),
+			SupplierIP:      msg.GetSupplierIP(),
+			ContainerID:     msg.GetContainerID(),
+			Status:          msg.GetStatus(),
+		})
+	}
+	return res
+}
@@ -0,0 +1,55 @@
+package rpc
+
+import (
+	"context"
+	"github.com/golang/protobuf/proto"
+	"github.com/strabox/caravela/api/types"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata keys of the request's context values sent with the messages (binary keys end in -bin).
+const partitionsStateMetadataKey = "caravela-partitions-state-bin"
+const nodeGUIDMetadataKey = "caravela-node-guid"
+const userIDMetadataKey = "caravela-user-id"
+
+// outgoingContext attaches the request's context values (partitions state, node's GUID and user's ID) to the
+// metadata sent with a message.
+func outgoingContext(ctx context.Context) (context.Context, error) {
+	md := metadata.MD{}
+	if partitionsState := types.SysPartitionsState(ctx); partitionsState != nil {
+		partitionsStateBytes, err := proto.Marshal(partitionsStateMsg(partitionsState))
+		if err != nil {
+			return nil, err
+		}
+		md.Set(partitionsStateMetadataKey, string(partitionsStateBytes))
+	}
+	if nodeGUID := types.NodeGUID(ctx); nodeGUID != "" {
+		md.Set(nodeGUIDMetadataKey, nodeGUID)
+	}
+	if userID := types.UserID(ctx); userID != "" {
+		md.Set(userIDMetadataKey, userID)
+	}
+	return metadata.NewOutgoingContext(ctx, md), nil
+}
+
+// incomingContext fills the request's context values with the ones present in the metadata of a received message.
+func incomingContext(ctx context.Context) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx, nil
+	}
+	if values := md.Get(partitionsStateMetadataKey); len(values) > 0 {
+		partitionsState := &PartitionsState{}
+		if err := proto.Unmarshal([]byte(values[0]), partitionsState); err != nil {
+			return nil, err
+		}
+		ctx = context.WithValue(ctx, types.PartitionsStateKey, partitionsState.partitionsState())
+	}
+	if values := md.Get(nodeGUIDMetadataKey); len(values) > 0 {
+		ctx = context.WithValue(ctx, types.NodeGUIDKey, values[0])
+	}
+	if values := md.Get(userIDMetadataKey); len(values) > 0 {
+		ctx = context.WithValue(ctx, types.UserIDKey, values[0])
+	}
+	return ctx, nil
+}
@@ -0,0 +1,231 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	log "github.com/Sirupsen/logrus"
+	"github.com/strabox/caravela/api"
+	restUtil "github.com/strabox/caravela/api/rest/util"
+	"github.com/strabox/caravela/api/security"
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcCredentials "google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"net"
+)
+
+// Server handles the offers, launch and stop messages sent through gRPC and redirects them to the local Node.
+// The other messages and the users' API are served by the REST API server.
+type Server struct {
+	grpcServer *grpc.Server
+	address    string
+	restServer api.Server
+	mutualTLS  bool // True if the nodes communicate using mutual TLS.
+	node       api.LocalNode
+}
+
+// NewServer creates a new gRPC server that receives the messages for the local node, along with the given REST API
+// server. With credentials the server only accepts messages from the nodes with a certificate issued by the cluster's CA.
+func NewServer(hostIP string, port int, credentials *security.Credentials, restServer api.Server) *Server {
+	res := &Server{
+		address:    fmt.Sprintf(":%d", port),
+		restServer: restServer,
+		mutualTLS:  credentials != nil,
+	}
+
+	serverOptions := []grpc.ServerOption{grpc.UnaryInterceptor(res.intercept)}
+	if credentials != nil {
+		res.address = net.JoinHostPort(hostIP, fmt.Sprintf("%d", port))
+		serverOptions = append(serverOptions, grpc.Creds(grpcCredentials.NewTLS(credentials.ServerTLSConfig())))
+	}
+	res.grpcServer = grpc.NewServer(serverOptions...)
+	return res
+}
+
+// Start starts the REST API server and the gRPC server.
+func (server *Server) Start(node api.LocalNode) error {
+	log.Debug(util.LogTag("API") + "Starting gRPC Server ...")
+
+	if err := server.restServer.Start(node); err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", server.address)
+	if err != nil {
+		return err
+	}
+	server.node = node
+	server.grpcServer.RegisterService(&caravelaServiceDesc, server)
+
+	go func() {
+		if err := server.grpcServer.Serve(listener); err != nil {
+			log.Infof(util.LogTag("[API]")+" gRPC server STOPPED: %s", err)
+		}
+	}()
+	return nil
+}
+
+// Stop the gRPC server and the REST API server.
+func (server *Server) Stop() {
+	go server.grpcServer.GracefulStop()
+	server.restServer.Stop()
+}
+
+// intercept fills the request's context with the values sent in the message's metadata.
+func (server *Server) intercept(ctx context.Context, msg interface{}, _ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := incomingContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return handler(ctx, msg)
+}
+
+// checkNodeIdentity verifies if the node claimed in a message is the node that sent it, like the REST API does.
+// With mutual TLS the messages of the nodes without a verified certificate are rejected.
+func (server *Server) checkNodeIdentity(ctx context.Context, claimedNode *types.Node) error {
+	if !server.mutualTLS {
+		return nil
+	}
+
+	peerIP, ok := verifiedPeerIP(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "node certificate required")
+	}
+	if claimedNode.IP == "" {
+		claimedNode.IP = peerIP
+	} else if claimedNode.IP != peerIP {
+		return status.Errorf(codes.Unauthenticated, "node %s claimed to be %s", peerIP, claimedNode.IP)
+	}
+	return nil
+}
+
+// verifiedPeerIP returns the IP of the node that sent the message, verified through its certificate.
+func verifiedPeerIP(ctx context.Context) (string, bool) {
+	messagePeer, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	tlsInfo, ok := messagePeer.AuthInfo.(grpcCredentials.TLSInfo)
+	if !ok {
+		return "", false
+	}
+	return security.PeerIP(&tlsInfo.State)
+}
+
+// statusError converts the errors returned by the local node into the gRPC status sent to the other node. The typed
+// errors go in the status' message, like the REST API sends them in the response's body.
+func statusError(err error) error {
+	switch typedErr := err.(type) {
+	case *types.ImageRejectedError:
+		return status.Error(codes.PermissionDenied, string(restUtil.ToJSONBytes(typedErr)))
+	case *types.QuotaExceededError:
+		return status.Error(codes.ResourceExhausted, string(restUtil.ToJSONBytes(typedErr)))
+	case *types.ContainerNotFoundError:
+		return status.Error(codes.NotFound, string(restUtil.ToJSONBytes(typedErr)))
+	default:
+		return status.Error(codes.Unknown, err.Error())
+	}
+}
+
+// =============================== Discovery ===============================
+
+func (server *Server) CreateOffer(ctx context.Context, createOfferMsg *CreateOfferMsg) (*Empty, error) {
+	fromNode, toNode, offer := createOfferMsg.GetFromNode().node(), createOfferMsg.GetToNode().node(),
+		createOfferMsg.GetOffer().offer()
+	if err := server.checkNodeIdentity(ctx, fromNode); err != nil {
+		return nil, err
+	}
+	log.Infof("<-- CREATE OFFER To: %s, ID: %d, Amt: %d, Res: <%d,%d>, From: %s", toNode.GUID[0:12], offer.ID,
+		offer.Amount, offer.FreeResources.CPUs, offer.FreeResources.Memory, fromNode.IP)
+
+	server.node.CreateOffer(ctx, fromNode, toNode, offer)
+	return &Empty{}, nil
+}
+
+func (server *Server) RefreshOffer(ctx context.Context, refreshOfferMsg *RefreshOfferMsg) (*RefreshOfferResponseMsg, error) {
+	fromTrader, offer := refreshOfferMsg.GetFromTrader().node(), refreshOfferMsg.GetOffer().offer()
+	if err := server.checkNodeIdentity(ctx, fromTrader); err != nil {
+		return nil, err
+	}
+	log.Infof("<-- REFRESH OFFER ID: %d, From: %s", offer.ID, fromTrader.GUID[0:12])
+
+	return &RefreshOfferResponseMsg{Refreshed: server.node.RefreshOffer(ctx, fromTrader, offer)}, nil
+}
+
+func (server *Server) UpdateOffer(ctx context.Context, updateOfferMsg *UpdateOfferMsg) (*Empty, error) {
+	fromSupplier, toTrader, offer := updateOfferMsg.GetFromSupplier().node(), updateOfferMsg.GetToTrader().node(),
+		updateOfferMsg.GetOffer().offer()
+	if err := server.checkNodeIdentity(ctx, fromSupplier); err != nil {
+		return nil, err
+	}
+	log.Infof("<-- UPDATE OFFER ID: %d, From: %s, To: %s", offer.ID, fromSupplier.IP, toTrader.GUID[0:12])
+
+	server.node.UpdateOffer(ctx, fromSupplier, toTrader, offer)
+	return &Empty{}, nil
+}
+
+func (server *Server) RemoveOffer(ctx context.Context, offerRemoveMsg *OfferRemoveMsg) (*Empty, error) {
+	fromSupplier, toTrader, offer := offerRemoveMsg.GetFromSupplier().node(), offerRemoveMsg.GetToTrader().node(),
+		offerRemoveMsg.GetOffer().offer()
+	if err := server.checkNodeIdentity(ctx, fromSupplier); err != nil {
+		return nil, err
+	}
+	log.Infof("<-- REMOVE OFFER To: %s, ID: %d, From: %s", toTrader.GUID[0:12], offer.ID, fromSupplier.IP)
+
+	server.node.RemoveOffer(ctx, fromSupplier, toTrader, offer)
+	return &Empty{}, nil
+}
+
+func (server *Server) GetOffers(ctx context.Context, getOffersMsg *GetOffersMsg) (*GetOffersResponseMsg, error) {
+	fromNode, toTrader := getOffersMsg.GetFromNode().node(), getOffersMsg.GetToTrader().node()
+	if err := server.checkNodeIdentity(ctx, fromNode); err != nil {
+		return nil, err
+	}
+	log.Infof("<-- GET OFFERS To: %s, Relay: %t, From: %s", toTrader.GUID[0:12], getOffersMsg.GetRelay(), fromNode.GUID)
+
+	offers := server.node.GetOffers(ctx, fromNode, toTrader, getOffersMsg.GetRelay())
+	return &GetOffersResponseMsg{Offers: availableOffersMsg(offers)}, nil
+}
+
+// =============================== Scheduling ===============================
+
+func (server *Server) LaunchContainer(ctx context.Context,
+	launchContainerMsg *LaunchContainerMsg) (*LaunchContainerResponseMsg, error) {
+
+	fromBuyer, offer := launchContainerMsg.GetFromBuyer().node(), launchContainerMsg.GetOffer().offer()
+	contConfigs := containersConfigs(launchContainerMsg.GetContainersConfigs())
+	if err := server.checkNodeIdentity(ctx, fromBuyer); err != nil {
+		return nil, err
+	}
+	for i, contConfig := range contConfigs {
+		log.Infof("<-- LAUNCH [%d] From: %s, ID: %d, Img: %s, PortMaps: %v, Args: %v, Res: <<%d;%d>;%d>",
+			i, fromBuyer.IP, offer.ID, contConfig.ImageKey, contConfig.PortMappings, contConfig.Args,
+			contConfig.Resources.CPUClass, contConfig.Resources.CPUs, contConfig.Resources.Memory)
+	}
+
+	contsStatus, err := server.node.LaunchContainers(ctx, fromBuyer, offer, contConfigs)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &LaunchContainerResponseMsg{ContainersStatus: containersStatusMsg(contsStatus)}, nil
+}
+
+// =============================== Containers ===============================
+
+func (server *Server) StopLocalContainer(ctx context.Context, stopContainerMsg *StopLocalContainerMsg) (*Empty, error) {
+	fromBuyer := stopContainerMsg.GetFromBuyer().node()
+	log.Infof("<-- STOP Local Container ID: %s, Buyer: %s", stopContainerMsg.GetContainerID(), fromBuyer.IP)
+
+	if err := server.checkNodeIdentity(ctx, fromBuyer); err != nil {
+		return nil, err
+	}
+
+	if err := server.node.StopLocalContainer(ctx, fromBuyer, stopContainerMsg.GetContainerID()); err != nil {
+		return nil, statusError(err)
+	}
+	return &Empty{}, nil
+}
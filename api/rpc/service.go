@@ -0,0 +1,107 @@
+package rpc
+
+import (
+	"context"
+	"google.golang.org/grpc"
+)
+
+// Name of the gRPC service (caravela.proto) and the full name of its methods.
+const serviceName = "caravela.Caravela"
+const createOfferMethod = "/" + serviceName + "/CreateOffer"
+const refreshOfferMethod = "/" + serviceName + "/RefreshOffer"
+const updateOfferMethod = "/" + serviceName + "/UpdateOffer"
+const removeOfferMethod = "/" + serviceName + "/RemoveOffer"
+const getOffersMethod = "/" + serviceName + "/GetOffers"
+const launchContainerMethod = "/" + serviceName + "/LaunchContainer"
+const stopLocalContainerMethod = "/" + serviceName + "/StopLocalContainer"
+
+// caravelaServer handles the messages of the Caravela gRPC service.
+type caravelaServer interface {
+	CreateOffer(ctx context.Context, createOfferMsg *CreateOfferMsg) (*Empty, error)
+	RefreshOffer(ctx context.Context, refreshOfferMsg *RefreshOfferMsg) (*RefreshOfferResponseMsg, error)
+	UpdateOffer(ctx context.Context, updateOfferMsg *UpdateOfferMsg) (*Empty, error)
+	RemoveOffer(ctx context.Context, offerRemoveMsg *OfferRemoveMsg) (*Empty, error)
+	GetOffers(ctx context.Context, getOffersMsg *GetOffersMsg) (*GetOffersResponseMsg, error)
+	LaunchContainer(ctx context.Context, launchContainerMsg *LaunchContainerMsg) (*LaunchContainerResponseMsg, error)
+	StopLocalContainer(ctx context.Context, stopContainerMsg *StopLocalContainerMsg) (*Empty, error)
+}
+
+// caravelaServiceDesc describes the Caravela gRPC service, it is what protoc's gRPC plugin generates.
+var caravelaServiceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*caravelaServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateOffer",
+			Handler: methodHandler(createOfferMethod, func() interface{} { return &CreateOfferMsg{} },
+				func(srv caravelaServer, ctx context.Context, msg interface{}) (interface{}, error) {
+					return srv.CreateOffer(ctx, msg.(*CreateOfferMsg))
+				}),
+		},
+		{
+			MethodName: "RefreshOffer",
+			Handler: methodHandler(refreshOfferMethod, func() interface{} { return &RefreshOfferMsg{} },
+				func(srv caravelaServer, ctx context.Context, msg interface{}) (interface{}, error) {
+					return srv.RefreshOffer(ctx, msg.(*RefreshOfferMsg))
+				}),
+		},
+		{
+			MethodName: "UpdateOffer",
+			Handler: methodHandler(updateOfferMethod, func() interface{} { return &UpdateOfferMsg{} },
+				func(srv caravelaServer, ctx context.Context, msg interface{}) (interface{}, error) {
+					return srv.UpdateOffer(ctx, msg.(*UpdateOfferMsg))
+				}),
+		},
+		{
+			MethodName: "RemoveOffer",
+			Handler: methodHandler(removeOfferMethod, func() interface{} { return &OfferRemoveMsg{} },
+				func(srv caravelaServer, ctx context.Context, msg interface{}) (interface{}, error) {
+					return srv.RemoveOffer(ctx, msg.(*OfferRemoveMsg))
+				}),
+		},
+		{
+			MethodName: "GetOffers",
+			Handler: methodHandler(getOffersMethod, func() interface{} { return &GetOffersMsg{} },
+				func(srv caravelaServer, ctx context.Context, msg interface{}) (interface{}, error) {
+					return srv.GetOffers(ctx, msg.(*GetOffersMsg))
+				}),
+		},
+		{
+			MethodName: "LaunchContainer",
+			Handler: methodHandler(launchContainerMethod, func() interface{} { return &LaunchContainerMsg{} },
+				func(srv caravelaServer, ctx context.Context, msg interface{}) (interface{}, error) {
+					return srv.LaunchContainer(ctx, msg.(*LaunchContainerMsg))
+				}),
+		},
+		{
+			MethodName: "StopLocalContainer",
+			Handler: methodHandler(stopLocalContainerMethod, func() interface{} { return &StopLocalContainerMsg{} },
+				func(srv caravelaServer, ctx context.Context, msg interface{}) (interface{}, error) {
+					return srv.StopLocalContainer(ctx, msg.(*StopLocalContainerMsg))
+				}),
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "caravela.proto",
+}
+
+// methodHandler decodes the message of a method and handles it (through the server's interceptor, if any).
+func methodHandler(fullMethod string, newMsg func() interface{},
+	handle func(srv caravelaServer, ctx context.Context, msg interface{}) (interface{}, error)) func(interface{},
+	context.Context, func(interface{}) error, grpc.UnaryServerInterceptor) (interface{}, error) {
+
+	return func(srv interface{}, ctx context.Context, dec func(interface{}) error,
+		interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+		msg := newMsg()
+		if err := dec(msg); err != nil {
+			return nil, err
+		}
+		if interceptor == nil {
+			return handle(srv.(caravelaServer), ctx, msg)
+		}
+		info := &grpc.UnaryServerInfo{Server: srv, FullMethod: fullMethod}
+		return interceptor(ctx, msg, info, func(ctx context.Context, msg interface{}) (interface{}, error) {
+			return handle(srv.(caravelaServer), ctx, msg)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package security
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"time"
+)
+
+// Authority represents the cluster's certificate authority, it issues the certificates of the nodes that
+// prove they know the bootstrap token.
+type Authority struct {
+	caCert   *x509.Certificate // Cluster's CA certificate.
+	caPEM    []byte            // Cluster's CA certificate (PEM).
+	caKey    crypto.Signer     // Cluster's CA private key.
+	token    string            // Bootstrap token shared with the joining nodes.
+	validity time.Duration     // Validity of the issued certificates.
+}
+
+// LoadAuthority loads the cluster's CA certificate and private key from PEM files.
+func LoadAuthority(caFile, caKeyFile, token string, validity time.Duration) (*Authority, error) {
+	caPEM, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	caKeyPEM, err := ioutil.ReadFile(caKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	caBlock, _ := pem.Decode(caPEM)
+	if caBlock == nil {
+		return nil, errors.New("CA certificate is not PEM encoded")
+	}
+	caCert, err := x509.ParseCertificate(caBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CA certificate: %s", err)
+	}
+
+	caKey, err := parsePrivateKey(caKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Authority{
+		caCert:   caCert,
+		caPEM:    caPEM,
+		caKey:    caKey,
+		token:    token,
+		validity: validity,
+	}, nil
+}
+
+// NodeCredentials issues the credentials for the local node.
+func (a *Authority) NodeCredentials(hostIP string) (*Credentials, error) {
+	csr, keyPEM, err := NewCertificateRequest(hostIP)
+	if err != nil {
+		return nil, err
+	}
+
+	certPEM, err := a.issue(csr, hostIP)
+	if err != nil {
+		return nil, err
+	}
+	return NewCredentials(a.caPEM, certPEM, keyPEM)
+}
+
+// IssueCertificate issues a certificate for a joining node, the proof must show that the node knows the bootstrap
+// token. It returns the certificate, the CA certificate and the proof that the authority knows the token.
+func (a *Authority) IssueCertificate(requesterIP string, csr []byte, proof []byte) ([]byte, []byte, []byte, error) {
+	if a.token == "" {
+		return nil, nil, nil, errors.New("node does not accept bootstrap requests")
+	}
+
+	if !hmac.Equal(proof, BootstrapProof(a.token, csr)) {
+		return nil, nil, nil, errors.New("invalid bootstrap token")
+	}
+
+	certPEM, err := a.issue(csr, requesterIP)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return certPEM, a.caPEM, BootstrapProof(a.token, certPEM, a.caPEM), nil
+}
+
+// issue issues a certificate (PEM) for the node's certificate request, that must be for the given IP.
+func (a *Authority) issue(csrDER []byte, nodeIP string) ([]byte, error) {
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate request: %s", err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("invalid certificate request signature: %s", err)
+	}
+
+	if len(csr.IPAddresses) != 1 || csr.IPAddresses[0].String() != nodeIP {
+		return nil, fmt.Errorf("certificate request must be only for the IP %s", nodeIP)
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: nodeIP},
+		IPAddresses:  csr.IPAddresses,
+		NotBefore:    now.Add(-5 * time.Minute), // Tolerate small clock skews between the nodes.
+		NotAfter:     now.Add(a.validity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, a.caCert, csr.PublicKey, a.caKey)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), nil
+}
+
+// BootstrapProof proves the knowledge of the bootstrap token without sending it, binding it to the given data.
+func BootstrapProof(token string, data ...[]byte) []byte {
+	mac := hmac.New(sha256.New, []byte(token))
+	for _, d := range data {
+		mac.Write(d)
+	}
+	return mac.Sum(nil)
+}
+
+// parsePrivateKey parses a PEM encoded private key (PKCS1, PKCS8 or EC).
+func parsePrivateKey(keyPEM []byte) (crypto.Signer, error) {
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, errors.New("CA private key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(keyBlock.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(keyBlock.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes); err == nil {
+		if signer, ok := key.(crypto.Signer); ok {
+			return signer, nil
+		}
+	}
+	return nil, errors.New("invalid CA private key")
+}
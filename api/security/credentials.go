@@ -0,0 +1,137 @@
+/*
+Security package provides the mutual TLS between the CARAVELA's nodes: the nodes' credentials, the cluster's
+certificate authority that issues the nodes' certificates and the bootstrap of the joining nodes.
+*/
+package security
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+)
+
+// Credentials holds the node's certificate and the cluster's CA used to verify the other nodes.
+type Credentials struct {
+	caPEM       []byte          // Cluster's CA certificate (PEM).
+	caPool      *x509.CertPool  // Pool with the cluster's CA certificate.
+	certificate tls.Certificate // Node's certificate and private key.
+}
+
+// NewCredentials creates the credentials from PEM encoded CA certificate, node's certificate and private key.
+func NewCredentials(caPEM, certPEM, keyPEM []byte) (*Credentials, error) {
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caPEM) {
+		return nil, errors.New("invalid CA certificate")
+	}
+
+	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid node certificate: %s", err)
+	}
+
+	return &Credentials{
+		caPEM:       caPEM,
+		caPool:      caPool,
+		certificate: certificate,
+	}, nil
+}
+
+// LoadCredentials loads the credentials from PEM files.
+func LoadCredentials(caFile, certFile, keyFile string) (*Credentials, error) {
+	caPEM, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	certPEM, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPEM, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	return NewCredentials(caPEM, certPEM, keyPEM)
+}
+
+// CAPEM returns the cluster's CA certificate (PEM).
+func (c *Credentials) CAPEM() []byte {
+	return c.caPEM
+}
+
+// ServerTLSConfig returns the TLS configuration for the REST API server.
+// The client certificates are verified when given, the endpoints decide if they are mandatory.
+func (c *Credentials) ServerTLSConfig() *tls.Config {
+	return &tls.Config{
+		Certificates: []tls.Certificate{c.certificate},
+		ClientCAs:    c.caPool,
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+		MinVersion:   tls.VersionTLS12,
+	}
+}
+
+// ClientTLSConfig returns the TLS configuration for the requests to other nodes.
+func (c *Credentials) ClientTLSConfig() *tls.Config {
+	return &tls.Config{
+		Certificates: []tls.Certificate{c.certificate},
+		RootCAs:      c.caPool,
+		MinVersion:   tls.VersionTLS12,
+	}
+}
+
+// PeerIP returns the IP of the node identified by the verified certificate of a TLS connection.
+func PeerIP(state *tls.ConnectionState) (string, bool) {
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+	peerCert := state.VerifiedChains[0][0]
+	if len(peerCert.IPAddresses) == 0 {
+		return "", false
+	}
+	return peerCert.IPAddresses[0].String(), true
+}
+
+// NewCertificateRequest creates a new private key and a certificate request (DER) for the node's IP.
+func NewCertificateRequest(hostIP string) ([]byte, []byte, error) {
+	ip := net.ParseIP(hostIP)
+	if ip == nil {
+		return nil, nil, fmt.Errorf("invalid host IP: %s", hostIP)
+	}
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:     pkix.Name{CommonName: hostIP},
+		IPAddresses: []net.IP{ip},
+	}, privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyPEM, err := encodePrivateKey(privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return csr, keyPEM, nil
+}
+
+// encodePrivateKey encodes an ECDSA private key in PEM.
+func encodePrivateKey(privateKey *ecdsa.PrivateKey) ([]byte, error) {
+	keyDER, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), nil
+}
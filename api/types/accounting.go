@@ -0,0 +1,43 @@
+package types
+
+import "time"
+
+// UsageRecord represents the resources used by a container in a supplier, accounted by the resources reserved
+// for the container during its lifetime.
+type UsageRecord struct {
+	ContainerID     string    `json:"ContainerID"`
+	ImageKey        string    `json:"ImageKey"`
+	BuyerIP         string    `json:"BuyerIP"`
+	UserID          string    `json:"UserID,omitempty"`
+	CPUs            int       `json:"CPUs"`
+	Memory          int       `json:"Memory"`
+	Start           time.Time `json:"Start"`
+	End             time.Time `json:"End"` // Zero while the container is running.
+	CPUSeconds      float64   `json:"CPUSeconds"`
+	MemoryMBSeconds float64   `json:"MemoryMBSeconds"`
+}
+
+// Running returns true if the container was still running when the record was obtained.
+func (r *UsageRecord) Running() bool {
+	return r.End.IsZero()
+}
+
+// Window returns the record with the usage restricted to the period between since and until, the running
+// containers are accounted until the given time. It returns false if the container did not run in the period.
+func (r UsageRecord) Window(since, until time.Time) (UsageRecord, bool) {
+	start, end := r.Start, r.End
+	if end.IsZero() || end.After(until) {
+		end = until
+	}
+	if start.Before(since) {
+		start = since
+	}
+	if !end.After(start) {
+		return r, false
+	}
+
+	seconds := end.Sub(start).Seconds()
+	r.CPUSeconds = float64(r.CPUs) * seconds
+	r.MemoryMBSeconds = float64(r.Memory) * seconds
+	return r, true
+}
@@ -3,12 +3,14 @@ package types
 import "errors"
 
 type ContainerConfig struct {
-	Name         string        `json:"N"`
-	ImageKey     string        `json:"IK"`
-	Args         []string      `json:"A"`
-	PortMappings []PortMapping `json:"PM"`
-	Resources    Resources     `json:"FR"`
-	GroupPolicy  GroupPolicy   `json:"GP"`
+	Name           string        `json:"N"`
+	ImageKey       string        `json:"IK"`
+	Args           []string      `json:"A"`
+	PortMappings   []PortMapping `json:"PM"`
+	Resources      Resources     `json:"FR"`
+	GroupPolicy    GroupPolicy   `json:"GP"`
+	RegistryAuth   *RegistryAuth `json:"RA,omitempty"` // Credentials to pull the image (never returned in a status).
+	ImageSignature string        `json:"IS,omitempty"` // Base64 detached signature of the image's digest.
 }
 
 type ContainerStatus struct {
@@ -24,6 +26,23 @@ type PortMapping struct {
 	Protocol      string `json:"P"`
 }
 
+// RegistryAuth holds the credentials used to pull a container's image from a private registry.
+type RegistryAuth struct {
+	ServerAddress string `json:"SA"`
+	Username      string `json:"U"`
+	Password      string `json:"P"`
+}
+
+// String returns a representation of the credentials that can be safely logged (password hidden).
+func (ra RegistryAuth) String() string {
+	return "<" + ra.ServerAddress + ";" + ra.Username + ";*****>"
+}
+
+// GoString hides the password when the credentials are printed using the %#v verb.
+func (ra RegistryAuth) GoString() string {
+	return ra.String()
+}
+
 // ======================= Container Group Policy ========================
 
 type GroupPolicy uint
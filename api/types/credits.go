@@ -0,0 +1,22 @@
+package types
+
+import "time"
+
+// CreditReceipt represents the credits that a buyer pays to a supplier for the resources used by the buyer's containers
+// in the supplier between Since and Time. It is signed by the buyer (payer) and countersigned by the supplier (payee).
+type CreditReceipt struct {
+	ID                string    `json:"ID"`
+	BuyerIP           string    `json:"BIP"`
+	SupplierIP        string    `json:"SIP"`
+	UserID            string    `json:"UID,omitempty"`
+	ContainerIDs      []string  `json:"CIDs"`
+	CPUs              int       `json:"CPUs"`
+	Memory            int       `json:"Mem"`
+	Credits           float64   `json:"Cr"`
+	Since             time.Time `json:"S"`
+	Time              time.Time `json:"T"`
+	BuyerKey          []byte    `json:"BK"`           // Buyer's public key (PKIX DER).
+	BuyerSignature    []byte    `json:"BS"`           // Buyer's signature of the receipt.
+	SupplierKey       []byte    `json:"SK,omitempty"` // Supplier's public key (PKIX DER).
+	SupplierSignature []byte    `json:"SS,omitempty"` // Supplier's signature of the receipt.
+}
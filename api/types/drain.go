@@ -0,0 +1,17 @@
+package types
+
+import "time"
+
+// DrainRequest asks a node to enter maintenance (drain) mode.
+type DrainRequest struct {
+	Leave   bool          `json:"Leave"`   // If the node leaves the system after draining
+	Timeout time.Duration `json:"Timeout"` // Maximum time waiting for the containers to be rescheduled
+}
+
+// DrainStatus is the outcome of draining a node.
+type DrainStatus struct {
+	Draining            bool `json:"Draining"`
+	ContainersMoved     int  `json:"ContainersMoved"`     // Containers rescheduled by their buyers in other suppliers
+	ContainersRemaining int  `json:"ContainersRemaining"` // Containers still running when the timeout expired
+	Leaving             bool `json:"Leaving"`
+}
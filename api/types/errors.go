@@ -0,0 +1,55 @@
+package types
+
+import "fmt"
+
+// ImageRejectedError is returned when a supplier's image policy rejects the image of a container.
+type ImageRejectedError struct {
+	ImageKey string `json:"IK"`
+	Reason   string `json:"R"`
+}
+
+func NewImageRejectedError(imageKey, reason string) *ImageRejectedError {
+	return &ImageRejectedError{
+		ImageKey: imageKey,
+		Reason:   reason,
+	}
+}
+
+func (e *ImageRejectedError) Error() string {
+	return fmt.Sprintf("image %s rejected: %s", e.ImageKey, e.Reason)
+}
+
+// QuotaExceededError is returned when a request would make a user use more resources than its quota allows.
+type QuotaExceededError struct {
+	UserID string `json:"UID"`
+	Reason string `json:"R"`
+}
+
+func NewQuotaExceededError(userID, reason string) *QuotaExceededError {
+	return &QuotaExceededError{
+		UserID: userID,
+		Reason: reason,
+	}
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("quota of user %s exceeded: %s", e.UserID, e.Reason)
+}
+
+// ContainerNotFoundError is returned when a supplier does not have the container. Exited is true when the container
+// exited on its own and was removed by the supplier, otherwise the supplier lost it.
+type ContainerNotFoundError struct {
+	ContainerID string `json:"ID"`
+	Exited      bool   `json:"Exited"`
+}
+
+func NewContainerNotFoundError(containerID string, exited bool) *ContainerNotFoundError {
+	return &ContainerNotFoundError{
+		ContainerID: containerID,
+		Exited:      exited,
+	}
+}
+
+func (e *ContainerNotFoundError) Error() string {
+	return fmt.Sprintf("container %s does not exist", e.ContainerID)
+}
@@ -0,0 +1,20 @@
+package types
+
+import "time"
+
+// FaultRule describes the faults injected in the requests that a node sends to other nodes. A rule with FromIP A,
+// ToIP B and DropRate 1 creates a one-way partition from A to B.
+type FaultRule struct {
+	Method    string        `json:"Method"`    // Remote call affected e.g. RefreshOffer (empty matches all)
+	FromIP    string        `json:"FromIP"`    // Node that sends the requests (empty matches all)
+	ToIP      string        `json:"ToIP"`      // Node that receives the requests (empty matches all)
+	Delay     time.Duration `json:"Delay"`     // Delay added to the requests (nanoseconds in JSON)
+	DropRate  float64       `json:"DropRate"`  // Probability [0,1] of a request being lost (node unavailable)
+	ErrorRate float64       `json:"ErrorRate"` // Probability [0,1] of a request failing with an error
+}
+
+// Faults holds the faults injected in the requests that a node sends to other nodes (chaos experiments).
+type Faults struct {
+	Enabled bool        `json:"Enabled"` // If the rules are being applied
+	Rules   []FaultRule `json:"Rules"`
+}
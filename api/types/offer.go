@@ -14,6 +14,7 @@ type Offer struct {
 	FreeResources     Resources `json:"FR"`
 	UsedResources     Resources `json:"UR"`
 	ContainersRunning int       `json:"CR"`
+	ImagesFilter      []byte    `json:"IF,omitempty"` // Bloom filter of the images cached in the supplier.
 }
 
 type AvailableOffer struct {
@@ -22,6 +23,12 @@ type AvailableOffer struct {
 	Weight     int    `json:"-"` // Used locally only by the scheduler.
 }
 
+// ReplicatedOffer is an offer managed by a trader that is replicated into the trader's successors.
+type ReplicatedOffer struct {
+	AvailableOffer `json:"AO"`
+	SupplierGUID   string `json:"SG"`
+}
+
 // ======================= CPU Class ========================
 
 type CPUClass uint
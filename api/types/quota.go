@@ -0,0 +1,58 @@
+package types
+
+// QuotaUsage represents an amount of resources used (or allowed) by the containers of a user.
+type QuotaUsage struct {
+	Containers int `json:"Conts"`
+	CPUs       int `json:"CPUs"`
+	Memory     int `json:"Mem"`
+}
+
+// Add returns the sum of two usages.
+func (q QuotaUsage) Add(other QuotaUsage) QuotaUsage {
+	return QuotaUsage{
+		Containers: q.Containers + other.Containers,
+		CPUs:       q.CPUs + other.CPUs,
+		Memory:     q.Memory + other.Memory,
+	}
+}
+
+// Negate returns the usage with the opposite sign, used to release resources.
+func (q QuotaUsage) Negate() QuotaUsage {
+	return QuotaUsage{
+		Containers: -q.Containers,
+		CPUs:       -q.CPUs,
+		Memory:     -q.Memory,
+	}
+}
+
+// UserQuota represents the resources quota of a user in the whole system.
+// A limit of 0 means unlimited, the respective remaining value is -1.
+type UserQuota struct {
+	UserID    string     `json:"UID"`
+	Limits    QuotaUsage `json:"Limits"`
+	Usage     QuotaUsage `json:"Usage"`
+	Remaining QuotaUsage `json:"Remaining"`
+}
+
+// NewUserQuota creates the quota of a user given its limits and current usage.
+func NewUserQuota(userID string, limits, usage QuotaUsage) *UserQuota {
+	remaining := func(limit, used int) int {
+		if limit == 0 {
+			return -1
+		} else if used >= limit {
+			return 0
+		}
+		return limit - used
+	}
+
+	return &UserQuota{
+		UserID: userID,
+		Limits: limits,
+		Usage:  usage,
+		Remaining: QuotaUsage{
+			Containers: remaining(limits.Containers, usage.Containers),
+			CPUs:       remaining(limits.CPUs, usage.CPUs),
+			Memory:     remaining(limits.Memory, usage.Memory),
+		},
+	}
+}
@@ -0,0 +1,16 @@
+package types
+
+import "time"
+
+// SupplierReputation holds the reputation that a node gives to a supplier based on the outcomes it observed.
+type SupplierReputation struct {
+	SupplierIP         string    `json:"SupplierIP"`
+	Score              float64   `json:"Score"` // Between 0 (unreliable) and 1 (reliable), 0.5 when unknown
+	LaunchesSucceeded  int       `json:"LaunchesSucceeded"`
+	LaunchesFailed     int       `json:"LaunchesFailed"`
+	LaunchesTimedOut   int       `json:"LaunchesTimedOut"`
+	ContainersLost     int       `json:"ContainersLost"`
+	RefreshesSucceeded int       `json:"RefreshesSucceeded"`
+	RefreshesFailed    int       `json:"RefreshesFailed"`
+	LastUpdate         time.Time `json:"LastUpdate"`
+}
@@ -9,6 +9,7 @@ var (
 	RequestIDKey       = requestCtxKey("ID")
 	NodeGUIDKey        = requestCtxKey("GUID")
 	PartitionsStateKey = requestCtxKey("PartitionsState")
+	UserIDKey          = requestCtxKey("UserID")
 )
 
 // RequestID retrieves the request ID key from a context.
@@ -34,3 +35,11 @@ func SysPartitionsState(ctx context.Context) []PartitionState {
 	}
 	return nil
 }
+
+// UserID retrieves the ID of the user that made the request from a context.
+func UserID(ctx context.Context) string {
+	if userID, ok := ctx.Value(UserIDKey).(string); ok {
+		return userID
+	}
+	return ""
+}
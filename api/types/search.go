@@ -0,0 +1,19 @@
+package types
+
+import "time"
+
+// SearchStats are the statistics of the offers' searches made by a node.
+type SearchStats struct {
+	Searches      int           `json:"Searches"`
+	EmptySearches int           `json:"EmptySearches"` // Searches that found no offers
+	TotalLatency  time.Duration `json:"TotalLatency"`
+	MaxLatency    time.Duration `json:"MaxLatency"`
+}
+
+// AverageLatency returns the mean latency of the searches made.
+func (s SearchStats) AverageLatency() time.Duration {
+	if s.Searches == 0 {
+		return 0
+	}
+	return s.TotalLatency / time.Duration(s.Searches)
+}
@@ -34,6 +34,11 @@ func Run() {
 			Usage: "IP of the caravela instance/daemon to send the request",
 			Value: defaultCaravelaInstanceIP,
 		},
+		cli.StringFlag{
+			Name:   "context",
+			Usage:  "File with the user's API token and the caravela instance/daemon (default: ~/" + defaultContextFile + ")",
+			EnvVar: "CARAVELA_CONTEXT",
+		},
 	}
 
 	// Before running the user's command
@@ -18,6 +18,11 @@ var (
 					Usage: "Host's IP address",
 					Value: defaultHostIP,
 				},
+				cli.StringFlag{
+					Name:   "token, t",
+					Usage:  "Bootstrap token to obtain the node's certificate (systems using mutual TLS)",
+					EnvVar: "CARAVELA_BOOTSTRAP_TOKEN",
+				},
 			},
 		},
 		{
@@ -72,6 +77,19 @@ var (
 					Usage: "Maximum amount of Memory (in Megabytes) that container can use",
 					Value: defaultMemory,
 				},
+				cli.StringFlag{
+					Name:  "registry-user",
+					Usage: "Username to pull the container's image from a private registry",
+				},
+				cli.StringFlag{
+					Name:   "registry-password",
+					Usage:  "Password to pull the container's image from a private registry",
+					EnvVar: "CARAVELA_REGISTRY_PASSWORD",
+				},
+				cli.StringFlag{
+					Name:  "signature",
+					Usage: "Base64 signature of the image's digest, required by suppliers that only run signed images",
+				},
 			},
 		},
 		{
@@ -93,6 +111,84 @@ var (
 				},
 			},
 		},
+		{
+			Name:     "quota",
+			Aliases:  []string{"q"},
+			Usage:    "Show the user's resources quota, usage and remaining resources in the system",
+			Category: "User's containers management",
+			Before:   printBanner,
+			Action:   showQuota,
+		},
+		{
+			Name:     "usage",
+			Aliases:  []string{"u"},
+			Usage:    "Report the resources used by each buyer's containers in the node (as a supplier)",
+			Category: "Caravela system management",
+			Action:   usageReport, // No banner, the report can be exported.
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "since, s",
+					Usage: "Beginning of the report, a duration until now (e.g. 24h) or a RFC3339 time",
+					Value: defaultUsageSince,
+				},
+				cli.StringFlag{
+					Name:  "format, f",
+					Usage: "Format of the report: table, csv or json",
+					Value: defaultUsageFormat,
+				},
+			},
+		},
+		{
+			Name:     "reputation",
+			Aliases:  []string{"rep"},
+			Usage:    "Show the reputation that the node gives to the suppliers it interacted with",
+			Category: "Caravela system management",
+			Before:   printBanner,
+			Action:   showReputations,
+		},
+		{
+			Name:     "node",
+			Usage:    "Manage the maintenance of the node",
+			Category: "Caravela system management",
+			Subcommands: []cli.Command{
+				{
+					Name:   "drain",
+					Usage:  "Stop offering the node's resources and move its containers to other nodes",
+					Before: printBanner,
+					Action: drainNode,
+					Flags: []cli.Flag{
+						cli.DurationFlag{
+							Name:  "timeout, t",
+							Usage: "Maximum time waiting for the containers to be moved",
+							Value: defaultDrainTimeout,
+						},
+						cli.BoolFlag{
+							Name:  "leave, l",
+							Usage: "Leave the system after draining the node",
+						},
+					},
+				},
+				{
+					Name:   "undrain",
+					Usage:  "Offer the node's resources again",
+					Before: printBanner,
+					Action: undrainNode,
+				},
+				{
+					Name:      "faults",
+					Usage:     "Show or set (from a JSON file) the faults injected in the requests to other nodes",
+					ArgsUsage: "[faults file]",
+					Before:    printBanner,
+					Action:    nodeFaults,
+					Flags: []cli.Flag{
+						cli.BoolFlag{
+							Name:  "disable, d",
+							Usage: "Stop injecting faults (keeping the rules)",
+						},
+					},
+				},
+			},
+		},
 		{
 			Name:      "exit",
 			ShortName: "e",
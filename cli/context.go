@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"github.com/strabox/caravela/api/client"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// userContext holds the user's credentials and the CARAVELA's instance/daemon used by the CLI.
+type userContext struct {
+	IP    string `yaml:"ip"`
+	Port  int    `yaml:"port"`
+	Token string `yaml:"token"`
+}
+
+// loadUserContext reads the user's context file. A missing context file is only an error when it was given.
+func loadUserContext(c *cli.Context) *userContext {
+	res := &userContext{}
+
+	contextFile := c.GlobalString("context")
+	if contextFile == "" {
+		contextFile = filepath.Join(os.Getenv("HOME"), defaultContextFile)
+	}
+
+	fileContent, err := ioutil.ReadFile(contextFile)
+	if err != nil {
+		if os.IsNotExist(err) && !c.GlobalIsSet("context") {
+			return res
+		}
+		fatalPrintf("Impossible read context file %s. %s\n", contextFile, err)
+	}
+
+	if err := yaml.Unmarshal(fileContent, res); err != nil {
+		fatalPrintf("Problem parsing context file %s. %s\n", contextFile, err)
+	}
+	return res
+}
+
+// newClient creates a user client of the CARAVELA system with the user's context.
+// The IP given explicitly in the command line overrides the context's one, a zero timeout keeps the default.
+func newClient(c *cli.Context, requestTimeout time.Duration) *client.Client {
+	userCtx := loadUserContext(c)
+
+	caravelaIP := c.GlobalString("ip")
+	if userCtx.IP != "" && !c.GlobalIsSet("ip") {
+		caravelaIP = userCtx.IP
+	}
+
+	config := client.DefaultConfig(caravelaIP)
+	if userCtx.Port != 0 {
+		config.SetCaravelaInstancePort(userCtx.Port)
+	}
+	if requestTimeout > 0 {
+		config.SetRequestTimeout(requestTimeout)
+	}
+	config.SetAuthToken(userCtx.Token)
+	return client.NewCaravela(config)
+}
@@ -14,7 +14,7 @@ func create(c *cli.Context) {
 		fatalPrintf("Invalid host IP address: %s\n", hostIP)
 	}
 
-	if err := initNode(hostIP, c.String("config"), false, ""); err != nil {
+	if err := initNode(hostIP, c.String("config"), false, "", ""); err != nil {
 		fatalPrintf("Problem: %s\n", err)
 	}
 }
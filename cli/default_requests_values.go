@@ -4,6 +4,7 @@ import (
 	"github.com/strabox/caravela/api/types"
 	"github.com/strabox/caravela/configuration"
 	"net"
+	"time"
 )
 
 // ================== Defaults values for CLI flags and requests ====================
@@ -12,6 +13,7 @@ const defaultConfigurationFile = configuration.DefaultFilePath
 const defaultLogLevel = "fatal"
 const defaultCaravelaInstanceIP = "127.0.0.1" // Target the local's node,
 const defaultHostIP = ""
+const defaultContextFile = ".caravela/context.yml" // Relative to the user's home directory.
 
 const defaultContainerName = ""
 const defaultCPUClass = types.LowCPUClassStr
@@ -19,6 +21,11 @@ const defaultCPUs = 0
 const defaultMemory = 0
 const defaultContainerGroupPolicy = types.SpreadGroupPolicyStr
 
+const defaultUsageSince = "24h"
+const defaultUsageFormat = "table"
+
+const defaultDrainTimeout = 2 * time.Minute
+
 var defaultContainerArgs = make([]string, 0)
 var defaultPortMappingsArgs = make([]string, 0)
 
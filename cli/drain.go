@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"github.com/urfave/cli"
+	"time"
+)
+
+// Extra time, over the drain timeout, that the request waits for the node's answer.
+const drainRequestMargin = 10 * time.Second
+
+func drainNode(c *cli.Context) {
+	timeout := c.Duration("timeout")
+
+	// Create a user client of the CARAVELA system
+	caravelaClient := newClient(c, timeout+drainRequestMargin)
+
+	drainStatus, err := caravelaClient.Drain(context.Background(), c.Bool("leave"), timeout)
+	if err != nil {
+		fatalPrintf("Error with request: %s\n", err)
+	}
+
+	fmt.Printf("Containers moved: %d\n", drainStatus.ContainersMoved)
+	if drainStatus.ContainersRemaining > 0 {
+		fmt.Printf("Containers still running (timeout): %d\n", drainStatus.ContainersRemaining)
+	}
+	if drainStatus.Leaving {
+		fmt.Println("Node is leaving the system")
+	}
+}
+
+func undrainNode(c *cli.Context) {
+	// Create a user client of the CARAVELA system
+	caravelaClient := newClient(c, 0)
+
+	if err := caravelaClient.Undrain(context.Background()); err != nil {
+		fatalPrintf("Error with request: %s\n", err)
+	}
+}
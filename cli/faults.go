@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/strabox/caravela/api/types"
+	"github.com/urfave/cli"
+	"io/ioutil"
+)
+
+func nodeFaults(c *cli.Context) {
+	// Create a user client of the CARAVELA system
+	caravelaClient := newClient(c, 0)
+
+	if c.NArg() > 0 { // Set the faults described in the file.
+		fileContent, err := ioutil.ReadFile(c.Args().First())
+		if err != nil {
+			fatalPrintf("Impossible read faults file %s. %s\n", c.Args().First(), err)
+		}
+
+		faults := &types.Faults{}
+		if err := json.Unmarshal(fileContent, faults); err != nil {
+			fatalPrintf("Problem parsing faults file %s. %s\n", c.Args().First(), err)
+		}
+		if c.Bool("disable") {
+			faults.Enabled = false
+		}
+
+		if err := caravelaClient.SetFaults(context.Background(), faults); err != nil {
+			fatalPrintf("Error with request: %s\n", err)
+		}
+		return
+	}
+
+	faults, err := caravelaClient.Faults(context.Background())
+	if err != nil {
+		fatalPrintf("Error with request: %s\n", err)
+	}
+
+	if c.Bool("disable") && faults.Enabled {
+		faults.Enabled = false
+		if err := caravelaClient.SetFaults(context.Background(), faults); err != nil {
+			fatalPrintf("Error with request: %s\n", err)
+		}
+	}
+
+	fmt.Printf("Enabled: %t\n", faults.Enabled)
+	for i, rule := range faults.Rules {
+		fmt.Printf("Rule %d: Method: %s, From: %s, To: %s, Delay: %s, Drop: %.2f, Error: %.2f\n", i,
+			orAll(rule.Method), orAll(rule.FromIP), orAll(rule.ToIP), rule.Delay, rule.DropRate, rule.ErrorRate)
+	}
+}
+
+// orAll returns the value or "*" (matches all) when the value is empty.
+func orAll(value string) string {
+	if value == "" {
+		return "*"
+	}
+	return value
+}
@@ -2,27 +2,49 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/strabox/caravela/api"
 	"github.com/strabox/caravela/api/remote"
 	"github.com/strabox/caravela/api/rest"
+	restUtil "github.com/strabox/caravela/api/rest/util"
+	"github.com/strabox/caravela/api/rpc"
+	"github.com/strabox/caravela/api/security"
 	"github.com/strabox/caravela/api/types"
 	"github.com/strabox/caravela/configuration"
 	"github.com/strabox/caravela/docker"
 	"github.com/strabox/caravela/node"
 	"github.com/strabox/caravela/node/common/guid"
+	"github.com/strabox/caravela/node/external"
 	overlayFactory "github.com/strabox/caravela/overlay/factory"
 	"strings"
 )
 
-func initNode(hostIP, configFilePath string, join bool, joinIP string) error {
+func initNode(hostIP, configFilePath string, join bool, joinIP string, bootstrapToken string) error {
 	var systemConfigurations *configuration.Configuration
+	var credentials *security.Credentials = nil // Only used if the nodes communicate using mutual TLS.
+	var authority *security.Authority = nil     // Only used if this node issues certificates.
 	var err error = nil
 
+	// Protocol of the other nodes, negotiated in all the requests between them (including the join's ones).
+	protocols := restUtil.NewProtocols()
+
 	// Create configuration structures from the configuration file (if it exists)
 	if join {
 		defaultConfigs := configuration.Default(hostIP)
-		caravelaClient := remote.NewHttpClient(defaultConfigs.APIPort(), defaultConfigs.APITimeout())
+		caravelaClient := remote.NewHttpClient(defaultConfigs.APIPort(), defaultConfigs.APITimeout(), protocols)
+
+		if bootstrapToken != "" { // Obtain the node's certificate before any other request.
+			credentials, err = remote.RequestCredentials(context.Background(), joinIP, defaultConfigs.APIPort(),
+				defaultConfigs.APITimeout(), hostIP, bootstrapToken, protocols)
+			if err != nil {
+				return err
+			}
+			caravelaClient = remote.NewSecureHttpClient(defaultConfigs.APIPort(), defaultConfigs.APITimeout(),
+				credentials, protocols)
+		}
 
+		// The join fails if the node joined runs an incompatible protocol (or rejects the local node's one).
 		systemConfigurations, err = caravelaClient.ObtainConfiguration(context.Background(), &types.Node{IP: joinIP})
 		if err != nil {
 			return err
@@ -32,6 +54,10 @@ func initNode(hostIP, configFilePath string, join bool, joinIP string) error {
 		if err != nil {
 			return err
 		}
+
+		if systemConfigurations.MutualTLS() && credentials == nil {
+			return errors.New("the system uses mutual TLS, a bootstrap token is necessary to join")
+		}
 	} else {
 		systemConfigurations, err = configuration.ReadFromFile(hostIP, configFilePath)
 		if err != nil && systemConfigurations != nil && strings.Contains(err.Error(), "cannot find the file") {
@@ -39,6 +65,13 @@ func initNode(hostIP, configFilePath string, join bool, joinIP string) error {
 		} else if err != nil && systemConfigurations == nil {
 			return err
 		}
+
+		if systemConfigurations.MutualTLS() {
+			credentials, authority, err = loadCredentials(hostIP, systemConfigurations)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	// Print/log the systemConfigurations values
@@ -52,13 +85,25 @@ func initNode(hostIP, configFilePath string, join bool, joinIP string) error {
 	overlayConfigured := overlayFactory.Create(systemConfigurations)
 
 	// Create CARAVELA's Remote httpClient
-	caravelaCli := remote.NewHttpClient(systemConfigurations.APIPort(), systemConfigurations.APITimeout())
+	var caravelaCli external.Caravela = remote.NewHttpClient(systemConfigurations.APIPort(),
+		systemConfigurations.APITimeout(), protocols)
+	if credentials != nil {
+		caravelaCli = remote.NewSecureHttpClient(systemConfigurations.APIPort(), systemConfigurations.APITimeout(),
+			credentials, protocols)
+	}
 
 	// Create Docker client
-	dockerClient := docker.NewDockerClient(systemConfigurations)
+	dockerClient := docker.CreateClient(systemConfigurations)
 
 	// Create the API server
-	apiServer := rest.NewServer(systemConfigurations.APIPort())
+	var apiServer api.Server = rest.NewServer(hostIP, systemConfigurations.APIPort(), credentials, authority, protocols)
+
+	// The offers, launch and stop messages go through gRPC (the other messages keep using the REST API)
+	if systemConfigurations.Transport() == "grpc" {
+		caravelaCli = rpc.NewClient(systemConfigurations.GRPCPort(), systemConfigurations.APITimeout(), credentials,
+			caravelaCli)
+		apiServer = rpc.NewServer(hostIP, systemConfigurations.GRPCPort(), credentials, apiServer)
+	}
 
 	// Create a Caravela's Node passing all the external components and start its functions.
 	thisNode := node.NewNode(systemConfigurations, overlayConfigured, caravelaCli, dockerClient, apiServer)
@@ -70,3 +115,27 @@ func initNode(hostIP, configFilePath string, join bool, joinIP string) error {
 
 	return nil
 }
+
+// loadCredentials loads the node's credentials and the cluster's CA (if this node issues certificates).
+func loadCredentials(hostIP string, config *configuration.Configuration) (*security.Credentials, *security.Authority, error) {
+	var authority *security.Authority = nil
+	var err error = nil
+
+	if config.SecurityCAKeyFile() != "" {
+		authority, err = security.LoadAuthority(config.SecurityCAFile(), config.SecurityCAKeyFile(),
+			config.BootstrapToken(), config.CertificateValidity())
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if config.SecurityCertFile() != "" {
+		credentials, err := security.LoadCredentials(config.SecurityCAFile(), config.SecurityCertFile(),
+			config.SecurityKeyFile())
+		return credentials, authority, err
+	} else if authority != nil {
+		credentials, err := authority.NodeCredentials(hostIP)
+		return credentials, authority, err
+	}
+	return nil, nil, errors.New("mutual TLS needs the node's certificate or the CA's private key")
+}
@@ -23,7 +23,7 @@ func join(c *cli.Context) {
 		fatalPrintln("Please provide a valid join IP address")
 	}
 
-	if err := initNode(hostIP, "", true, joinIP); err != nil {
+	if err := initNode(hostIP, "", true, joinIP, c.String("token")); err != nil {
 		fatalPrintf("Error: %s\n", err)
 	}
 }
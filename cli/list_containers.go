@@ -3,14 +3,13 @@ package cli
 import (
 	"context"
 	"fmt"
-	"github.com/strabox/caravela/api/client"
 	"github.com/urfave/cli"
 	"strings"
 )
 
 func listContainer(c *cli.Context) {
 	// Create a user client of the CARAVELA system
-	caravelaClient := client.NewCaravelaIP(c.GlobalString("ip"))
+	caravelaClient := newClient(c, 0)
 
 	containersStatus, err := caravelaClient.ListContainers(context.Background())
 	if err != nil {
@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"github.com/urfave/cli"
+)
+
+func showQuota(c *cli.Context) {
+	// Create a user client of the CARAVELA system
+	caravelaClient := newClient(c, 0)
+
+	userQuota, err := caravelaClient.Quota(context.Background())
+	if err != nil {
+		fatalPrintf("Error with request: %s\n", err)
+	}
+
+	var columnSize = 15
+	presentQuotaLine := func(resource string, used, limit, remaining int) {
+		limitStr, remainingStr := "-", "-" // Unlimited resource
+		if limit > 0 {
+			limitStr, remainingStr = fmt.Sprintf("%d", limit), fmt.Sprintf("%d", remaining)
+		}
+		presentTableLine([]string{resource, fmt.Sprintf("%d", used), limitStr, remainingStr}, columnSize)
+	}
+
+	presentTableLine([]string{"RESOURCE", "USED", "LIMIT", "REMAINING"}, columnSize)
+	presentQuotaLine("Containers", userQuota.Usage.Containers, userQuota.Limits.Containers, userQuota.Remaining.Containers)
+	presentQuotaLine("CPUs", userQuota.Usage.CPUs, userQuota.Limits.CPUs, userQuota.Remaining.CPUs)
+	presentQuotaLine("Memory (MB)", userQuota.Usage.Memory, userQuota.Limits.Memory, userQuota.Remaining.Memory)
+}
@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"github.com/urfave/cli"
+)
+
+func showReputations(c *cli.Context) {
+	// Create a user client of the CARAVELA system
+	caravelaClient := newClient(c, 0)
+
+	reputations, err := caravelaClient.Reputations(context.Background())
+	if err != nil {
+		fatalPrintf("Error with request: %s\n", err)
+	}
+
+	var columnSize = 15
+	presentTableLine([]string{"SUPPLIER", "SCORE", "LAUNCHES", "FAILED", "TIMED OUT", "LOST", "REFRESHES", "REFRESH FAILS"},
+		columnSize)
+	for _, reputation := range reputations {
+		presentTableLine([]string{
+			reputation.SupplierIP,
+			fmt.Sprintf("%.2f", reputation.Score),
+			fmt.Sprintf("%d", reputation.LaunchesSucceeded),
+			fmt.Sprintf("%d", reputation.LaunchesFailed),
+			fmt.Sprintf("%d", reputation.LaunchesTimedOut),
+			fmt.Sprintf("%d", reputation.ContainersLost),
+			fmt.Sprintf("%d", reputation.RefreshesSucceeded),
+			fmt.Sprintf("%d", reputation.RefreshesFailed),
+		}, columnSize)
+	}
+}
@@ -3,7 +3,6 @@ package cli
 import (
 	"context"
 	"fmt"
-	"github.com/strabox/caravela/api/client"
 	"github.com/strabox/caravela/api/types"
 	"github.com/strabox/caravela/util"
 	"github.com/urfave/cli"
@@ -63,7 +62,9 @@ func runContainers(c *cli.Context) {
 					CPUs:     service.CPUs,
 					Memory:   service.Memory,
 				},
-				GroupPolicy: groupPolicy,
+				GroupPolicy:    groupPolicy,
+				RegistryAuth:   service.RegistryAuth.toRegistryAuth(),
+				ImageSignature: service.ImageSignature,
 			}
 			i++
 		}
@@ -88,6 +89,14 @@ func runContainers(c *cli.Context) {
 			fatalPrintln(err)
 		}
 
+		var registryAuth *types.RegistryAuth = nil
+		if c.String("registry-user") != "" {
+			registryAuth = &types.RegistryAuth{
+				Username: c.String("registry-user"),
+				Password: c.String("registry-password"),
+			}
+		}
+
 		containersConfigs = make([]types.ContainerConfig, 1)
 		containersConfigs[0] = types.ContainerConfig{
 			Name:         c.String("name"),
@@ -99,11 +108,13 @@ func runContainers(c *cli.Context) {
 				CPUs:     int(c.Uint("cpus")),
 				Memory:   int(c.Uint("memory")),
 			},
+			RegistryAuth:   registryAuth,
+			ImageSignature: c.String("signature"),
 		}
 	}
 
 	// Create a user client of the CARAVELA system
-	caravelaClient := client.NewCaravelaTimeoutIP(c.GlobalString("ip"), 3600*time.Second) // TODO: Timeout hack to handler the submit container request
+	caravelaClient := newClient(c, 3600*time.Second) // TODO: Timeout hack to handler the submit container request
 	err := caravelaClient.SubmitContainers(context.Background(), containersConfigs)
 	if err != nil {
 		fatalPrintln(err)
@@ -167,14 +178,35 @@ func validatePortMappings(inputPortMappings []string) ([]types.PortMapping, erro
 
 // containerRequest holds the YAML file content for a container deployment request.
 type containerRequest struct {
-	Name         string   `yaml:"name"`
-	ImageKey     string   `yaml:"image"`
-	Args         []string `yaml:"args"`
-	PortMappings []string `yaml:"ports"`
-	CPUPower     string   `yaml:"cpu_power"`
-	CPUs         int      `yaml:"cpus"`
-	Memory       int      `yaml:"memory"`
-	GroupPolicy  string   `yaml:"group_policy"`
+	Name           string               `yaml:"name"`
+	ImageKey       string               `yaml:"image"`
+	Args           []string             `yaml:"args"`
+	PortMappings   []string             `yaml:"ports"`
+	CPUPower       string               `yaml:"cpu_power"`
+	CPUs           int                  `yaml:"cpus"`
+	Memory         int                  `yaml:"memory"`
+	GroupPolicy    string               `yaml:"group_policy"`
+	RegistryAuth   *registryAuthRequest `yaml:"registry_auth"`
+	ImageSignature string               `yaml:"signature"`
+}
+
+// registryAuthRequest holds the YAML file content for the credentials to pull a container's image.
+type registryAuthRequest struct {
+	Server   string `yaml:"server"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// toRegistryAuth converts the YAML credentials into the API credentials (nil if there are no credentials).
+func (r *registryAuthRequest) toRegistryAuth() *types.RegistryAuth {
+	if r == nil {
+		return nil
+	}
+	return &types.RegistryAuth{
+		ServerAddress: r.Server,
+		Username:      r.Username,
+		Password:      r.Password,
+	}
 }
 
 func (s *containerRequest) UnmarshalYAML(unmarshal func(interface{}) error) error {
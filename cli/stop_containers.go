@@ -2,7 +2,6 @@ package cli
 
 import (
 	"context"
-	"github.com/strabox/caravela/api/client"
 	"github.com/urfave/cli"
 )
 
@@ -17,7 +16,7 @@ func stopContainers(c *cli.Context) {
 	}
 
 	// Create a user client of the CARAVELA system
-	caravelaClient := client.NewCaravelaIP(c.GlobalString("ip"))
+	caravelaClient := newClient(c, 0)
 
 	err := caravelaClient.StopContainers(context.Background(), containersIDs)
 	if err != nil {
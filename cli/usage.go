@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/urfave/cli"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// usageLine is the usage report line of a buyer (and user) in the node.
+type usageLine struct {
+	BuyerIP         string  `json:"BuyerIP"`
+	UserID          string  `json:"UserID"`
+	Containers      int     `json:"Containers"`
+	CPUSeconds      float64 `json:"CPUSeconds"`
+	MemoryMBSeconds float64 `json:"MemoryMBSeconds"`
+}
+
+func usageReport(c *cli.Context) {
+	since, err := parseSince(c.String("since"))
+	if err != nil {
+		fatalPrintf("Invalid since: %s. %s\n", c.String("since"), err)
+	}
+
+	// Create a user client of the CARAVELA system
+	caravelaClient := newClient(c, 0)
+
+	records, clientErr := caravelaClient.Accounting(context.Background(), since)
+	if clientErr != nil {
+		fatalPrintf("Error with request: %s\n", clientErr)
+	}
+
+	report := make([]usageLine, 0)
+	reportIndex := make(map[string]int) // BuyerIP+UserID->Index in the report
+	for _, record := range records {
+		key := record.BuyerIP + "/" + record.UserID
+		index, exist := reportIndex[key]
+		if !exist {
+			index = len(report)
+			reportIndex[key] = index
+			report = append(report, usageLine{BuyerIP: record.BuyerIP, UserID: record.UserID})
+		}
+		report[index].Containers++
+		report[index].CPUSeconds += record.CPUSeconds
+		report[index].MemoryMBSeconds += record.MemoryMBSeconds
+	}
+	sort.Slice(report, func(i, j int) bool { return report[i].CPUSeconds > report[j].CPUSeconds })
+
+	switch c.String("format") {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(report)
+	case "csv":
+		writer := csv.NewWriter(os.Stdout)
+		writer.Write([]string{"buyer_ip", "user", "containers", "cpu_seconds", "memory_mb_seconds"})
+		for _, usage := range report {
+			writer.Write([]string{usage.BuyerIP, usage.UserID, strconv.Itoa(usage.Containers),
+				strconv.FormatFloat(usage.CPUSeconds, 'f', 0, 64), strconv.FormatFloat(usage.MemoryMBSeconds, 'f', 0, 64)})
+		}
+		writer.Flush()
+	case "table":
+		var columnSize = 20
+		presentTableLine([]string{"BUYER", "USER", "CONTAINERS", "CPU-SECONDS", "MEMORY-MB-SECONDS"}, columnSize)
+		for _, usage := range report {
+			presentTableLine([]string{usage.BuyerIP, usage.UserID, strconv.Itoa(usage.Containers),
+				fmt.Sprintf("%.0f", usage.CPUSeconds), fmt.Sprintf("%.0f", usage.MemoryMBSeconds)}, columnSize)
+		}
+	default:
+		fatalPrintf("Invalid format: %s, it must be table, csv or json\n", c.String("format"))
+	}
+}
+
+// parseSince parses the beginning of the usage report, a duration until now (e.g. 24h) or a RFC3339 time.
+func parseSince(since string) (time.Time, error) {
+	if since == "" {
+		return time.Time{}, nil
+	}
+	if ago, err := time.ParseDuration(since); err == nil {
+		return time.Now().Add(-ago), nil
+	}
+	return time.Parse(time.RFC3339, since)
+}
@@ -16,6 +16,9 @@ const minimumDockerEngineVersion = "1.35"
 // Default port for the CARAVELA's API endpoints
 const defaultCaravelaAPIPort = 8001
 
+// Default port for the CARAVELA's gRPC server (grpc transport)
+const defaultCaravelaGRPCPort = 8002
+
 // Directory path to where search for the configuration file. (Directory of binary execution)
 const DefaultFilePath = "configuration.toml"
 
@@ -33,8 +36,53 @@ type Configuration struct {
 
 // Configurations for the local host node.
 type host struct {
-	IP               string `json:"-"`                // Do not encode host IP due to security concerns!!!
-	DockerAPIVersion string `json:"DockerAPIVersion"` // API Version of the local node Docker's engine
+	IP               string           `json:"-"`                // Do not encode host IP due to security concerns!!!
+	DockerAPIVersion string           `json:"DockerAPIVersion"` // API Version of the local node Docker's engine
+	DockerEngine     string           `json:"DockerEngine"`     // Docker engine used, the real "docker" or the in-memory "fake"
+	FakeDockerEngine fakeDockerEngine `json:"FakeDockerEngine"` // In-memory fake Docker engine configs
+	ImagePolicy      imagePolicy      `json:"-"`                // Images allowed to run in the node (local to each node, never shared)
+	Admission        admissionPolicy  `json:"-"`                // Rules to admit the buyers' launch requests (local to each node, never shared)
+	Users            []UserAccount    `json:"-"`                // Users of the node's user API (local to each node, never shared)
+	AccountingFile   string           `json:"-"`                // File where the containers' resources usage is recorded (empty keeps it in memory)
+	ShutdownMode     string           `json:"-"`                // What happens to the containers when the node stops: remove or keep
+	ContainersState  string           `json:"-"`                // File where the kept containers are recorded to be re-registered when the node restarts
+	FaultInjection   bool             `json:"-"`                // Allows injecting faults in the requests sent to other nodes (chaos experiments)
+	FaultsFile       string           `json:"-"`                // JSON file with the faults injected in the requests sent to other nodes (empty injects none)
+}
+
+// UserAccount holds a user that can use the node's user API, identified by its API token.
+type UserAccount struct {
+	Name  string `json:"-"` // User's ID, owner of the containers it submits
+	Token string `json:"-"` // API token presented by the user
+	Admin bool   `json:"-"` // If the user can administrate the node e.g. shut it down
+}
+
+// Configurations for the images that the node accepts to run as a supplier.
+type imagePolicy struct {
+	AllowedRegistries   []string `json:"-"` // Registries allowed (empty allows all) e.g. docker.io
+	AllowedRepositories []string `json:"-"` // Repositories patterns allowed (empty allows all) e.g. library/*
+	RequireDigest       bool     `json:"-"` // If the images must be pinned by digest e.g. redis@sha256:...
+	RequireSignature    bool     `json:"-"` // If the images digests must be signed by a trusted key
+	TrustedKeys         []string `json:"-"` // Paths of the trusted PEM public keys (RSA or ECDSA)
+}
+
+// Configurations for the admission of containers launch requests from other nodes (buyers).
+type admissionPolicy struct {
+	AllowedBuyers         []string `json:"-"` // Buyers IPs or CIDRs allowed (empty allows all)
+	DeniedBuyers          []string `json:"-"` // Buyers IPs or CIDRs denied (takes precedence over allowed)
+	MaxContainersPerBuyer int      `json:"-"` // Maximum number of running containers of each buyer (0 is unlimited)
+	MaxCPUsPerBuyer       int      `json:"-"` // Maximum CPUs used by the containers of each buyer (0 is unlimited)
+	MaxMemoryPerBuyer     int      `json:"-"` // Maximum memory (in MB) used by the containers of each buyer (0 is unlimited)
+	ForbiddenHostPorts    []string `json:"-"` // Host ports ranges that can't be mapped e.g. 0-1023
+	NoNewPrivileges       bool     `json:"-"` // If the containers are prevented from gaining new privileges
+	AuditFile             string   `json:"-"` // File where the rejected requests are recorded (empty only logs them)
+}
+
+// Configurations for the in-memory fake Docker engine.
+type fakeDockerEngine struct {
+	CPUClass int `json:"CPUClass"` // CPU class of the simulated engine
+	CPUs     int `json:"CPUs"`     // Number of CPUs of the simulated engine
+	Memory   int `json:"Memory"`   // Memory (in MB) of the simulated engine
 }
 
 // ##################################################################################################
@@ -47,11 +95,64 @@ type caravela struct {
 	DiscoveryBackend discoveryBackend    `json:"DiscoveryBackend"` // Define what strategy is used to manage the offers
 	APIPort          int                 `json:"APIPort"`          // Port of API REST endpoints
 	APITimeout       duration            `json:"APITimeout"`       // Timeout for API REST requests
+	Transport        string              `json:"Transport"`        // Transport of the offers, launch and stop messages: http or grpc
+	GRPCPort         int                 `json:"GRPCPort"`         // Port of the gRPC server (only used by the grpc transport)
 	CPUSlices        int                 `json:"CPUSlices"`        // Number of equal slices for a CPU/Core e.g. 2
 	CPUOvercommit    int                 `json:"CPUOvercommit"`    // CPU overcommit percentage e.g. 140%
 	MemoryOvercommit int                 `json:"MemoryOvercommit"` // Memory overcommit percentage e.g. 120%
 	Resources        ResourcesPartitions `json:"FreeResources"`    // FreeResources partitions
 	SchedulingPolicy string              `json:"SchedulingPolicy"` // Scheduling policies used when several nodes are available.
+	Security         security            `json:"Security"`         // Security of the communication between nodes
+	Quotas           quotas              `json:"Quotas"`           // Users' resources quotas in the whole system
+	Credits          credits             `json:"Credits"`          // Credits exchanged between buyers and suppliers
+	Reputation       reputation          `json:"Reputation"`       // Reputation of the suppliers used when scheduling
+}
+
+// Configurations for the reputation that each node gives to the suppliers based on what it observes.
+type reputation struct {
+	Enabled  bool     `json:"Enabled"`  // If the offers of suppliers with bad reputation are tried last
+	MinScore float64  `json:"MinScore"` // Score, between 0 and 1, below which a supplier has bad reputation
+	HalfLife duration `json:"HalfLife"` // Time for the weight of the observed outcomes to decay to half
+}
+
+// Configurations for the credits that the buyers pay to the suppliers for the resources of their containers.
+type credits struct {
+	Enabled               bool     `json:"Enabled"`               // If the nodes exchange credits
+	InitialBalance        float64  `json:"InitialBalance"`        // Credits that each node has when it joins
+	CPUPrice              float64  `json:"CPUPrice"`              // Credits per CPU per hour
+	MemoryPrice           float64  `json:"MemoryPrice"`           // Credits per GB of memory per hour
+	BillingInterval       duration `json:"BillingInterval"`       // Interval to pay the resource-time used by the running containers
+	NegativeBalancePolicy string   `json:"NegativeBalancePolicy"` // What suppliers do to buyers with negative balance: refuse or deprioritize
+	DeprioritizeDelay     duration `json:"DeprioritizeDelay"`     // Delay of the deprioritized launch requests
+	ReceiptsFile          string   `json:"-"`                     // File where the node keeps the receipts (empty keeps them in memory)
+	KeyFile               string   `json:"-"`                     // File with the node's receipts signing key (empty uses a new key)
+}
+
+// Configurations for the users' resources quotas, shared by all the nodes so they are enforced equally.
+type quotas struct {
+	MaxContainers int         `json:"MaxContainers"` // Default maximum running containers of each user (0 is unlimited)
+	MaxCPUs       int         `json:"MaxCPUs"`       // Default maximum CPUs used by each user (0 is unlimited)
+	MaxMemory     int         `json:"MaxMemory"`     // Default maximum memory (in MB) used by each user (0 is unlimited)
+	Users         []UserQuota `json:"Users"`         // Quotas of specific users, overriding the defaults
+}
+
+// UserQuota holds the resources quota of a specific user.
+type UserQuota struct {
+	Name          string `json:"Name"`          // User's ID
+	MaxContainers int    `json:"MaxContainers"` // Maximum running containers (0 is unlimited)
+	MaxCPUs       int    `json:"MaxCPUs"`       // Maximum CPUs (0 is unlimited)
+	MaxMemory     int    `json:"MaxMemory"`     // Maximum memory in MB (0 is unlimited)
+}
+
+// Configurations for the mutual TLS between the CARAVELA's nodes.
+type security struct {
+	MutualTLS           bool     `json:"MutualTLS"`           // If the nodes communicate using mutual TLS
+	CertificateValidity duration `json:"CertificateValidity"` // Validity of the certificates issued to the nodes
+	CAFile              string   `json:"-"`                   // Cluster's CA certificate (PEM)
+	CAKeyFile           string   `json:"-"`                   // Cluster's CA private key, only in the nodes that issue certificates
+	CertFile            string   `json:"-"`                   // Node's certificate (empty issues one with the CA key)
+	KeyFile             string   `json:"-"`                   // Node's certificate private key
+	BootstrapToken      string   `json:"-"`                   // Secret that joining nodes present to obtain a certificate
 }
 
 type discoveryBackend struct {
@@ -70,6 +171,13 @@ type offeringChordDiscBackend struct {
 	MaxRefreshesMissed        int      `json:"MaxRefreshesMissed"`     // Maximum amount of refreshes a trader failed to send to the supplier
 	PartitionsStateBufferSize int      `json:"PartitionsStateBufferSize"`
 	MaxPartitionsSearch       int      `json:"MaxPartitionsSearch"`
+	SearchParallelism         int      `json:"SearchParallelism"`   // Number of partitions searched at the same time
+	SearchTimeout             duration `json:"SearchTimeout"`       // Deadline of an offers search
+	SearchMinOffers           int      `json:"SearchMinOffers"`     // Offers collected after which the search stops
+	SearchHedgeDelay          duration `json:"SearchHedgeDelay"`    // Time waiting for a trader before asking the next one too
+	OfferReplicas             int      `json:"OfferReplicas"`       // Number of successor traders that keep a replica of the offers
+	ReplicationInterval       duration `json:"ReplicationInterval"` // Interval for trader to replicate its offers into the successors
+	ReplicaTimeout            duration `json:"ReplicaTimeout"`      // Time without replication after which the replicated trader is suspected
 	// Debug performance flags
 	SpreadOffers             bool `json:"SpreadOffers"`          // Used to tell if the spread offers mechanism is used or not.
 	SpreadPartitionsState    bool `json:"SpreadPartitionsState"` // Used to tell if the spread partitions state is used or not.
@@ -88,7 +196,37 @@ type randomChordDiscBackend struct {
 
 // Configuration for the CARAVELA's container image storage
 type imagesStorageBackend struct {
-	StorageBackend string `json:"StorageBackend"` // Type of storage of images used to share them
+	StorageBackend string              `json:"StorageBackend"` // Type of storage of images used to share them
+	PeersBackend   peersStorageBackend `json:"PeersBackend"`   // Peers storage backend configs.
+	Registry       registryBackend     `json:"-"`              // Registry storage backend configs (local to each node, never shared).
+	ArchiveBackend archiveBackend      `json:"ArchiveBackend"` // Archive storage backend configs.
+}
+
+// Configuration for the local/offline images archive storage backend
+type archiveBackend struct {
+	Location        string   `json:"Location"`        // Directory or HTTP(S) URL where the docker save archives are stored
+	IndexFile       string   `json:"IndexFile"`       // Index file (inside the location) that maps image keys to archives
+	DownloadTimeout duration `json:"DownloadTimeout"` // Timeout to download an archive from an HTTP location
+}
+
+// Configuration for the private registries storage backend
+type registryBackend struct {
+	CredentialsFile string                `json:"-"` // Docker's config.json like file with the registries credentials
+	Credentials     []RegistryCredentials `json:"-"` // Credentials for each private registry
+}
+
+// RegistryCredentials holds the credentials for a specific private registry.
+type RegistryCredentials struct {
+	Registry string `json:"-"` // Registry address e.g. registry.example.com:5000
+	Username string `json:"-"`
+	Password string `json:"-"`
+}
+
+// Configuration for the peers images storage backend (images shared between CARAVELA's nodes)
+type peersStorageBackend struct {
+	AdvertiseInterval duration `json:"AdvertiseInterval"` // Interval for a node to re-advertise the images it holds
+	HolderTimeout     duration `json:"HolderTimeout"`     // Time that a holder is known without being re-advertised
+	DownloadTimeout   duration `json:"DownloadTimeout"`   // Timeout to download an image from another node
 }
 
 // ##################################################################################################
@@ -97,9 +235,10 @@ type imagesStorageBackend struct {
 
 // Configurations for the node overlay
 type overlay struct {
-	Overlay     string `json:"Overlay"`     // Overlay configured
-	OverlayPort int    `json:"OverlayPort"` // Port of the overlay endpoints
-	Chord       chord  `json:"Chord"`       // overlay configurations
+	Overlay     string   `json:"Overlay"`     // Overlay configured
+	OverlayPort int      `json:"OverlayPort"` // Port of the overlay endpoints
+	Chord       chord    `json:"Chord"`       // Chord overlay configurations
+	Kademlia    kademlia `json:"Kademlia"`    // Kademlia overlay configurations
 }
 
 type chord struct {
@@ -109,6 +248,15 @@ type chord struct {
 	HashSizeBits  int      `json:"HashSizeBits"`  // Number of chord hash size (in bits)
 }
 
+type kademlia struct {
+	Timeout         duration `json:"Timeout"`         // Timeout for the kademlia messages
+	VirtualNodes    int      `json:"VirtualNodes"`    // Number of kademlia virtual nodes per physical node
+	BucketSize      int      `json:"BucketSize"`      // Maximum number of contacts in each k-bucket (k)
+	Parallelism     int      `json:"Parallelism"`     // Number of concurrent messages in a lookup (alpha)
+	LookupSize      int      `json:"LookupSize"`      // Number of (closest) nodes returned by a lookup
+	RefreshInterval duration `json:"RefreshInterval"` // Interval to verify the contacts and refresh the k-buckets
+}
+
 // Default returns the configuration structure with all the default values for the system to work.
 func Default(hostIP string) *Configuration {
 	refreshingInterval := duration{Duration: 15 * time.Second}
@@ -117,15 +265,77 @@ func Default(hostIP string) *Configuration {
 		Host: host{
 			IP:               hostIP,
 			DockerAPIVersion: minimumDockerEngineVersion,
+			DockerEngine:     "docker",
+			FakeDockerEngine: fakeDockerEngine{
+				CPUClass: 0,
+				CPUs:     4,
+				Memory:   4096,
+			},
+			ImagePolicy: imagePolicy{
+				AllowedRegistries:   make([]string, 0),
+				AllowedRepositories: make([]string, 0),
+				RequireDigest:       false,
+				RequireSignature:    false,
+				TrustedKeys:         make([]string, 0),
+			},
+			Admission: admissionPolicy{
+				AllowedBuyers:         make([]string, 0),
+				DeniedBuyers:          make([]string, 0),
+				MaxContainersPerBuyer: 0,
+				MaxCPUsPerBuyer:       0,
+				MaxMemoryPerBuyer:     0,
+				ForbiddenHostPorts:    make([]string, 0),
+				NoNewPrivileges:       false,
+				AuditFile:             "",
+			},
+			Users:           make([]UserAccount, 0),
+			AccountingFile:  "caravela_accounting.log",
+			ShutdownMode:    "remove",
+			ContainersState: "caravela_containers.json",
+			FaultInjection:  false,
+			FaultsFile:      "",
 		},
 		Caravela: caravela{
 			Simulation:       false,
 			APIPort:          defaultCaravelaAPIPort,
 			APITimeout:       duration{Duration: 5 * time.Second},
+			Transport:        "http",
+			GRPCPort:         defaultCaravelaGRPCPort,
 			CPUSlices:        1,
 			CPUOvercommit:    100,
 			MemoryOvercommit: 100,
 			SchedulingPolicy: "binpack",
+			Security: security{
+				MutualTLS:           false,
+				CertificateValidity: duration{Duration: 365 * 24 * time.Hour},
+				CAFile:              "",
+				CAKeyFile:           "",
+				CertFile:            "",
+				KeyFile:             "",
+				BootstrapToken:      "",
+			},
+			Quotas: quotas{
+				MaxContainers: 0,
+				MaxCPUs:       0,
+				MaxMemory:     0,
+				Users:         make([]UserQuota, 0),
+			},
+			Credits: credits{
+				Enabled:               false,
+				InitialBalance:        100,
+				CPUPrice:              1,
+				MemoryPrice:           1,
+				BillingInterval:       duration{Duration: 15 * time.Minute},
+				NegativeBalancePolicy: "refuse",
+				DeprioritizeDelay:     duration{Duration: 2 * time.Second},
+				ReceiptsFile:          "caravela_receipts.log",
+				KeyFile:               "caravela_credits.key",
+			},
+			Reputation: reputation{
+				Enabled:  true,
+				MinScore: 0.3,
+				HalfLife: duration{Duration: 1 * time.Hour},
+			},
 			DiscoveryBackend: discoveryBackend{
 				Backend: "chord-single-offer",
 				OfferingChordBackend: offeringChordDiscBackend{
@@ -138,6 +348,13 @@ func Default(hostIP string) *Configuration {
 					MaxRefreshesMissed:        2,
 					PartitionsStateBufferSize: 15,
 					MaxPartitionsSearch:       3,
+					SearchParallelism:         2,
+					SearchTimeout:             duration{Duration: 5 * time.Second},
+					SearchMinOffers:           1,
+					SearchHedgeDelay:          duration{Duration: 500 * time.Millisecond},
+					OfferReplicas:             2,
+					ReplicationInterval:       duration{Duration: 30 * time.Second},
+					ReplicaTimeout:            duration{Duration: 90 * time.Second},
 					// Debug performance flags
 					SpreadOffers:             true,
 					SpreadPartitionsState:    true,
@@ -177,6 +394,20 @@ func Default(hostIP string) *Configuration {
 		},
 		ImagesStorage: imagesStorageBackend{
 			StorageBackend: "DockerHub",
+			PeersBackend: peersStorageBackend{
+				AdvertiseInterval: duration{Duration: 5 * time.Minute},
+				HolderTimeout:     duration{Duration: 15 * time.Minute},
+				DownloadTimeout:   duration{Duration: 10 * time.Minute},
+			},
+			Registry: registryBackend{
+				CredentialsFile: "",
+				Credentials:     make([]RegistryCredentials, 0),
+			},
+			ArchiveBackend: archiveBackend{
+				Location:        "",
+				IndexFile:       "index.json",
+				DownloadTimeout: duration{Duration: 10 * time.Minute},
+			},
 		},
 		Overlay: overlay{
 			Overlay:     "chord",
@@ -187,6 +418,14 @@ func Default(hostIP string) *Configuration {
 				NumSuccessors: 3,
 				HashSizeBits:  160,
 			},
+			Kademlia: kademlia{
+				Timeout:         duration{Duration: 2 * time.Second},
+				VirtualNodes:    3,
+				BucketSize:      8,
+				Parallelism:     3,
+				LookupSize:      3,
+				RefreshInterval: duration{Duration: 1 * time.Minute},
+			},
 		},
 	}
 }
@@ -215,6 +454,19 @@ func ObtainExternal(hostIP string, config *Configuration) (*Configuration, error
 	res := *config
 	res.Host.IP = hostIP
 
+	// The configurations local to each node are never shared, so the joining node uses the default ones.
+	defaults := Default(hostIP)
+	res.Host.ImagePolicy = defaults.Host.ImagePolicy
+	res.Host.Admission = defaults.Host.Admission
+	res.Host.Users = defaults.Host.Users
+	res.Host.AccountingFile = defaults.Host.AccountingFile
+	res.Host.ShutdownMode = defaults.Host.ShutdownMode
+	res.Host.ContainersState = defaults.Host.ContainersState
+	res.Host.FaultInjection = defaults.Host.FaultInjection
+	res.Host.FaultsFile = defaults.Host.FaultsFile
+	res.Caravela.Credits.ReceiptsFile = defaults.Caravela.Credits.ReceiptsFile
+	res.Caravela.Credits.KeyFile = defaults.Caravela.Credits.KeyFile
+
 	if err := res.validate(); err != nil {
 		return nil, err
 	}
@@ -231,16 +483,108 @@ func (c *Configuration) validate() error {
 		return fmt.Errorf("invalid host ip address: %s", c.HostIP())
 	}
 
+	if c.DockerEngine() != "docker" && c.DockerEngine() != "fake" {
+		return fmt.Errorf("invalid docker engine: %s, it must be docker or fake", c.DockerEngine())
+	}
+
+	if c.DockerEngine() == "fake" && (c.FakeDockerCPUs() <= 0 || c.FakeDockerMemory() <= 0) {
+		return fmt.Errorf("fake docker engine CPUs and Memory must be positive integers")
+	}
+
+	if c.ShutdownMode() != "remove" && c.ShutdownMode() != "keep" {
+		return fmt.Errorf("invalid shutdown mode: %s, it must be remove or keep", c.ShutdownMode())
+	}
+
+	if c.ShutdownMode() == "keep" && c.ContainersStateFile() == "" {
+		return fmt.Errorf("shutdown mode keep needs a containers state file")
+	}
+
+	if c.FaultsFile() != "" && !c.FaultInjection() {
+		return fmt.Errorf("faults file needs the fault injection enabled")
+	}
+
+	if c.ImagePolicyRequireSignature() && len(c.ImagePolicyTrustedKeys()) == 0 {
+		return fmt.Errorf("image policy requires signatures but there are no trusted keys")
+	}
+
+	for _, buyer := range append(c.AdmissionAllowedBuyers(), c.AdmissionDeniedBuyers()...) {
+		if _, _, err := net.ParseCIDR(buyer); err != nil && net.ParseIP(buyer) == nil {
+			return fmt.Errorf("invalid admission buyer: %s, it must be an IP or CIDR", buyer)
+		}
+	}
+
+	if c.AdmissionMaxContainersPerBuyer() < 0 || c.AdmissionMaxCPUsPerBuyer() < 0 || c.AdmissionMaxMemoryPerBuyer() < 0 {
+		return fmt.Errorf("admission per buyer limits must be >= 0")
+	}
+
+	for _, portRange := range c.AdmissionForbiddenHostPorts() {
+		if _, _, err := util.ParsePortRange(portRange); err != nil {
+			return err
+		}
+	}
+
+	usersNames, usersTokens := make(map[string]bool), make(map[string]bool)
+	for _, user := range c.Users() {
+		if user.Name == "" || user.Token == "" {
+			return fmt.Errorf("users must have a name and an API token")
+		}
+		if usersNames[user.Name] || usersTokens[user.Token] {
+			return fmt.Errorf("user %s has a repeated name or API token", user.Name)
+		}
+		usersNames[user.Name], usersTokens[user.Token] = true, true
+	}
+
 	// =================================== Caravela ===========================================
 
 	if !util.IsValidPort(c.APIPort()) {
 		return fmt.Errorf("invalid backend port: %d", c.APIPort())
 	}
 
+	if c.Transport() != "http" && c.Transport() != "grpc" {
+		return fmt.Errorf("invalid transport: %s, it must be http or grpc", c.Transport())
+	}
+
+	if c.Transport() == "grpc" && (!util.IsValidPort(c.GRPCPort()) || c.GRPCPort() == c.APIPort()) {
+		return fmt.Errorf("invalid gRPC port: %d", c.GRPCPort())
+	}
+
 	if c.CPUSlices() <= 0 {
 		return fmt.Errorf("CPUSlices: %d, it must be >= 1", c.CPUSlices())
 	}
 
+	if c.MutualTLS() && c.CertificateValidity() <= 0 {
+		return fmt.Errorf("certificate validity must be positive")
+	}
+
+	if c.Caravela.Quotas.MaxContainers < 0 || c.Caravela.Quotas.MaxCPUs < 0 || c.Caravela.Quotas.MaxMemory < 0 {
+		return fmt.Errorf("quotas limits must be >= 0")
+	}
+
+	if c.CreditsEnabled() {
+		if c.CreditsCPUPrice() < 0 || c.CreditsMemoryPrice() < 0 || c.CreditsBillingInterval() <= 0 {
+			return fmt.Errorf("credits prices must be >= 0 and the billing interval positive")
+		}
+		if c.CreditsNegativeBalancePolicy() != "refuse" && c.CreditsNegativeBalancePolicy() != "deprioritize" {
+			return fmt.Errorf("invalid credits negative balance policy: %s, it must be refuse or deprioritize",
+				c.CreditsNegativeBalancePolicy())
+		}
+	}
+
+	if c.ReputationMinScore() < 0 || c.ReputationMinScore() > 1 || c.ReputationHalfLife() <= 0 {
+		return fmt.Errorf("reputation minimum score must be between 0 and 1 and the half life positive")
+	}
+
+	userQuotas := make(map[string]bool)
+	for _, userQuota := range c.Caravela.Quotas.Users {
+		if userQuota.Name == "" || userQuotas[userQuota.Name] {
+			return fmt.Errorf("quotas users must have an unique name")
+		}
+		if userQuota.MaxContainers < 0 || userQuota.MaxCPUs < 0 || userQuota.MaxMemory < 0 {
+			return fmt.Errorf("quota of user %s limits must be >= 0", userQuota.Name)
+		}
+		userQuotas[userQuota.Name] = true
+	}
+
 	if c.CPUOvercommit() < 100 {
 		return fmt.Errorf("CPUOvercommit: %d, CPU overcommit percentage must be >= 100", c.CPUOvercommit())
 	}
@@ -281,6 +625,14 @@ func (c *Configuration) validate() error {
 		return fmt.Errorf("maximum number of missed refreshes must be a positive integer")
 	}
 
+	if c.OfferReplicas() < 0 {
+		return fmt.Errorf("the number of offer replicas must be a positive integer")
+	}
+
+	if c.OfferReplicas() > 0 && c.ReplicaTimeout() <= c.ReplicationInterval() {
+		return fmt.Errorf("the replica timeout must be higher than the replication interval")
+	}
+
 	if c.PartitionsStateBufferSize() <= 0 {
 		return fmt.Errorf("the partitions state buffer size must be > 0")
 	}
@@ -289,6 +641,22 @@ func (c *Configuration) validate() error {
 		return fmt.Errorf("the max partitions search must be > 0")
 	}
 
+	if c.SearchParallelism() <= 0 {
+		return fmt.Errorf("the search parallelism must be > 0")
+	}
+
+	if c.SearchTimeout() <= 0 {
+		return fmt.Errorf("the search timeout must be > 0")
+	}
+
+	if c.SearchMinOffers() <= 0 {
+		return fmt.Errorf("the search minimum offers must be > 0")
+	}
+
+	if c.SearchHedgeDelay() < 0 {
+		return fmt.Errorf("the search hedge delay must be >= 0")
+	}
+
 	if c.GUIDEstimatedNetworkSize() <= 0 {
 		return fmt.Errorf("estimated network size must a positive integer")
 	}
@@ -303,6 +671,30 @@ func (c *Configuration) validate() error {
 		return fmt.Errorf("random discovery backend maximum retries must be a positive integer")
 	}
 
+	// ================================= Images Storage =========================================
+
+	if c.ImagesAdvertiseInterval() <= 0 || c.ImagesDownloadTimeout() <= 0 {
+		return fmt.Errorf("images advertise interval and download timeout must be positive durations")
+	}
+
+	if c.ImagesHolderTimeout() <= c.ImagesAdvertiseInterval() {
+		return fmt.Errorf("images holder timeout must be greater than the advertise interval")
+	}
+
+	for _, credentials := range c.RegistryCredentials() {
+		if credentials.Registry == "" || credentials.Username == "" {
+			return fmt.Errorf("registry credentials must have a registry and a username")
+		}
+	}
+
+	if c.ImagesStorageBackend() == "Archive" && (c.ArchiveLocation() == "" || c.ArchiveIndexFile() == "") {
+		return fmt.Errorf("archive storage backend needs a location and an index file")
+	}
+
+	if c.ArchiveDownloadTimeout() <= 0 {
+		return fmt.Errorf("archive download timeout must be a positive duration")
+	}
+
 	// ===================================== Overlay ============================================
 
 	if !util.IsValidPort(c.OverlayPort()) {
@@ -323,6 +715,28 @@ func (c *Configuration) validate() error {
 		return fmt.Errorf("chord's hash size bits nodes must be a positive integer greater or equal to 56")
 	}
 
+	// ================================= Kademlia Overlay ========================================
+
+	if c.KademliaVirtualNodes() <= 0 {
+		return fmt.Errorf("kademlia's number of virtual nodes must be a positive integer")
+	}
+
+	if c.KademliaBucketSize() <= 0 {
+		return fmt.Errorf("kademlia's bucket size must be a positive integer")
+	}
+
+	if c.KademliaParallelism() <= 0 {
+		return fmt.Errorf("kademlia's parallelism must be a positive integer")
+	}
+
+	if c.KademliaLookupSize() <= 0 || c.KademliaLookupSize() > c.KademliaBucketSize() {
+		return fmt.Errorf("kademlia's lookup size must be a positive integer not greater than the bucket size")
+	}
+
+	if c.KademliaRefreshInterval() <= 0 {
+		return fmt.Errorf("kademlia's refresh interval must be a positive duration")
+	}
+
 	return nil
 }
 
@@ -335,15 +749,81 @@ func (c *Configuration) Print() {
 	log.Printf("$$$$$$$$$$$$$$$$$$$$$$$$$$$ HOST $$$$$$$$$$$$$$$$$$$$$$$$$$$$$$$$$")
 	log.Printf("IP Address:                  %s", c.HostIP())
 	log.Printf("Docker Engine API Version:   %s", c.DockerAPIVersion())
+	log.Printf("Docker Engine:               %s", c.DockerEngine())
+	if c.DockerEngine() == "fake" {
+		log.Printf("  CPU Class:                 %d", c.FakeDockerCPUClass())
+		log.Printf("  CPUs:                      %d", c.FakeDockerCPUs())
+		log.Printf("  Memory:                    %d", c.FakeDockerMemory())
+	}
+	log.Printf("Image Policy:")
+	log.Printf("  Allowed Registries:        %v", c.ImagePolicyAllowedRegistries())
+	log.Printf("  Allowed Repositories:      %v", c.ImagePolicyAllowedRepositories())
+	log.Printf("  Require Digest:            %t", c.ImagePolicyRequireDigest())
+	log.Printf("  Require Signature:         %t", c.ImagePolicyRequireSignature())
+	log.Printf("  Trusted Keys:              %v", c.ImagePolicyTrustedKeys())
+	log.Printf("Admission:")
+	log.Printf("  Allowed Buyers:            %v", c.AdmissionAllowedBuyers())
+	log.Printf("  Denied Buyers:             %v", c.AdmissionDeniedBuyers())
+	log.Printf("  Max Containers Per Buyer:  %d", c.AdmissionMaxContainersPerBuyer())
+	log.Printf("  Max CPUs Per Buyer:        %d", c.AdmissionMaxCPUsPerBuyer())
+	log.Printf("  Max Memory Per Buyer:      %d", c.AdmissionMaxMemoryPerBuyer())
+	log.Printf("  Forbidden Host Ports:      %v", c.AdmissionForbiddenHostPorts())
+	log.Printf("  No New Privileges:         %t", c.AdmissionNoNewPrivileges())
+	log.Printf("  Audit File:                %s", c.AdmissionAuditFile())
+	log.Printf("Users:")
+	for _, user := range c.Users() {
+		log.Printf("  Name:                      %s (Admin: %t)", user.Name, user.Admin)
+	}
+	log.Printf("Accounting File:             %s", c.AccountingFile())
+	log.Printf("Shutdown Mode:               %s", c.ShutdownMode())
+	if c.ShutdownMode() == "keep" {
+		log.Printf("  Containers State File:     %s", c.ContainersStateFile())
+	}
+	log.Printf("Fault Injection:             %t", c.FaultInjection())
+	if c.FaultInjection() {
+		log.Printf("  Faults File:               %s", c.FaultsFile())
+	}
 
 	log.Printf("$$$$$$$$$$$$$$$$$$$$$$$$$$ CARAVELA $$$$$$$$$$$$$$$$$$$$$$$$$$$$$$")
 	log.Printf("Simulation:                  %t", c.Simulation())
 	log.Printf("Port:                        %d", c.APIPort())
 	log.Printf("Messages Timeout:            %s", c.APITimeout().String())
+	log.Printf("Transport:                   %s", c.Transport())
+	if c.Transport() == "grpc" {
+		log.Printf("  gRPC Port:                 %d", c.GRPCPort())
+	}
 	log.Printf("CPU Slices:                  %d", c.CPUSlices())
 	log.Printf("CPU Overcommit:              %d", c.CPUOvercommit())
 	log.Printf("Memory Overcommit:           %d", c.MemoryOvercommit())
 	log.Printf("Scheduling Policy:           %s", c.SchedulingPolicy())
+	log.Printf("Mutual TLS:                  %t", c.MutualTLS())
+	if c.MutualTLS() {
+		log.Printf("  Certificate Validity:      %s", c.CertificateValidity().String())
+		log.Printf("  CA File:                   %s", c.SecurityCAFile())
+		log.Printf("  Certificate File:          %s", c.SecurityCertFile())
+		log.Printf("  Issues Certificates:       %t", c.SecurityCAKeyFile() != "")
+	}
+	log.Printf("Quotas:")
+	log.Printf("  Default:                   <%d;%d;%d> (Containers;CPUs;Memory)", c.Caravela.Quotas.MaxContainers,
+		c.Caravela.Quotas.MaxCPUs, c.Caravela.Quotas.MaxMemory)
+	for _, userQuota := range c.Caravela.Quotas.Users {
+		log.Printf("  User %-21s <%d;%d;%d>", userQuota.Name+":", userQuota.MaxContainers, userQuota.MaxCPUs,
+			userQuota.MaxMemory)
+	}
+	log.Printf("Credits:                     %t", c.CreditsEnabled())
+	if c.CreditsEnabled() {
+		log.Printf("  Initial Balance:           %.2f", c.CreditsInitialBalance())
+		log.Printf("  Prices:                    %.2f/CPU/h %.2f/GB/h", c.CreditsCPUPrice(), c.CreditsMemoryPrice())
+		log.Printf("  Billing Interval:          %s", c.CreditsBillingInterval().String())
+		log.Printf("  Negative Balance Policy:   %s", c.CreditsNegativeBalancePolicy())
+		log.Printf("  Receipts File:             %s", c.CreditsReceiptsFile())
+		log.Printf("  Key File:                  %s", c.CreditsKeyFile())
+	}
+	log.Printf("Reputation:                  %t", c.ReputationEnabled())
+	if c.ReputationEnabled() {
+		log.Printf("  Minimum Score:             %.2f", c.ReputationMinScore())
+		log.Printf("  Half Life:                 %s", c.ReputationHalfLife().String())
+	}
 	log.Printf("FreeResources Partitions:")
 	for _, powerPart := range c.Caravela.Resources.CPUClasses {
 		log.Printf("  CPUClass:                  %d", powerPart.Value)
@@ -369,6 +849,13 @@ func (c *Configuration) Print() {
 	log.Printf("      Max num of refreshes missed:   %d", c.MaxRefreshesMissed())
 	log.Printf("      Partitions State Buffer Size:  %d", c.PartitionsStateBufferSize())
 	log.Printf("      Max Partitions Search:         %d", c.MaxPartitionsSearch())
+	log.Printf("      Search Parallelism:            %d", c.SearchParallelism())
+	log.Printf("      Search Timeout:                %s", c.SearchTimeout().String())
+	log.Printf("      Search Min Offers:             %d", c.SearchMinOffers())
+	log.Printf("      Search Hedge Delay:            %s", c.SearchHedgeDelay().String())
+	log.Printf("      Offer Replicas:                %d", c.OfferReplicas())
+	log.Printf("      Replication Interval:          %s", c.ReplicationInterval().String())
+	log.Printf("      Replica Timeout:               %s", c.ReplicaTimeout().String())
 	// Debug performance flags.
 	log.Printf("      Spread Offers:                 %t", c.SpreadOffers())
 	log.Printf("      Spread Partitions State:       %t", c.SpreadPartitionsState())
@@ -378,6 +865,19 @@ func (c *Configuration) Print() {
 
 	log.Printf("$$$$$$$$$$$$$$$$$$$$$$$ IMAGES STORAGE $$$$$$$$$$$$$$$$$$$$$$$$$$$")
 	log.Printf("Active Storage Backend:              %s", c.ImagesStorageBackend())
+	log.Printf("Peers:")
+	log.Printf("  Advertise Interval:                %s", c.ImagesAdvertiseInterval().String())
+	log.Printf("  Holder Timeout:                    %s", c.ImagesHolderTimeout().String())
+	log.Printf("  Download Timeout:                  %s", c.ImagesDownloadTimeout().String())
+	log.Printf("Registry:")
+	log.Printf("  Credentials File:                  %s", c.RegistryCredentialsFile())
+	for _, credentials := range c.RegistryCredentials() {
+		log.Printf("  Credentials:                       %s (%s)", credentials.Registry, credentials.Username)
+	}
+	log.Printf("Archive:")
+	log.Printf("  Location:                          %s", c.ArchiveLocation())
+	log.Printf("  Index File:                        %s", c.ArchiveIndexFile())
+	log.Printf("  Download Timeout:                  %s", c.ArchiveDownloadTimeout().String())
 
 	log.Printf("$$$$$$$$$$$$$$$$$$$$$$$$$$ OVERLAY $$$$$$$$$$$$$$$$$$$$$$$$$$$$$$$")
 	log.Printf("Active Overlay:                      %s", c.OverlayName())
@@ -389,6 +889,14 @@ func (c *Configuration) Print() {
 	log.Printf("  Number of Successors:              %d", c.ChordNumSuccessors())
 	log.Printf("  Hash Size (bits):                  %d", c.ChordHashSizeBits())
 
+	log.Printf("Kademlia:")
+	log.Printf("  Messages Timeout:                  %s", c.KademliaTimeout().String())
+	log.Printf("  Number of Virtual Nodes:           %d", c.KademliaVirtualNodes())
+	log.Printf("  Bucket Size:                       %d", c.KademliaBucketSize())
+	log.Printf("  Parallelism:                       %d", c.KademliaParallelism())
+	log.Printf("  Lookup Size:                       %d", c.KademliaLookupSize())
+	log.Printf("  Refresh Interval:                  %s", c.KademliaRefreshInterval().String())
+
 	log.Printf("##################################################################")
 }
 
@@ -402,6 +910,98 @@ func (c *Configuration) DockerAPIVersion() string {
 	return c.Host.DockerAPIVersion
 }
 
+func (c *Configuration) DockerEngine() string {
+	return c.Host.DockerEngine
+}
+
+func (c *Configuration) FakeDockerCPUClass() int {
+	return c.Host.FakeDockerEngine.CPUClass
+}
+
+func (c *Configuration) FakeDockerCPUs() int {
+	return c.Host.FakeDockerEngine.CPUs
+}
+
+func (c *Configuration) FakeDockerMemory() int {
+	return c.Host.FakeDockerEngine.Memory
+}
+
+func (c *Configuration) ImagePolicyAllowedRegistries() []string {
+	return c.Host.ImagePolicy.AllowedRegistries
+}
+
+func (c *Configuration) ImagePolicyAllowedRepositories() []string {
+	return c.Host.ImagePolicy.AllowedRepositories
+}
+
+func (c *Configuration) ImagePolicyRequireDigest() bool {
+	return c.Host.ImagePolicy.RequireDigest
+}
+
+func (c *Configuration) ImagePolicyRequireSignature() bool {
+	return c.Host.ImagePolicy.RequireSignature
+}
+
+func (c *Configuration) ImagePolicyTrustedKeys() []string {
+	return c.Host.ImagePolicy.TrustedKeys
+}
+
+func (c *Configuration) AdmissionAllowedBuyers() []string {
+	return c.Host.Admission.AllowedBuyers
+}
+
+func (c *Configuration) AdmissionDeniedBuyers() []string {
+	return c.Host.Admission.DeniedBuyers
+}
+
+func (c *Configuration) AdmissionMaxContainersPerBuyer() int {
+	return c.Host.Admission.MaxContainersPerBuyer
+}
+
+func (c *Configuration) AdmissionMaxCPUsPerBuyer() int {
+	return c.Host.Admission.MaxCPUsPerBuyer
+}
+
+func (c *Configuration) AdmissionMaxMemoryPerBuyer() int {
+	return c.Host.Admission.MaxMemoryPerBuyer
+}
+
+func (c *Configuration) AdmissionForbiddenHostPorts() []string {
+	return c.Host.Admission.ForbiddenHostPorts
+}
+
+func (c *Configuration) AdmissionNoNewPrivileges() bool {
+	return c.Host.Admission.NoNewPrivileges
+}
+
+func (c *Configuration) AdmissionAuditFile() string {
+	return c.Host.Admission.AuditFile
+}
+
+func (c *Configuration) Users() []UserAccount {
+	return c.Host.Users
+}
+
+func (c *Configuration) AccountingFile() string {
+	return c.Host.AccountingFile
+}
+
+func (c *Configuration) ShutdownMode() string {
+	return c.Host.ShutdownMode
+}
+
+func (c *Configuration) ContainersStateFile() string {
+	return c.Host.ContainersState
+}
+
+func (c *Configuration) FaultInjection() bool {
+	return c.Host.FaultInjection
+}
+
+func (c *Configuration) FaultsFile() string {
+	return c.Host.FaultsFile
+}
+
 // ========================== Caravela =============================
 
 func (c *Configuration) Simulation() bool {
@@ -416,6 +1016,14 @@ func (c *Configuration) APITimeout() time.Duration {
 	return c.Caravela.APITimeout.Duration
 }
 
+func (c *Configuration) Transport() string {
+	return c.Caravela.Transport
+}
+
+func (c *Configuration) GRPCPort() int {
+	return c.Caravela.GRPCPort
+}
+
 func (c *Configuration) ResourcesPartitions() ResourcesPartitions {
 	copyResources := ResourcesPartitions{}
 	copyResources.CPUClasses = make([]CPUClassPartition, len(c.Caravela.Resources.CPUClasses))
@@ -452,6 +1060,105 @@ func (c *Configuration) SchedulingPolicy() string {
 	return c.Caravela.SchedulingPolicy
 }
 
+// ============================ Security ============================
+
+func (c *Configuration) MutualTLS() bool {
+	return c.Caravela.Security.MutualTLS
+}
+
+func (c *Configuration) CertificateValidity() time.Duration {
+	return c.Caravela.Security.CertificateValidity.Duration
+}
+
+func (c *Configuration) SecurityCAFile() string {
+	return c.Caravela.Security.CAFile
+}
+
+func (c *Configuration) SecurityCAKeyFile() string {
+	return c.Caravela.Security.CAKeyFile
+}
+
+func (c *Configuration) SecurityCertFile() string {
+	return c.Caravela.Security.CertFile
+}
+
+func (c *Configuration) SecurityKeyFile() string {
+	return c.Caravela.Security.KeyFile
+}
+
+func (c *Configuration) BootstrapToken() string {
+	return c.Caravela.Security.BootstrapToken
+}
+
+// ============================= Quotas =============================
+
+// Quota returns the resources quota of the given user (0 values are unlimited).
+func (c *Configuration) Quota(userID string) UserQuota {
+	for _, userQuota := range c.Caravela.Quotas.Users {
+		if userQuota.Name == userID {
+			return userQuota
+		}
+	}
+	return UserQuota{
+		Name:          userID,
+		MaxContainers: c.Caravela.Quotas.MaxContainers,
+		MaxCPUs:       c.Caravela.Quotas.MaxCPUs,
+		MaxMemory:     c.Caravela.Quotas.MaxMemory,
+	}
+}
+
+// ============================= Credits ============================
+
+func (c *Configuration) CreditsEnabled() bool {
+	return c.Caravela.Credits.Enabled
+}
+
+func (c *Configuration) CreditsInitialBalance() float64 {
+	return c.Caravela.Credits.InitialBalance
+}
+
+func (c *Configuration) CreditsCPUPrice() float64 {
+	return c.Caravela.Credits.CPUPrice
+}
+
+func (c *Configuration) CreditsMemoryPrice() float64 {
+	return c.Caravela.Credits.MemoryPrice
+}
+
+func (c *Configuration) CreditsBillingInterval() time.Duration {
+	return c.Caravela.Credits.BillingInterval.Duration
+}
+
+func (c *Configuration) CreditsNegativeBalancePolicy() string {
+	return c.Caravela.Credits.NegativeBalancePolicy
+}
+
+func (c *Configuration) CreditsDeprioritizeDelay() time.Duration {
+	return c.Caravela.Credits.DeprioritizeDelay.Duration
+}
+
+func (c *Configuration) CreditsReceiptsFile() string {
+	return c.Caravela.Credits.ReceiptsFile
+}
+
+func (c *Configuration) CreditsKeyFile() string {
+	return c.Caravela.Credits.KeyFile
+}
+
+// ============================ Reputation ===========================
+
+func (c *Configuration) ReputationEnabled() bool {
+	return c.Caravela.Reputation.Enabled
+}
+
+func (c *Configuration) ReputationMinScore() float64 {
+	return c.Caravela.Reputation.MinScore
+}
+
+func (c *Configuration) ReputationHalfLife() time.Duration {
+	return c.Caravela.Reputation.HalfLife.Duration
+}
+
 // ========================== Discovery StorageBackend ================================
 
 func (c *Configuration) DiscoveryBackend() string {
@@ -496,6 +1203,34 @@ func (c *Configuration) MaxPartitionsSearch() int {
 	return c.Caravela.DiscoveryBackend.OfferingChordBackend.MaxPartitionsSearch
 }
 
+func (c *Configuration) SearchParallelism() int {
+	return c.Caravela.DiscoveryBackend.OfferingChordBackend.SearchParallelism
+}
+
+func (c *Configuration) SearchTimeout() time.Duration {
+	return c.Caravela.DiscoveryBackend.OfferingChordBackend.SearchTimeout.Duration
+}
+
+func (c *Configuration) SearchMinOffers() int {
+	return c.Caravela.DiscoveryBackend.OfferingChordBackend.SearchMinOffers
+}
+
+func (c *Configuration) SearchHedgeDelay() time.Duration {
+	return c.Caravela.DiscoveryBackend.OfferingChordBackend.SearchHedgeDelay.Duration
+}
+
+func (c *Configuration) OfferReplicas() int {
+	return c.Caravela.DiscoveryBackend.OfferingChordBackend.OfferReplicas
+}
+
+func (c *Configuration) ReplicationInterval() time.Duration {
+	return c.Caravela.DiscoveryBackend.OfferingChordBackend.ReplicationInterval.Duration
+}
+
+func (c *Configuration) ReplicaTimeout() time.Duration {
+	return c.Caravela.DiscoveryBackend.OfferingChordBackend.ReplicaTimeout.Duration
+}
+
 // Debug performance flag.
 func (c *Configuration) SpreadOffers() bool {
 	return c.Caravela.DiscoveryBackend.OfferingChordBackend.SpreadOffers
@@ -533,6 +1268,38 @@ func (c *Configuration) ImagesStorageBackend() string {
 	return c.ImagesStorage.StorageBackend
 }
 
+func (c *Configuration) ImagesAdvertiseInterval() time.Duration {
+	return c.ImagesStorage.PeersBackend.AdvertiseInterval.Duration
+}
+
+func (c *Configuration) ImagesHolderTimeout() time.Duration {
+	return c.ImagesStorage.PeersBackend.HolderTimeout.Duration
+}
+
+func (c *Configuration) ImagesDownloadTimeout() time.Duration {
+	return c.ImagesStorage.PeersBackend.DownloadTimeout.Duration
+}
+
+func (c *Configuration) RegistryCredentialsFile() string {
+	return c.ImagesStorage.Registry.CredentialsFile
+}
+
+func (c *Configuration) RegistryCredentials() []RegistryCredentials {
+	return c.ImagesStorage.Registry.Credentials
+}
+
+func (c *Configuration) ArchiveLocation() string {
+	return c.ImagesStorage.ArchiveBackend.Location
+}
+
+func (c *Configuration) ArchiveIndexFile() string {
+	return c.ImagesStorage.ArchiveBackend.IndexFile
+}
+
+func (c *Configuration) ArchiveDownloadTimeout() time.Duration {
+	return c.ImagesStorage.ArchiveBackend.DownloadTimeout.Duration
+}
+
 // =============================== Overlay ==================================
 
 func (c *Configuration) OverlayName() string {
@@ -560,3 +1327,29 @@ func (c *Configuration) ChordNumSuccessors() int {
 func (c *Configuration) ChordHashSizeBits() int {
 	return c.Overlay.Chord.HashSizeBits
 }
+
+// ========================== Kademlia's Specific ===========================
+
+func (c *Configuration) KademliaTimeout() time.Duration {
+	return c.Overlay.Kademlia.Timeout.Duration
+}
+
+func (c *Configuration) KademliaVirtualNodes() int {
+	return c.Overlay.Kademlia.VirtualNodes
+}
+
+func (c *Configuration) KademliaBucketSize() int {
+	return c.Overlay.Kademlia.BucketSize
+}
+
+func (c *Configuration) KademliaParallelism() int {
+	return c.Overlay.Kademlia.Parallelism
+}
+
+func (c *Configuration) KademliaLookupSize() int {
+	return c.Overlay.Kademlia.LookupSize
+}
+
+func (c *Configuration) KademliaRefreshInterval() time.Duration {
+	return c.Overlay.Kademlia.RefreshInterval.Duration
+}
@@ -0,0 +1,58 @@
+package configuration
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestObtainExternal_JSON(t *testing.T) {
+	configJSON, err := json.Marshal(Default("10.0.0.1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	received := &Configuration{}
+	if err := json.Unmarshal(configJSON, received); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := ObtainExternal("10.0.0.2", received)
+
+	if assert.NoError(t, err, "Configuration received by a joining node is invalid") {
+		assert.Equal(t, "10.0.0.2", config.HostIP())
+		assert.Equal(t, "remove", config.ShutdownMode(), "Local configurations should have the default values")
+		assert.Equal(t, "caravela_containers.json", config.ContainersStateFile())
+		assert.Equal(t, "caravela_credits.key", config.CreditsKeyFile(), "Credits key should be persisted")
+	}
+}
+
+func TestObtainExternal_Transport(t *testing.T) {
+	shared := Default("10.0.0.1")
+	shared.Caravela.Transport = "grpc"
+	shared.Caravela.GRPCPort = 9002
+	configJSON, err := json.Marshal(shared)
+	if err != nil {
+		t.Fatal(err)
+	}
+	received := &Configuration{}
+	if err := json.Unmarshal(configJSON, received); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := ObtainExternal("10.0.0.2", received)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "grpc", config.Transport(), "Joining nodes should use the system's transport")
+		assert.Equal(t, 9002, config.GRPCPort())
+	}
+}
+
+func TestValidate_Transport(t *testing.T) {
+	config := Default("10.0.0.1")
+	config.Caravela.Transport = "udp"
+	assert.Error(t, config.validate(), "Unknown transport accepted")
+
+	config.Caravela.Transport = "grpc"
+	config.Caravela.GRPCPort = config.APIPort()
+	assert.Error(t, config.validate(), "gRPC port shared with the REST API accepted")
+}
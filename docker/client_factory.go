@@ -0,0 +1,16 @@
+package docker
+
+import (
+	"github.com/strabox/caravela/configuration"
+	"github.com/strabox/caravela/docker/fake"
+	"github.com/strabox/caravela/node/external"
+)
+
+// CreateClient creates the Docker client based on the configurations. It can be a client for the local
+// Docker Engine or an in-memory fake engine (for nodes that run without Docker).
+func CreateClient(config *configuration.Configuration) external.DockerClient {
+	if config.DockerEngine() == "fake" {
+		return fake.NewClient(config.FakeDockerCPUClass(), config.FakeDockerCPUs(), config.FakeDockerMemory())
+	}
+	return NewDockerClient(config)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/strabox/caravela/docker/events"
 	"github.com/strabox/caravela/storage"
 	"github.com/strabox/caravela/util"
+	"io"
 	"strconv"
 )
 
@@ -118,7 +119,7 @@ func (c *Client) CheckContainerStatus(containerID string) (myContainer.Status, e
 func (c *Client) RunContainer(contConfig caravelaTypes.ContainerConfig) (*caravelaTypes.ContainerStatus, error) {
 	c.isInit()
 
-	dockerImageKey, err := c.imagesBackend.LoadImage(contConfig.ImageKey)
+	dockerImageKey, err := c.imagesBackend.LoadImage(contConfig.ImageKey, contConfig.RegistryAuth)
 	if err != nil {
 		log.Errorf(util.LogTag("DOCKER")+"Loading image error", err)
 		return nil, err
@@ -138,6 +139,11 @@ func (c *Client) RunContainer(contConfig caravelaTypes.ContainerConfig) (*carave
 		containerPortSet[port] = struct{}{}
 	}
 
+	var securityOpts []string = nil
+	if c.configs.AdmissionNoNewPrivileges() { // Containers can't gain privileges e.g. through setuid binaries.
+		securityOpts = []string{"no-new-privileges"}
+	}
+
 	resp, err := c.docker.ContainerCreate(context.Background(),
 		&container.Config{
 			Image:        dockerImageKey,  // Image key name
@@ -151,6 +157,8 @@ func (c *Client) RunContainer(contConfig caravelaTypes.ContainerConfig) (*carave
 				Memory:    int64(contConfig.Resources.Memory) * 1000000,                                                   // Maximum memory available to the container.
 			},
 			PortBindings: hostPortMap, // Port mappings between container's port and host's port
+			Privileged:   false,       // Containers never run in privileged mode.
+			SecurityOpt:  securityOpts,
 		}, nil, contConfig.Name)
 	if err != nil { // Error creating the container
 		c.docker.ContainerRemove(context.Background(), resp.ID, types.ContainerRemoveOptions{}) // Remove the container (avoid filling space)
@@ -178,6 +186,7 @@ func (c *Client) RunContainer(contConfig caravelaTypes.ContainerConfig) (*carave
 		}
 	}
 
+	contConfig.RegistryAuth = nil // Never keep or return the pull credentials.
 	return &caravelaTypes.ContainerStatus{
 		ContainerConfig: contConfig,
 		ContainerID:     resp.ID,
@@ -195,3 +204,44 @@ func (c *Client) RemoveContainer(containerID string) error {
 	}
 	return nil
 }
+
+// ListImages returns the keys (tags and digests) of all the images present in the Docker engine.
+func (c *Client) ListImages() ([]string, error) {
+	c.isInit()
+
+	images, err := c.docker.ImageList(context.Background(), types.ImageListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("problem listing images error: %s", err)
+	}
+
+	imageKeys := make([]string, 0, len(images))
+	for _, image := range images {
+		for _, repoTag := range image.RepoTags {
+			if repoTag != "<none>:<none>" {
+				imageKeys = append(imageKeys, repoTag)
+			}
+		}
+		for _, repoDigest := range image.RepoDigests {
+			if repoDigest != "<none>@<none>" {
+				imageKeys = append(imageKeys, repoDigest)
+			}
+		}
+	}
+	return imageKeys, nil
+}
+
+// SaveImage exports an image from the Docker engine in the docker save (tar archive) format.
+func (c *Client) SaveImage(imageKey string) (io.ReadCloser, error) {
+	c.isInit()
+
+	imageArchive, err := c.docker.ImageSave(context.Background(), []string{imageKey})
+	if err != nil {
+		return nil, fmt.Errorf("problem saving image %s error: %s", imageKey, err)
+	}
+	return imageArchive, nil
+}
+
+// SetImagesPeers provides the images storage backend a way to share images with other nodes.
+func (c *Client) SetImagesPeers(peers storage.ImagesPeers) {
+	c.imagesBackend.SetPeers(peers)
+}
@@ -0,0 +1,294 @@
+/*
+Fake package provides an in-memory implementation of the Docker client used by the CARAVELA's nodes.
+It simulates the containers lifecycle without a Docker Engine, allowing to inject failures and inspect
+the simulated containers. Useful for tests and to run nodes in machines without Docker.
+*/
+package fake
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	log "github.com/Sirupsen/logrus"
+	"github.com/strabox/caravela/api/types"
+	myContainer "github.com/strabox/caravela/docker/container"
+	"github.com/strabox/caravela/docker/events"
+	"github.com/strabox/caravela/storage"
+	"github.com/strabox/caravela/util"
+	"io"
+	"io/ioutil"
+	"sync"
+)
+
+// Size of the events channel buffer (similar to the real Docker client).
+const eventsBufferSize = 15
+
+// First host port used when a container asks for a random host port.
+const firstRandomHostPort = 32768
+
+// Client is an in-memory Docker client that simulates a Docker Engine.
+// It implements the external.DockerClient interface.
+type Client struct {
+	cpuClass int // CPU class of the simulated engine.
+	cpus     int // Number of CPUs of the simulated engine.
+	memory   int // Memory (in MB) of the simulated engine.
+
+	containers   map[string]*container // Simulated containers (containerID->container).
+	pulledImages map[string]bool       // Images that were already "pulled" into the simulated engine.
+	pullErrors   map[string]error      // Injected errors when pulling a specific image.
+	startErrors  map[string]error      // Injected errors when starting a container of a specific image.
+	nextHostPort int                   // Next random host port to be assigned.
+	mutex        sync.Mutex            // Mutex to control the access to the client's state.
+
+	eventsChan chan *events.Event // Channel where the containers events are published.
+}
+
+// container represents a simulated container inside the fake Docker Engine.
+type container struct {
+	status  types.ContainerStatus // Status of the container as returned to the client.
+	running bool                  // True if the container is running, false otherwise.
+}
+
+// NewClient creates a new in-memory Docker client with the given total resources.
+func NewClient(cpuClass, cpus, memory int) *Client {
+	return &Client{
+		cpuClass: cpuClass,
+		cpus:     cpus,
+		memory:   memory,
+
+		containers:   make(map[string]*container),
+		pulledImages: make(map[string]bool),
+		pullErrors:   make(map[string]error),
+		startErrors:  make(map[string]error),
+		nextHostPort: firstRandomHostPort,
+		mutex:        sync.Mutex{},
+
+		eventsChan: make(chan *events.Event, eventsBufferSize),
+	}
+}
+
+// ===============================================================================
+// =							  Failure Injection                              =
+// ===============================================================================
+
+// FailPull makes all the future pulls of the given image fail with the given error.
+// A nil error removes the injected failure.
+func (c *Client) FailPull(imageKey string, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err == nil {
+		delete(c.pullErrors, imageKey)
+	} else {
+		c.pullErrors[imageKey] = err
+	}
+}
+
+// FailStart makes all the future starts of containers of the given image fail with the given error.
+// A nil error removes the injected failure.
+func (c *Client) FailStart(imageKey string, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err == nil {
+		delete(c.startErrors, imageKey)
+	} else {
+		c.startErrors[imageKey] = err
+	}
+}
+
+// KillContainer simulates a sudden death of a running container.
+// It emits a events.ContainerDied event, exactly like the Docker Engine does.
+func (c *Client) KillContainer(containerID string) error {
+	c.mutex.Lock()
+	cont, exist := c.containers[containerID]
+	if !exist || !cont.running {
+		c.mutex.Unlock()
+		return fmt.Errorf("container %s is not running", containerID)
+	}
+	cont.running = false
+	cont.status.Status = "Finished"
+	c.mutex.Unlock()
+
+	log.Debugf(util.LogTag("FakeDOCKER")+"Container %s DIED", containerID)
+	diedEvent := &events.Event{Type: events.ContainerDied, Value: containerID}
+	select {
+	case c.eventsChan <- diedEvent:
+	default: // Events channel is full, deliver it later without blocking the caller
+		go func() { c.eventsChan <- diedEvent }()
+	}
+	return nil
+}
+
+// ===============================================================================
+// =							   Inspection API                                =
+// ===============================================================================
+
+// Containers returns the status of all the containers that exist in the simulated engine.
+func (c *Client) Containers() []types.ContainerStatus {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	res := make([]types.ContainerStatus, 0, len(c.containers))
+	for _, cont := range c.containers {
+		res = append(res, cont.status)
+	}
+	return res
+}
+
+// Container returns the status of a container that exists in the simulated engine.
+func (c *Client) Container(containerID string) (types.ContainerStatus, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if cont, exist := c.containers[containerID]; exist {
+		return cont.status, true
+	}
+	return types.ContainerStatus{}, false
+}
+
+// NumContainersRunning returns the number of containers running in the simulated engine.
+func (c *Client) NumContainersRunning() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	running := 0
+	for _, cont := range c.containers {
+		if cont.running {
+			running++
+		}
+	}
+	return running
+}
+
+// HasImage verifies if the given image was already pulled into the simulated engine.
+func (c *Client) HasImage(imageKey string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.pulledImages[imageKey]
+}
+
+// ===============================================================================
+// =							DockerClient Interface                           =
+// ===============================================================================
+
+func (c *Client) Start() <-chan *events.Event {
+	return c.eventsChan
+}
+
+func (c *Client) GetDockerEngineTotalResources() (int, int, int) {
+	return c.cpuClass, c.cpus, c.memory
+}
+
+func (c *Client) CheckContainerStatus(containerID string) (myContainer.Status, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	cont, exist := c.containers[containerID]
+	if !exist {
+		return myContainer.NewContainerStatus(myContainer.Unknown), fmt.Errorf("no such container: %s", containerID)
+	}
+
+	if cont.running {
+		return myContainer.NewContainerStatus(myContainer.Running), nil
+	}
+	return myContainer.NewContainerStatus(myContainer.Finished), nil
+}
+
+func (c *Client) RunContainer(contConfig types.ContainerConfig) (*types.ContainerStatus, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err, fail := c.pullErrors[contConfig.ImageKey]; fail {
+		log.Errorf(util.LogTag("FakeDOCKER")+"Pulling container image error: %s", err)
+		return nil, err
+	}
+	c.pulledImages[contConfig.ImageKey] = true
+
+	if err, fail := c.startErrors[contConfig.ImageKey]; fail {
+		log.Errorf(util.LogTag("FakeDOCKER")+"Starting container error: %s", err)
+		return nil, err
+	}
+
+	containerID := randomContainerID()
+	if contConfig.Name == "" {
+		contConfig.Name = "caravela_" + containerID[:12]
+	}
+
+	portMappings := make([]types.PortMapping, len(contConfig.PortMappings))
+	for i, portMap := range contConfig.PortMappings {
+		portMappings[i] = portMap
+		if portMap.HostPort == 0 { // Simulate the Docker's engine random host port assignment.
+			portMappings[i].HostPort = c.nextHostPort
+			c.nextHostPort++
+		}
+	}
+	contConfig.PortMappings = portMappings
+	contConfig.RegistryAuth = nil // Never keep or return the pull credentials.
+
+	status := types.ContainerStatus{
+		ContainerConfig: contConfig,
+		ContainerID:     containerID,
+		Status:          "Running",
+	}
+	c.containers[containerID] = &container{status: status, running: true}
+
+	log.Infof(util.LogTag("FakeDOCKER")+"Container RUNNING, Img: %s, Args: %v, Res: <%d,%d>",
+		contConfig.ImageKey, contConfig.Args, contConfig.Resources.CPUs, contConfig.Resources.Memory)
+
+	return &status, nil
+}
+
+func (c *Client) RemoveContainer(containerID string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, exist := c.containers[containerID]; !exist {
+		return fmt.Errorf("problem stopping/removing container error: no such container %s", containerID)
+	}
+	delete(c.containers, containerID)
+	return nil
+}
+
+func (c *Client) ListImages() ([]string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	imageKeys := make([]string, 0, len(c.pulledImages))
+	for imageKey := range c.pulledImages {
+		imageKeys = append(imageKeys, imageKey)
+	}
+	return imageKeys, nil
+}
+
+func (c *Client) SaveImage(imageKey string) (io.ReadCloser, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.pulledImages[imageKey] {
+		return nil, fmt.Errorf("problem saving image %s error: no such image", imageKey)
+	}
+
+	// Simulated archive containing only the image's repositories file.
+	content := []byte(fmt.Sprintf("{\"%s\":{}}", imageKey))
+	archive := new(bytes.Buffer)
+	tarWriter := tar.NewWriter(archive)
+	tarWriter.WriteHeader(&tar.Header{Name: "repositories", Mode: 0644, Size: int64(len(content))})
+	tarWriter.Write(content)
+	tarWriter.Close()
+	return ioutil.NopCloser(archive), nil
+}
+
+func (c *Client) SetImagesPeers(_ storage.ImagesPeers) {
+	// The simulated engine "pulls" all the images instantaneously, so it does not need other nodes.
+}
+
+// randomContainerID generates a random container ID with the same format of the Docker's engine IDs.
+func randomContainerID() string {
+	id := make([]byte, 32)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,134 @@
+package fake
+
+import (
+	"archive/tar"
+	"errors"
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/docker/events"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const imageKeyTest = "redis:alpine"
+
+var testContainerConfig = types.ContainerConfig{
+	ImageKey:     imageKeyTest,
+	Args:         make([]string, 0),
+	PortMappings: []types.PortMapping{{HostPort: 0, ContainerPort: 6379, Protocol: "tcp"}},
+	Resources:    types.Resources{CPUs: 1, Memory: 256},
+}
+
+func TestClient_GetDockerEngineTotalResources(t *testing.T) {
+	client := NewClient(1, 4, 2048)
+
+	cpuClass, cpus, memory := client.GetDockerEngineTotalResources()
+
+	assert.Equal(t, 1, cpuClass, "CPU class is incorrect!")
+	assert.Equal(t, 4, cpus, "CPUs are incorrect!")
+	assert.Equal(t, 2048, memory, "Memory is incorrect!")
+}
+
+func TestClient_RunContainer(t *testing.T) {
+	client := NewClient(0, 4, 2048)
+
+	status, err := client.RunContainer(testContainerConfig)
+
+	assert.Nil(t, err, "Run container should not fail!")
+	assert.Equal(t, "Running", status.Status, "Container's status is incorrect!")
+	assert.NotEqual(t, "", status.Name, "Container's name should be generated!")
+	assert.NotEqual(t, 0, status.PortMappings[0].HostPort, "Container's host port should be assigned!")
+	assert.True(t, client.HasImage(imageKeyTest), "Container's image should be pulled!")
+	assert.Equal(t, 1, client.NumContainersRunning(), "Number of containers running is incorrect!")
+
+	contStatus, err := client.CheckContainerStatus(status.ContainerID)
+	assert.Nil(t, err, "Check container status should not fail!")
+	assert.True(t, contStatus.IsRunning(), "Container should be running!")
+}
+
+func TestClient_RunContainer_PullError(t *testing.T) {
+	client := NewClient(0, 4, 2048)
+	client.FailPull(imageKeyTest, errors.New("pull error"))
+
+	status, err := client.RunContainer(testContainerConfig)
+
+	assert.Nil(t, status, "Container status should be nil!")
+	assert.NotNil(t, err, "Run container should fail!")
+	assert.False(t, client.HasImage(imageKeyTest), "Container's image should not be pulled!")
+
+	client.FailPull(imageKeyTest, nil)
+	_, err = client.RunContainer(testContainerConfig)
+	assert.Nil(t, err, "Run container should not fail after removing the failure!")
+}
+
+func TestClient_RunContainer_StartError(t *testing.T) {
+	client := NewClient(0, 4, 2048)
+	client.FailStart(imageKeyTest, errors.New("start error"))
+
+	status, err := client.RunContainer(testContainerConfig)
+
+	assert.Nil(t, status, "Container status should be nil!")
+	assert.NotNil(t, err, "Run container should fail!")
+	assert.Equal(t, 0, len(client.Containers()), "No container should exist!")
+}
+
+func TestClient_KillContainer(t *testing.T) {
+	client := NewClient(0, 4, 2048)
+	eventsChan := client.Start()
+	status, _ := client.RunContainer(testContainerConfig)
+
+	err := client.KillContainer(status.ContainerID)
+
+	assert.Nil(t, err, "Kill container should not fail!")
+	event := <-eventsChan
+	assert.Equal(t, events.ContainerDied, event.Type, "Event type is incorrect!")
+	assert.Equal(t, status.ContainerID, event.Value, "Event value is incorrect!")
+	contStatus, _ := client.CheckContainerStatus(status.ContainerID)
+	assert.False(t, contStatus.IsRunning(), "Container should not be running!")
+	assert.NotNil(t, client.KillContainer(status.ContainerID), "Kill a dead container should fail!")
+}
+
+func TestClient_KillContainer_EventsChannelFull(t *testing.T) {
+	client := NewClient(0, 64, 65536)
+	eventsChan := client.Start()
+	containersIDs := make([]string, eventsBufferSize+5)
+	for i := range containersIDs {
+		status, _ := client.RunContainer(testContainerConfig)
+		containersIDs[i] = status.ContainerID
+	}
+
+	for _, containerID := range containersIDs {
+		assert.Nil(t, client.KillContainer(containerID), "Kill container should not block or fail!")
+	}
+
+	for range containersIDs {
+		event := <-eventsChan
+		assert.Equal(t, events.ContainerDied, event.Type, "Event type is incorrect!")
+	}
+}
+
+func TestClient_RemoveContainer(t *testing.T) {
+	client := NewClient(0, 4, 2048)
+	status, _ := client.RunContainer(testContainerConfig)
+
+	err := client.RemoveContainer(status.ContainerID)
+
+	assert.Nil(t, err, "Remove container should not fail!")
+	_, exist := client.Container(status.ContainerID)
+	assert.False(t, exist, "Container should not exist!")
+	assert.NotNil(t, client.RemoveContainer(status.ContainerID), "Remove a non existing container should fail!")
+}
+
+func TestClient_SaveImage(t *testing.T) {
+	client := NewClient(0, 4, 2048)
+
+	_, err := client.SaveImage(imageKeyTest)
+	assert.NotNil(t, err, "Save a non pulled image should fail!")
+
+	client.RunContainer(testContainerConfig)
+	imageArchive, err := client.SaveImage(imageKeyTest)
+
+	assert.Nil(t, err, "Save image should not fail!")
+	header, err := tar.NewReader(imageArchive).Next()
+	assert.Nil(t, err, "Image archive should be a tar archive!")
+	assert.Equal(t, "repositories", header.Name, "Image archive content is incorrect!")
+}
@@ -1,6 +1,7 @@
 package guid
 
 import (
+	"crypto/sha256"
 	"github.com/strabox/caravela/util"
 	"math/big"
 	"math/rand"
@@ -116,6 +117,16 @@ func NewGUIDBytes(bytesID []byte) *GUID {
 	return guid
 }
 
+// NewGUIDHash creates a new GUID based on the hash of an identifier, mapping e.g. users or images into the overlay's
+// key space. The prefix separates the identifiers of different kinds.
+func NewGUIDHash(prefix, id string) *GUID {
+	hash := sha256.Sum256([]byte(prefix + id))
+	if SizeBytes() < len(hash) {
+		return NewGUIDBytes(hash[:SizeBytes()])
+	}
+	return NewGUIDBytes(hash[:])
+}
+
 // newGUIDBigInt creates a new GUID based on Golang big.Int representation.
 func newGUIDBigInt(bytesID *big.Int) *GUID {
 	guid := &GUID{}
@@ -198,6 +209,14 @@ func (g *GUID) Equals(guid2 GUID) bool {
 	return g.id.Cmp(guid2.id) == 0
 }
 
+// Between returns true if the guid is inside the ring interval (lower, higher), wrapping around the maximum GUID.
+func (g *GUID) Between(lower, higher GUID) bool {
+	if lower.Lower(higher) {
+		return g.Higher(lower) && g.Lower(higher)
+	}
+	return g.Higher(lower) || g.Lower(higher)
+}
+
 // Bytes returns an array of bytes (with size of guidSizeBits) with the value of the GUID.
 func (g *GUID) Bytes() []byte {
 	numOfBytes := guidSizeBits / 8
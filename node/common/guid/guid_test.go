@@ -87,6 +87,14 @@ func TestNewGUIDBytes(t *testing.T) {
 	assert.Equal(t, SizeBytes(), len(guid.Bytes()), "Byte array return has different length from the GUID size")
 }
 
+func TestNewGUIDHash(t *testing.T) {
+	guid := NewGUIDHash("user:", "alice")
+
+	assert.True(t, guid.Equals(*NewGUIDHash("user:", "alice")), "Same identifier mapped into different GUIDs")
+	assert.False(t, guid.Equals(*NewGUIDHash("account:", "alice")), "Identifiers of different kinds mapped together")
+	assert.True(t, guid.Cmp(*MaximumGUID()) < 0, "GUID outside the key space")
+}
+
 func TestNewGUIDBigInt(t *testing.T) {
 	guidBigInt := big.NewInt(7899999999999)
 
@@ -321,3 +329,13 @@ func TestGuid_Copy(t *testing.T) {
 	assert.Equal(t, guidOriginal.String(), guidCopy.String(), "Strings should be equal")
 	assert.Equal(t, SizeBytes(), len(guidCopy.Bytes()), "Byte array return has different length from the GUID")
 }
+
+func TestGuid_Between(t *testing.T) {
+	guid := NewGUIDInteger(1000)
+
+	assert.True(t, guid.Between(*NewGUIDInteger(999), *NewGUIDInteger(1001)), "GUID should be inside the interval")
+	assert.False(t, guid.Between(*NewGUIDInteger(1000), *NewGUIDInteger(1001)), "Interval should be open")
+	assert.False(t, guid.Between(*NewGUIDInteger(1001), *NewGUIDInteger(2000)), "GUID should be outside the interval")
+	assert.True(t, guid.Between(*NewGUIDInteger(5000), *NewGUIDInteger(1001)), "Interval should wrap around the ring")
+	assert.False(t, guid.Between(*NewGUIDInteger(5000), *NewGUIDInteger(999)), "GUID should be outside the interval")
+}
@@ -1,8 +1,13 @@
 package common
 
-import "github.com/strabox/caravela/node/discovery/offering/partitions"
+import (
+	"github.com/strabox/caravela/node/common/reputation"
+	"github.com/strabox/caravela/node/discovery/offering/partitions"
+)
 
 type Node interface {
 	GetSystemPartitionsState() *partitions.SystemResourcePartitions
 	GUID() string
+	ImagesFilter() []byte
+	SupplierReputations() *reputation.Reputations
 }
@@ -0,0 +1,135 @@
+package reputation
+
+import (
+	"github.com/strabox/caravela/api/types"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Weights of each outcome observed in the supplier's reputation.
+const (
+	launchSucceededWeight  = 1.0
+	launchFailedWeight     = 1.0
+	launchTimedOutWeight   = 2.0
+	containerLostWeight    = 3.0
+	refreshSucceededWeight = 0.25
+	refreshFailedWeight    = 0.5
+)
+
+// Reputations keeps the local reputation of each supplier (by IP), built from the outcomes that the node observes
+// when it launches containers in the supplier, stops them and refreshes the supplier's offers.
+// The weight of the outcomes decays over time so a supplier can recover from past failures.
+type Reputations struct {
+	halfLife  time.Duration
+	suppliers map[string]*supplierReputation
+	mutex     sync.Mutex
+	now       func() time.Time
+}
+
+// supplierReputation holds the outcomes observed of a supplier.
+type supplierReputation struct {
+	types.SupplierReputation
+	good float64 // Decayed weight of the good outcomes
+	bad  float64 // Decayed weight of the bad outcomes
+}
+
+// NewReputations creates an empty collection of suppliers' reputations.
+func NewReputations(halfLife time.Duration) *Reputations {
+	return &Reputations{
+		halfLife:  halfLife,
+		suppliers: make(map[string]*supplierReputation),
+		mutex:     sync.Mutex{},
+		now:       time.Now,
+	}
+}
+
+func (r *Reputations) LaunchSucceeded(supplierIP string) {
+	r.record(supplierIP, launchSucceededWeight, 0, func(rep *types.SupplierReputation) { rep.LaunchesSucceeded++ })
+}
+
+func (r *Reputations) LaunchFailed(supplierIP string) {
+	r.record(supplierIP, 0, launchFailedWeight, func(rep *types.SupplierReputation) { rep.LaunchesFailed++ })
+}
+
+func (r *Reputations) LaunchTimedOut(supplierIP string) {
+	r.record(supplierIP, 0, launchTimedOutWeight, func(rep *types.SupplierReputation) { rep.LaunchesTimedOut++ })
+}
+
+func (r *Reputations) ContainerLost(supplierIP string) {
+	r.record(supplierIP, 0, containerLostWeight, func(rep *types.SupplierReputation) { rep.ContainersLost++ })
+}
+
+func (r *Reputations) RefreshSucceeded(supplierIP string) {
+	r.record(supplierIP, refreshSucceededWeight, 0, func(rep *types.SupplierReputation) { rep.RefreshesSucceeded++ })
+}
+
+func (r *Reputations) RefreshFailed(supplierIP string) {
+	r.record(supplierIP, 0, refreshFailedWeight, func(rep *types.SupplierReputation) { rep.RefreshesFailed++ })
+}
+
+// Score returns the current score of the supplier, between 0 and 1. Unknown suppliers have a neutral score (0.5).
+func (r *Reputations) Score(supplierIP string) float64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	rep, exist := r.suppliers[supplierIP]
+	if !exist {
+		return score(0, 0)
+	}
+	r.decay(rep)
+	return score(rep.good, rep.bad)
+}
+
+// List returns the reputation of all the known suppliers sorted by their IP.
+func (r *Reputations) List() []types.SupplierReputation {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	res := make([]types.SupplierReputation, 0, len(r.suppliers))
+	for _, rep := range r.suppliers {
+		r.decay(rep)
+		rep.Score = score(rep.good, rep.bad)
+		res = append(res, rep.SupplierReputation)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].SupplierIP < res[j].SupplierIP })
+	return res
+}
+
+// record adds an outcome to the supplier's reputation.
+func (r *Reputations) record(supplierIP string, good, bad float64, count func(*types.SupplierReputation)) {
+	if supplierIP == "" {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	rep, exist := r.suppliers[supplierIP]
+	if !exist {
+		rep = &supplierReputation{SupplierReputation: types.SupplierReputation{SupplierIP: supplierIP, LastUpdate: r.now()}}
+		r.suppliers[supplierIP] = rep
+	}
+	r.decay(rep)
+	rep.good += good
+	rep.bad += bad
+	count(&rep.SupplierReputation)
+}
+
+// decay reduces the weight of the outcomes observed since the last update of the supplier's reputation.
+func (r *Reputations) decay(rep *supplierReputation) {
+	now := r.now()
+	elapsed := now.Sub(rep.LastUpdate)
+	if elapsed > 0 && r.halfLife > 0 {
+		factor := math.Pow(0.5, float64(elapsed)/float64(r.halfLife))
+		rep.good *= factor
+		rep.bad *= factor
+	}
+	rep.LastUpdate = now
+}
+
+// score calculates a score, between 0 and 1, from the weight of the good and bad outcomes (Laplace smoothed).
+func score(good, bad float64) float64 {
+	return (good + 1) / (good + bad + 2)
+}
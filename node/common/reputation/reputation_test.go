@@ -0,0 +1,42 @@
+package reputation
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestReputations_Score_Unknown(t *testing.T) {
+	reputations := NewReputations(time.Hour)
+
+	assert.Equal(t, 0.5, reputations.Score("10.0.0.1"), "Unknown suppliers should have a neutral score")
+}
+
+func TestReputations_Score_Outcomes(t *testing.T) {
+	reputations := NewReputations(time.Hour)
+	reputations.LaunchSucceeded("10.0.0.1")
+	reputations.LaunchSucceeded("10.0.0.1")
+	reputations.LaunchTimedOut("10.0.0.2")
+
+	assert.True(t, reputations.Score("10.0.0.1") > 0.5, "Successful launches should raise the score")
+	assert.True(t, reputations.Score("10.0.0.2") < 0.5, "Timed out launches should lower the score")
+
+	list := reputations.List()
+	assert.Len(t, list, 2)
+	assert.Equal(t, "10.0.0.1", list[0].SupplierIP)
+	assert.Equal(t, 2, list[0].LaunchesSucceeded)
+	assert.Equal(t, 1, list[1].LaunchesTimedOut)
+}
+
+func TestReputations_Score_Decay(t *testing.T) {
+	now := time.Now()
+	reputations := NewReputations(time.Hour)
+	reputations.now = func() time.Time { return now }
+	reputations.ContainerLost("10.0.0.1")
+	lostScore := reputations.Score("10.0.0.1")
+
+	now = now.Add(10 * time.Hour)
+
+	assert.True(t, reputations.Score("10.0.0.1") > lostScore, "Old failures should weight less over time")
+	assert.InDelta(t, 0.5, reputations.Score("10.0.0.1"), 0.01)
+}
@@ -0,0 +1,27 @@
+package common
+
+import (
+	"context"
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/node/common/guid"
+	"github.com/strabox/caravela/overlay"
+)
+
+// ResponsibleNodes returns the nodes (without repetitions) responsible for the key in the overlay, the first is the
+// responsible and the others are its successors (that keep the key's replicas).
+func ResponsibleNodes(ctx context.Context, overlay overlay.Overlay, key *guid.GUID) []*types.Node {
+	overlayNodes, err := overlay.Lookup(ctx, key.Bytes())
+	if err != nil {
+		return nil
+	}
+
+	res := make([]*types.Node, 0, len(overlayNodes))
+	seen := make(map[string]bool)
+	for _, overlayNode := range overlayNodes {
+		if !seen[overlayNode.IP()] {
+			seen[overlayNode.IP()] = true
+			res = append(res, &types.Node{IP: overlayNode.IP()})
+		}
+	}
+	return res
+}
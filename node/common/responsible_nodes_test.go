@@ -0,0 +1,38 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/node/common/guid"
+	"github.com/strabox/caravela/overlay"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// overlayStub returns always the same nodes for any key (or fails if there are none).
+type overlayStub struct {
+	overlay.Overlay
+	nodes []*overlay.OverlayNode
+}
+
+func (o *overlayStub) Lookup(context.Context, []byte) ([]*overlay.OverlayNode, error) {
+	if len(o.nodes) == 0 {
+		return nil, errors.New("lookup failed")
+	}
+	return o.nodes, nil
+}
+
+func TestResponsibleNodes(t *testing.T) {
+	overlayNodes := &overlayStub{nodes: []*overlay.OverlayNode{
+		overlay.NewOverlayNode("10.0.0.1", 8000, []byte{1}),
+		overlay.NewOverlayNode("10.0.0.1", 8000, []byte{2}),
+		overlay.NewOverlayNode("10.0.0.2", 8000, []byte{3}),
+	}}
+
+	nodes := ResponsibleNodes(context.Background(), overlayNodes, guid.NewGUIDInteger(1))
+
+	assert.Equal(t, []*types.Node{{IP: "10.0.0.1"}, {IP: "10.0.0.2"}}, nodes,
+		"Virtual nodes of the same node should be returned once, keeping the order")
+	assert.Empty(t, ResponsibleNodes(context.Background(), &overlayStub{}, guid.NewGUIDInteger(1)))
+}
@@ -0,0 +1,159 @@
+package containers
+
+import (
+	"bufio"
+	"encoding/json"
+	log "github.com/Sirupsen/logrus"
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/util"
+	"os"
+	"sync"
+	"time"
+)
+
+// accountingLedger records the resources used by the buyers' containers, the CPU-seconds and memory MB-seconds
+// are accounted by the resources reserved for each container during its lifetime. The records of the finished
+// containers are appended to a file, one JSON record per line.
+type accountingLedger struct {
+	file     string                       // File where the finished records are persisted (empty keeps them in memory).
+	mutex    sync.Mutex                   // Mutex to control access to the records.
+	running  map[string]types.UsageRecord // Records of the running containers (containerID->Record).
+	finished []types.UsageRecord          // Records of the finished containers, only when there is no file.
+	now      func() time.Time             // Clock used to account the containers' lifetime.
+}
+
+// newAccountingLedger creates an accounting ledger that persists the records in the given file (empty keeps
+// them in memory).
+func newAccountingLedger(accountingFile string) (*accountingLedger, error) {
+	if accountingFile != "" {
+		file, err := os.OpenFile(accountingFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, err
+		}
+		file.Close()
+	}
+
+	return &accountingLedger{
+		file:     accountingFile,
+		mutex:    sync.Mutex{},
+		running:  make(map[string]types.UsageRecord),
+		finished: make([]types.UsageRecord, 0),
+		now:      time.Now,
+	}, nil
+}
+
+// Start starts accounting the resources of a container.
+func (a *accountingLedger) Start(container *localContainer) {
+	a.Resume(container, a.now())
+}
+
+// Resume accounts the resources of a container since the given time, used for the containers that kept running
+// while the node restarted.
+func (a *accountingLedger) Resume(container *localContainer, start time.Time) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	contResources := container.Resources()
+	a.running[container.ID()] = types.UsageRecord{
+		ContainerID: container.ID(),
+		ImageKey:    container.ImageKey(),
+		BuyerIP:     container.BuyerIP(),
+		UserID:      container.UserID(),
+		CPUs:        contResources.CPUs(),
+		Memory:      contResources.Memory(),
+		Start:       start,
+	}
+}
+
+// StartTime returns when the accounting of a running container started.
+func (a *accountingLedger) StartTime(containerID string) time.Time {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.running[containerID].Start
+}
+
+// Finish stops accounting the resources of a container and persists its record.
+func (a *accountingLedger) Finish(containerID string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	record, exist := a.running[containerID]
+	if !exist {
+		return
+	}
+	delete(a.running, containerID)
+
+	record.End = a.now()
+	record, _ = record.Window(record.Start, record.End)
+
+	if a.file == "" {
+		a.finished = append(a.finished, record)
+		return
+	}
+
+	if err := a.persist(record); err != nil {
+		log.Errorf(util.LogTag("ACCOUNT")+"Persisting record of %s error: %s", containerID, err)
+	}
+}
+
+// Records returns the records of the containers that ran since the given time, with the usage restricted to
+// that period.
+func (a *accountingLedger) Records(since time.Time) ([]types.UsageRecord, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	now := a.now()
+	res := make([]types.UsageRecord, 0)
+	addRecord := func(record types.UsageRecord) {
+		if windowRecord, ran := record.Window(since, now); ran {
+			res = append(res, windowRecord)
+		}
+	}
+
+	if a.file == "" {
+		for _, record := range a.finished {
+			addRecord(record)
+		}
+	} else {
+		file, err := os.Open(a.file)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			var record types.UsageRecord
+			if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+				continue // Ignore partially written records.
+			}
+			addRecord(record)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, record := range a.running {
+		addRecord(record)
+	}
+	return res, nil
+}
+
+// persist appends a finished record to the file.
+func (a *accountingLedger) persist(record types.UsageRecord) error {
+	entry, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(a.file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(entry, '\n'))
+	return err
+}
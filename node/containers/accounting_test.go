@@ -0,0 +1,99 @@
+package containers
+
+import (
+	"github.com/strabox/caravela/node/common/resources"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var accountingStartTest = time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestAccountingLedger_Records_FinishedAndRunning(t *testing.T) {
+	ledger, err := newAccountingLedger("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := accountingStartTest
+	ledger.now = func() time.Time { return now }
+
+	ledger.Start(newContainer("", "redis", nil, nil, *resources.NewResourcesCPUClass(0, 2, 512), "c1",
+		"10.0.0.1", "alice"))
+	ledger.Start(newContainer("", "redis", nil, nil, *resources.NewResourcesCPUClass(0, 1, 256), "c2",
+		"10.0.0.1", "alice"))
+	now = now.Add(10 * time.Second)
+	ledger.Finish("c1")
+	now = now.Add(10 * time.Second)
+
+	records, err := ledger.Records(time.Time{})
+
+	assert.Nil(t, err)
+	assert.Len(t, records, 2)
+	for _, record := range records {
+		if record.ContainerID == "c1" {
+			assert.Equal(t, 20.0, record.CPUSeconds, "Finished container CPU-seconds")
+			assert.Equal(t, 5120.0, record.MemoryMBSeconds, "Finished container memory MB-seconds")
+			assert.False(t, record.Running())
+		} else {
+			assert.Equal(t, 20.0, record.CPUSeconds, "Running container accounted until now")
+			assert.True(t, record.Running())
+		}
+		assert.Equal(t, "alice", record.UserID)
+	}
+}
+
+func TestAccountingLedger_Records_Since(t *testing.T) {
+	ledger, err := newAccountingLedger("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := accountingStartTest
+	ledger.now = func() time.Time { return now }
+
+	ledger.Start(newContainer("", "redis", nil, nil, *resources.NewResourcesCPUClass(0, 2, 512), "c1",
+		"10.0.0.1", "alice"))
+	now = now.Add(10 * time.Second)
+	ledger.Finish("c1")
+	now = now.Add(10 * time.Second)
+
+	records, _ := ledger.Records(accountingStartTest.Add(5 * time.Second))
+	assert.Len(t, records, 1)
+	assert.Equal(t, 10.0, records[0].CPUSeconds, "Usage before since should not be accounted")
+
+	records, _ = ledger.Records(accountingStartTest.Add(15 * time.Second))
+	assert.Empty(t, records, "Container finished before since")
+}
+
+func TestAccountingLedger_Records_Persisted(t *testing.T) {
+	dir, err := ioutil.TempDir("", "caravela_accounting")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "accounting.log")
+
+	ledger, err := newAccountingLedger(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := accountingStartTest
+	ledger.now = func() time.Time { return now }
+	ledger.Start(newContainer("", "redis", nil, nil, *resources.NewResourcesCPUClass(0, 1, 128), "c1",
+		"10.0.0.1", "alice"))
+	now = now.Add(time.Minute)
+	ledger.Finish("c1")
+
+	reopened, err := newAccountingLedger(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reopened.now = func() time.Time { return now.Add(time.Hour) }
+	records, err := reopened.Records(time.Time{})
+
+	assert.Nil(t, err)
+	assert.Len(t, records, 1, "Finished records should survive the node restart")
+	assert.Equal(t, 60.0, records[0].CPUSeconds)
+}
@@ -0,0 +1,147 @@
+package containers
+
+import (
+	"fmt"
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/configuration"
+	"github.com/strabox/caravela/util"
+	"net"
+	"strings"
+)
+
+// admissionPolicy decides which launch requests, from the buyer nodes, the node accepts as a supplier.
+type admissionPolicy struct {
+	allowedBuyers  []*net.IPNet // Buyers allowed (empty allows all).
+	deniedBuyers   []*net.IPNet // Buyers denied, takes precedence over the allowed ones.
+	maxContainers  int          // Maximum running containers of each buyer (0 is unlimited).
+	maxCPUs        int          // Maximum CPUs used by each buyer (0 is unlimited).
+	maxMemory      int          // Maximum memory used by each buyer (0 is unlimited).
+	forbiddenPorts [][2]int     // Host ports ranges that can't be mapped.
+}
+
+// buyerUsage represents the resources used, in the node, by the containers of a buyer.
+type buyerUsage struct {
+	containers int
+	cpus       int
+	memory     int
+}
+
+// newAdmissionPolicy creates the admission policy based on the configurations.
+func newAdmissionPolicy(config *configuration.Configuration) (*admissionPolicy, error) {
+	allowedBuyers, err := parseBuyers(config.AdmissionAllowedBuyers())
+	if err != nil {
+		return nil, err
+	}
+
+	deniedBuyers, err := parseBuyers(config.AdmissionDeniedBuyers())
+	if err != nil {
+		return nil, err
+	}
+
+	forbiddenPorts := make([][2]int, 0, len(config.AdmissionForbiddenHostPorts()))
+	for _, portRange := range config.AdmissionForbiddenHostPorts() {
+		first, last, err := util.ParsePortRange(portRange)
+		if err != nil {
+			return nil, err
+		}
+		forbiddenPorts = append(forbiddenPorts, [2]int{first, last})
+	}
+
+	return &admissionPolicy{
+		allowedBuyers:  allowedBuyers,
+		deniedBuyers:   deniedBuyers,
+		maxContainers:  config.AdmissionMaxContainersPerBuyer(),
+		maxCPUs:        config.AdmissionMaxCPUsPerBuyer(),
+		maxMemory:      config.AdmissionMaxMemoryPerBuyer(),
+		forbiddenPorts: forbiddenPorts,
+	}, nil
+}
+
+// Check verifies if the buyer's request is admitted, given the resources the buyer already uses in the node.
+func (a *admissionPolicy) Check(buyerIP string, containersConfigs []types.ContainerConfig, usage buyerUsage) error {
+	ip := net.ParseIP(buyerIP)
+	if ip == nil {
+		return fmt.Errorf("invalid buyer %s", buyerIP)
+	}
+
+	if containsIP(a.deniedBuyers, ip) {
+		return fmt.Errorf("buyer %s is denied", buyerIP)
+	}
+
+	if len(a.allowedBuyers) > 0 && !containsIP(a.allowedBuyers, ip) {
+		return fmt.Errorf("buyer %s is not allowed", buyerIP)
+	}
+
+	for _, contConfig := range containersConfigs {
+		usage.containers++
+		usage.cpus += contConfig.Resources.CPUs
+		usage.memory += contConfig.Resources.Memory
+
+		for _, portMap := range contConfig.PortMappings {
+			if a.isForbiddenPort(portMap.HostPort) {
+				return fmt.Errorf("host port %d is forbidden", portMap.HostPort)
+			}
+		}
+	}
+
+	if a.maxContainers > 0 && usage.containers > a.maxContainers {
+		return fmt.Errorf("buyer %s would exceed the maximum of %d containers", buyerIP, a.maxContainers)
+	}
+
+	if a.maxCPUs > 0 && usage.cpus > a.maxCPUs {
+		return fmt.Errorf("buyer %s would exceed the maximum of %d CPUs", buyerIP, a.maxCPUs)
+	}
+
+	if a.maxMemory > 0 && usage.memory > a.maxMemory {
+		return fmt.Errorf("buyer %s would exceed the maximum of %d MB of memory", buyerIP, a.maxMemory)
+	}
+	return nil
+}
+
+// isForbiddenPort returns true if the host port is inside a forbidden range.
+// Port 0 (random port chosen by the Docker engine) is always allowed.
+func (a *admissionPolicy) isForbiddenPort(hostPort int) bool {
+	if hostPort == 0 {
+		return false
+	}
+	for _, portRange := range a.forbiddenPorts {
+		if hostPort >= portRange[0] && hostPort <= portRange[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// parseBuyers parses a list of IPs or CIDRs, single IPs are converted into a CIDR with only that IP.
+func parseBuyers(buyers []string) ([]*net.IPNet, error) {
+	res := make([]*net.IPNet, 0, len(buyers))
+	for _, buyer := range buyers {
+		if !strings.Contains(buyer, "/") {
+			ip := net.ParseIP(buyer)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid admission buyer: %s", buyer)
+			}
+			if ip.To4() != nil {
+				buyer += "/32"
+			} else {
+				buyer += "/128"
+			}
+		}
+
+		_, ipNet, err := net.ParseCIDR(buyer)
+		if err != nil {
+			return nil, fmt.Errorf("invalid admission buyer: %s", buyer)
+		}
+		res = append(res, ipNet)
+	}
+	return res, nil
+}
+
+func containsIP(ipNets []*net.IPNet, ip net.IP) bool {
+	for _, ipNet := range ipNets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
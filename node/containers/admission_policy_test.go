@@ -0,0 +1,59 @@
+package containers
+
+import (
+	"github.com/strabox/caravela/api/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAdmissionPolicy_Check_AllowAll(t *testing.T) {
+	policy := &admissionPolicy{}
+
+	err := policy.Check("10.0.0.1", []types.ContainerConfig{{ImageKey: "redis",
+		PortMappings: []types.PortMapping{{HostPort: 80, ContainerPort: 80, Protocol: "tcp"}},
+		Resources:    types.Resources{CPUs: 1, Memory: 256}}}, buyerUsage{})
+
+	assert.Nil(t, err, "Empty policy should admit all the requests")
+}
+
+func TestAdmissionPolicy_Check_Buyers(t *testing.T) {
+	allowed, _ := parseBuyers([]string{"10.0.0.0/24"})
+	denied, _ := parseBuyers([]string{"10.0.0.66"})
+	policy := &admissionPolicy{allowedBuyers: allowed, deniedBuyers: denied}
+	containers := []types.ContainerConfig{{ImageKey: "redis", Resources: types.Resources{CPUs: 1, Memory: 256}}}
+
+	assert.Nil(t, policy.Check("10.0.0.1", containers, buyerUsage{}))
+	assert.Error(t, policy.Check("10.0.0.66", containers, buyerUsage{}), "Denied buyer should be rejected")
+	assert.Error(t, policy.Check("10.0.1.1", containers, buyerUsage{}), "Buyer outside allowed should be rejected")
+}
+
+func TestAdmissionPolicy_Check_BuyerLimits(t *testing.T) {
+	policy := &admissionPolicy{maxContainers: 2, maxCPUs: 4, maxMemory: 1024}
+
+	assert.Nil(t, policy.Check("10.0.0.1", []types.ContainerConfig{{ImageKey: "redis",
+		Resources: types.Resources{CPUs: 2, Memory: 512}}}, buyerUsage{containers: 1, cpus: 2, memory: 512}))
+	assert.Error(t, policy.Check("10.0.0.1", []types.ContainerConfig{{ImageKey: "redis",
+		Resources: types.Resources{CPUs: 1, Memory: 256}}}, buyerUsage{containers: 2, cpus: 2, memory: 512}),
+		"Containers limit should be enforced")
+	assert.Error(t, policy.Check("10.0.0.1", []types.ContainerConfig{{ImageKey: "redis",
+		Resources: types.Resources{CPUs: 3, Memory: 256}}}, buyerUsage{containers: 1, cpus: 2, memory: 512}),
+		"CPUs limit should be enforced")
+	assert.Error(t, policy.Check("10.0.0.1", []types.ContainerConfig{{ImageKey: "redis",
+		Resources: types.Resources{CPUs: 1, Memory: 768}}}, buyerUsage{containers: 1, cpus: 1, memory: 512}),
+		"Memory limit should be enforced")
+}
+
+func TestAdmissionPolicy_Check_ForbiddenPorts(t *testing.T) {
+	policy := &admissionPolicy{forbiddenPorts: [][2]int{{0, 1023}, {8001, 8001}}}
+
+	assert.Nil(t, policy.Check("10.0.0.1", []types.ContainerConfig{{ImageKey: "redis",
+		PortMappings: []types.PortMapping{{HostPort: 0, ContainerPort: 80, Protocol: "tcp"},
+			{HostPort: 8080, ContainerPort: 80, Protocol: "tcp"}},
+		Resources: types.Resources{CPUs: 1, Memory: 256}}}, buyerUsage{}))
+	assert.Error(t, policy.Check("10.0.0.1", []types.ContainerConfig{{ImageKey: "redis",
+		PortMappings: []types.PortMapping{{HostPort: 80, ContainerPort: 80, Protocol: "tcp"}},
+		Resources:    types.Resources{CPUs: 1, Memory: 256}}}, buyerUsage{}), "Privileged port should be forbidden")
+	assert.Error(t, policy.Check("10.0.0.1", []types.ContainerConfig{{ImageKey: "redis",
+		PortMappings: []types.PortMapping{{HostPort: 8001, ContainerPort: 80, Protocol: "tcp"}},
+		Resources:    types.Resources{CPUs: 1, Memory: 256}}}, buyerUsage{}), "Forbidden port should be rejected")
+}
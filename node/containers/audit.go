@@ -0,0 +1,66 @@
+package containers
+
+import (
+	"encoding/json"
+	log "github.com/Sirupsen/logrus"
+	"github.com/strabox/caravela/util"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// auditTrail records the launch requests rejected by the node, one JSON entry per line.
+type auditTrail struct {
+	output io.Writer  // Where the entries are written (nil only logs them).
+	mutex  sync.Mutex // Mutex to serialize the writes of the entries.
+}
+
+// auditEntry represents a rejected launch request in the audit trail.
+type auditEntry struct {
+	Time      time.Time `json:"Time"`
+	BuyerIP   string    `json:"BuyerIP"`
+	OfferID   int64     `json:"OfferID"`
+	ImageKeys []string  `json:"ImageKeys"`
+	Reason    string    `json:"Reason"`
+}
+
+// newAuditTrail creates an audit trail that appends the entries to the given file (empty only logs them).
+func newAuditTrail(auditFile string) (*auditTrail, error) {
+	res := &auditTrail{mutex: sync.Mutex{}}
+	if auditFile != "" {
+		file, err := os.OpenFile(auditFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, err
+		}
+		res.output = file
+	}
+	return res, nil
+}
+
+// Reject records a rejected launch request.
+func (a *auditTrail) Reject(buyerIP string, offerID int64, imageKeys []string, reason error) {
+	log.Warnf(util.LogTag("AUDIT")+"REJECTED Buyer: %s, Offer: %d, Imgs: %v, Reason: %s",
+		buyerIP, offerID, imageKeys, reason)
+
+	if a.output == nil {
+		return
+	}
+
+	entry, err := json.Marshal(auditEntry{
+		Time:      time.Now(),
+		BuyerIP:   buyerIP,
+		OfferID:   offerID,
+		ImageKeys: imageKeys,
+		Reason:    reason.Error(),
+	})
+	if err != nil {
+		return
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if _, err := a.output.Write(append(entry, '\n')); err != nil {
+		log.Errorf(util.LogTag("AUDIT")+"Writing audit entry error: %s", err)
+	}
+}
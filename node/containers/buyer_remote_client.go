@@ -0,0 +1,13 @@
+package containers
+
+import (
+	"context"
+	"github.com/strabox/caravela/api/types"
+)
+
+// Interface that provides the necessary methods to talk with the buyers of the local containers.
+type buyerRemoteClient interface {
+	RescheduleContainer(ctx context.Context, fromSupplier, toBuyer *types.Node, containerID string) error
+	AdoptContainer(ctx context.Context, fromSupplier, toBuyer *types.Node, userID string,
+		containerStatus *types.ContainerStatus) error
+}
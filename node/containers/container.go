@@ -10,17 +10,32 @@ import (
 type localContainer struct {
 	*common.Container // Base container
 
-	buyerIP string // IP of the node that submitted the container in the system TODO: Try use node's GUID and user ID?
+	buyerIP string // IP of the node that submitted the container in the system
+	userID  string // User that owns the container
 }
 
 func newContainer(name, imageKey string, args []string, portMaps []types.PortMapping, resources resources.Resources,
-	dockerID string, buyerIP string) *localContainer {
+	dockerID string, buyerIP string, userID string) *localContainer {
 	return &localContainer{
 		Container: common.NewContainer(name, imageKey, args, portMaps, resources, dockerID),
 		buyerIP:   buyerIP,
+		userID:    userID,
 	}
 }
 
 func (container *localContainer) BuyerIP() string {
 	return container.buyerIP
 }
+
+func (container *localContainer) UserID() string {
+	return container.userID
+}
+
+// imageKeys returns the images keys of the containers configurations.
+func imageKeys(containersConfigs []types.ContainerConfig) []string {
+	res := make([]string, len(containersConfigs))
+	for i, contConfig := range containersConfigs {
+		res[i] = contConfig.ImageKey
+	}
+	return res
+}
@@ -0,0 +1,163 @@
+package containers
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"github.com/docker/distribution/reference"
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/configuration"
+	"io/ioutil"
+	"math/big"
+	"path"
+)
+
+// imagePolicy decides which container's images the node accepts to run as a supplier.
+type imagePolicy struct {
+	allowedRegistries   []string           // Registries allowed (empty allows all).
+	allowedRepositories []string           // Repositories patterns allowed (empty allows all).
+	requireDigest       bool               // If the images must be pinned by digest.
+	requireSignature    bool               // If the images digests must be signed by a trusted key.
+	trustedKeys         []crypto.PublicKey // Public keys trusted to sign the images digests.
+}
+
+// newImagePolicy creates the image policy based on the configurations, loading the trusted public keys.
+func newImagePolicy(config *configuration.Configuration) (*imagePolicy, error) {
+	trustedKeys := make([]crypto.PublicKey, 0, len(config.ImagePolicyTrustedKeys()))
+	for _, keyPath := range config.ImagePolicyTrustedKeys() {
+		publicKey, err := readPublicKey(keyPath)
+		if err != nil {
+			return nil, err
+		}
+		trustedKeys = append(trustedKeys, publicKey)
+	}
+
+	return &imagePolicy{
+		allowedRegistries:   config.ImagePolicyAllowedRegistries(),
+		allowedRepositories: config.ImagePolicyAllowedRepositories(),
+		requireDigest:       config.ImagePolicyRequireDigest() || config.ImagePolicyRequireSignature(),
+		requireSignature:    config.ImagePolicyRequireSignature(),
+		trustedKeys:         trustedKeys,
+	}, nil
+}
+
+// Check verifies if the container's image is accepted, returning a types.ImageRejectedError if it is not.
+func (p *imagePolicy) Check(contConfig types.ContainerConfig) error {
+	imageRef, err := reference.ParseNormalizedNamed(contConfig.ImageKey)
+	if err != nil {
+		return types.NewImageRejectedError(contConfig.ImageKey, "invalid image reference")
+	}
+
+	if len(p.allowedRegistries) > 0 && !contains(p.allowedRegistries, reference.Domain(imageRef)) {
+		return types.NewImageRejectedError(contConfig.ImageKey,
+			fmt.Sprintf("registry %s is not allowed", reference.Domain(imageRef)))
+	}
+
+	repository := reference.FamiliarName(imageRef)
+	if len(p.allowedRepositories) > 0 && !matchesAny(p.allowedRepositories, repository, reference.Path(imageRef),
+		imageRef.Name()) {
+		return types.NewImageRejectedError(contConfig.ImageKey,
+			fmt.Sprintf("repository %s is not allowed", repository))
+	}
+
+	digested, isDigested := imageRef.(reference.Digested)
+	if p.requireDigest && !isDigested {
+		return types.NewImageRejectedError(contConfig.ImageKey, "image must be pinned by digest")
+	}
+
+	if p.requireSignature {
+		if contConfig.ImageSignature == "" {
+			return types.NewImageRejectedError(contConfig.ImageKey, "image signature is missing")
+		}
+		if !p.verifySignature(digested.Digest().String(), contConfig.ImageSignature) {
+			return types.NewImageRejectedError(contConfig.ImageKey, "image signature is not from a trusted key")
+		}
+	}
+	return nil
+}
+
+// verifySignature verifies if the base64 signature of the digest was made by one of the trusted keys.
+func (p *imagePolicy) verifySignature(digest string, signature string) bool {
+	signatureBytes, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	hash := sha256.Sum256([]byte(digest))
+	for _, trustedKey := range p.trustedKeys {
+		switch publicKey := trustedKey.(type) {
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hash[:], signatureBytes) == nil {
+				return true
+			}
+		case *ecdsa.PublicKey:
+			if verifyECDSA(publicKey, hash[:], signatureBytes) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// readPublicKey reads a PEM encoded (PKIX) RSA or ECDSA public key.
+func readPublicKey(keyPath string) (crypto.PublicKey, error) {
+	keyContent, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("impossible read trusted key %s: %s", keyPath, err)
+	}
+
+	block, _ := pem.Decode(keyContent)
+	if block == nil {
+		return nil, fmt.Errorf("trusted key %s is not PEM encoded", keyPath)
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusted key %s: %s", keyPath, err)
+	}
+
+	switch publicKey.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+		return publicKey, nil
+	default:
+		return nil, fmt.Errorf("trusted key %s must be RSA or ECDSA", keyPath)
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAny returns true if one of the values (e.g. different forms of a repository name) matches a pattern.
+func matchesAny(patterns []string, values ...string) bool {
+	for _, pattern := range patterns {
+		for _, value := range values {
+			if matched, _ := path.Match(pattern, value); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// verifyECDSA verifies an ASN.1 encoded ECDSA signature of the hash.
+func verifyECDSA(publicKey *ecdsa.PublicKey, hash []byte, signature []byte) bool {
+	var ecdsaSignature struct {
+		R, S *big.Int
+	}
+	if _, err := asn1.Unmarshal(signature, &ecdsaSignature); err != nil {
+		return false
+	}
+	return ecdsa.Verify(publicKey, hash, ecdsaSignature.R, ecdsaSignature.S)
+}
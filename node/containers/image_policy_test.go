@@ -0,0 +1,90 @@
+package containers
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"github.com/strabox/caravela/api/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const testDigest = "sha256:4b5d3f8b3bd7ba9d6a3e4cd9e7c5b3bfaa2f0a6d5a4b1c2f0e9d8c7b6a5f4e3d"
+
+func TestImagePolicy_Check_AllowAll(t *testing.T) {
+	policy := &imagePolicy{}
+
+	err := policy.Check(types.ContainerConfig{ImageKey: "redis"})
+
+	assert.Nil(t, err, "Empty policy should accept all the images")
+}
+
+func TestImagePolicy_Check_RegistryNotAllowed(t *testing.T) {
+	policy := &imagePolicy{allowedRegistries: []string{"registry.example.com"}}
+
+	err := policy.Check(types.ContainerConfig{ImageKey: "redis"})
+
+	assert.IsType(t, &types.ImageRejectedError{}, err, "Docker Hub image should be rejected")
+	assert.Nil(t, policy.Check(types.ContainerConfig{ImageKey: "registry.example.com/team/app:1.0"}))
+}
+
+func TestImagePolicy_Check_RepositoryPattern(t *testing.T) {
+	policy := &imagePolicy{allowedRepositories: []string{"library/*", "team/*"}}
+
+	assert.Nil(t, policy.Check(types.ContainerConfig{ImageKey: "library/redis"}))
+	assert.Nil(t, policy.Check(types.ContainerConfig{ImageKey: "team/app:1.0"}))
+	assert.IsType(t, &types.ImageRejectedError{}, policy.Check(types.ContainerConfig{ImageKey: "other/app"}))
+}
+
+func TestImagePolicy_Check_RequireDigest(t *testing.T) {
+	policy := &imagePolicy{requireDigest: true}
+
+	assert.IsType(t, &types.ImageRejectedError{}, policy.Check(types.ContainerConfig{ImageKey: "redis:latest"}))
+	assert.Nil(t, policy.Check(types.ContainerConfig{ImageKey: "redis@" + testDigest}))
+}
+
+func TestImagePolicy_Check_SignatureRSA(t *testing.T) {
+	privateKey, _ := rsa.GenerateKey(rand.Reader, 1024)
+	policy := &imagePolicy{requireDigest: true, requireSignature: true, trustedKeys: []crypto.PublicKey{&privateKey.PublicKey}}
+
+	hash := sha256.Sum256([]byte(testDigest))
+	signature, _ := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, hash[:])
+
+	err := policy.Check(types.ContainerConfig{ImageKey: "redis@" + testDigest,
+		ImageSignature: base64.StdEncoding.EncodeToString(signature)})
+
+	assert.Nil(t, err, "Signature from a trusted key should be accepted")
+}
+
+func TestImagePolicy_Check_SignatureECDSA(t *testing.T) {
+	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	policy := &imagePolicy{requireDigest: true, requireSignature: true, trustedKeys: []crypto.PublicKey{&privateKey.PublicKey}}
+
+	hash := sha256.Sum256([]byte(testDigest))
+	signature, _ := privateKey.Sign(rand.Reader, hash[:], crypto.SHA256)
+
+	err := policy.Check(types.ContainerConfig{ImageKey: "redis@" + testDigest,
+		ImageSignature: base64.StdEncoding.EncodeToString(signature)})
+
+	assert.Nil(t, err, "Signature from a trusted key should be accepted")
+}
+
+func TestImagePolicy_Check_SignatureUntrusted(t *testing.T) {
+	trustedKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	untrustedKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	policy := &imagePolicy{requireDigest: true, requireSignature: true, trustedKeys: []crypto.PublicKey{&trustedKey.PublicKey}}
+
+	hash := sha256.Sum256([]byte(testDigest))
+	signature, _ := untrustedKey.Sign(rand.Reader, hash[:], crypto.SHA256)
+
+	err := policy.Check(types.ContainerConfig{ImageKey: "redis@" + testDigest,
+		ImageSignature: base64.StdEncoding.EncodeToString(signature)})
+	assert.IsType(t, &types.ImageRejectedError{}, err, "Signature from an untrusted key should be rejected")
+
+	err = policy.Check(types.ContainerConfig{ImageKey: "redis@" + testDigest})
+	assert.IsType(t, &types.ImageRejectedError{}, err, "Missing signature should be rejected")
+}
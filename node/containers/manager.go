@@ -1,6 +1,7 @@
 package containers
 
 import (
+	"context"
 	"fmt"
 	log "github.com/Sirupsen/logrus"
 	"github.com/pkg/errors"
@@ -13,9 +14,13 @@ import (
 	"github.com/strabox/caravela/util"
 	"github.com/strabox/caravela/util/debug"
 	"sync"
+	"time"
 	"unsafe"
 )
 
+// Interval between the checks of the containers still running while the node is draining.
+const drainCheckInterval = 500 * time.Millisecond
+
 // Containers manager responsible for interacting with the Docker daemon and managing all the interaction with the
 // deployed containers.
 // Basically it is a local node manager for the containers.
@@ -25,23 +30,59 @@ type Manager struct {
 	config       *configuration.Configuration // System's configurations.
 	dockerClient external.DockerClient        // Docker's client.
 	supplier     supplierLocal                // Local Supplier component.
+	client       buyerRemoteClient            // Client to talk with the containers' buyers.
+	imagePolicy  *imagePolicy                 // Policy that decides which images the node accepts to run.
+	admission    *admissionPolicy             // Policy that decides which buyers' requests the node accepts.
+	audit        *auditTrail                  // Records the rejected launch requests.
+	accounting   *accountingLedger            // Records the resources used by the buyers' containers.
 
 	quitChan        chan bool                             // Channel to alert that the node is stopping.
 	containersMutex sync.Mutex                            // Mutex to control access to containers map.
 	containersMap   map[string]map[string]*localContainer // Collection of deployed containers (buyerIP->(containerID->Container)).
+	exited          map[string]*localContainer            // Containers that exited on their own, until their buyers stop them.
 }
 
 // NewManager creates a new containers manager component.
 func NewManager(config *configuration.Configuration, dockerClient external.DockerClient,
-	supplier supplierLocal) *Manager {
+	supplier supplierLocal, client buyerRemoteClient) *Manager {
+	imagePolicy, err := newImagePolicy(config)
+	if err != nil {
+		log.Panicf(util.LogTag("CONTAINER")+"Invalid image policy: %s", err)
+	}
+
+	admission, err := newAdmissionPolicy(config)
+	if err != nil {
+		log.Panicf(util.LogTag("CONTAINER")+"Invalid admission policy: %s", err)
+	}
+
+	audit, err := newAuditTrail(config.AdmissionAuditFile())
+	if err != nil {
+		log.Panicf(util.LogTag("CONTAINER")+"Impossible open audit file: %s", err)
+	}
+
+	accountingFile := config.AccountingFile()
+	if config.Simulation() {
+		accountingFile = ""
+	}
+	accounting, err := newAccountingLedger(accountingFile)
+	if err != nil {
+		log.Panicf(util.LogTag("CONTAINER")+"Impossible open accounting file: %s", err)
+	}
+
 	return &Manager{
 		config:       config,
 		dockerClient: dockerClient,
 		supplier:     supplier,
+		client:       client,
+		imagePolicy:  imagePolicy,
+		admission:    admission,
+		audit:        audit,
+		accounting:   accounting,
 
 		quitChan:        make(chan bool),
 		containersMutex: sync.Mutex{},
 		containersMap:   make(map[string]map[string]*localContainer),
+		exited:          make(map[string]*localContainer),
 	}
 }
 
@@ -52,7 +93,7 @@ func (m *Manager) receiveDockerEvents(eventsChan <-chan *events.Event) {
 			select {
 			case event := <-eventsChan:
 				if event.Type == events.ContainerDied {
-					m.StopContainer(event.Value)
+					m.containerExited(event.Value)
 				}
 			case quit := <-m.quitChan: // Stopping the containers management
 				if quit {
@@ -65,8 +106,8 @@ func (m *Manager) receiveDockerEvents(eventsChan <-chan *events.Event) {
 }
 
 // Verify if the offer is valid and alert the supplier and after that start the container in the Docker engine.
-func (m *Manager) StartContainer(fromBuyer *types.Node, offer *types.Offer, containersConfigs []types.ContainerConfig,
-	totalResourcesNecessary resources.Resources) ([]types.ContainerStatus, error) {
+func (m *Manager) StartContainer(fromBuyer *types.Node, userID string, offer *types.Offer,
+	containersConfigs []types.ContainerConfig, totalResourcesNecessary resources.Resources) ([]types.ContainerStatus, error) {
 	if !m.IsWorking() {
 		panic(fmt.Errorf("can't start container, container manager not working"))
 	}
@@ -74,6 +115,20 @@ func (m *Manager) StartContainer(fromBuyer *types.Node, offer *types.Offer, cont
 	m.containersMutex.Lock()
 	defer m.containersMutex.Unlock()
 
+	// ============== Verify the request against the admission policies ==============
+
+	if err := m.admission.Check(fromBuyer.IP, containersConfigs, m.buyerUsage(fromBuyer.IP)); err != nil {
+		m.audit.Reject(fromBuyer.IP, offer.ID, imageKeys(containersConfigs), err)
+		return nil, fmt.Errorf("can't start container, %s", err)
+	}
+
+	for _, contConfig := range containersConfigs {
+		if err := m.imagePolicy.Check(contConfig); err != nil {
+			m.audit.Reject(fromBuyer.IP, offer.ID, imageKeys(containersConfigs), err)
+			return nil, err
+		}
+	}
+
 	// =================== Obtain the resources from the offer ==================
 
 	obtained := m.supplier.ObtainResources(offer.ID, totalResourcesNecessary, len(containersConfigs))
@@ -104,7 +159,7 @@ func (m *Manager) StartContainer(fromBuyer *types.Node, offer *types.Offer, cont
 		containerID := deployedContStatus[i].ContainerID
 		contResources := resources.NewResourcesCPUClass(int(contConfig.Resources.CPUClass), contConfig.Resources.CPUs, contConfig.Resources.Memory)
 		newContainer := newContainer(contConfig.Name, contConfig.ImageKey, contConfig.Args, contConfig.PortMappings,
-			*contResources, containerID, fromBuyer.IP)
+			*contResources, containerID, fromBuyer.IP, userID)
 
 		if _, ok := m.containersMap[fromBuyer.IP]; !ok {
 			userContainersMap := make(map[string]*localContainer)
@@ -113,6 +168,7 @@ func (m *Manager) StartContainer(fromBuyer *types.Node, offer *types.Offer, cont
 		} else {
 			m.containersMap[fromBuyer.IP][containerID] = newContainer
 		}
+		m.accounting.Start(newContainer)
 
 		deployedContStatus[i].SupplierIP = m.config.HostIP() // Set the container's supplier's IP!
 
@@ -124,16 +180,45 @@ func (m *Manager) StartContainer(fromBuyer *types.Node, offer *types.Offer, cont
 	return deployedContStatus, nil
 }
 
+// buyerUsage returns the resources used by the buyer's containers running in the node.
+func (m *Manager) buyerUsage(buyerIP string) buyerUsage {
+	usage := buyerUsage{}
+	for _, container := range m.containersMap[buyerIP] {
+		contResources := container.Resources()
+		usage.containers++
+		usage.cpus += contResources.CPUs()
+		usage.memory += contResources.Memory()
+	}
+	return usage
+}
+
 // StopContainer stop a local container in the Docker engine and remove it.
 func (m *Manager) StopContainer(containerIDToStop string) error {
+	return m.stopContainer(containerIDToStop, func(*localContainer) bool { return true })
+}
+
+// StopUserContainer stops a local container, in the Docker engine, only if it was launched by the given buyer
+// and it is owned by the given user.
+func (m *Manager) StopUserContainer(buyerIP string, userID string, containerIDToStop string) error {
+	return m.stopContainer(containerIDToStop, func(container *localContainer) bool {
+		return container.BuyerIP() == buyerIP && container.UserID() == userID
+	})
+}
+
+// stopContainer stops and removes a local container if the container is allowed to be stopped.
+func (m *Manager) stopContainer(containerIDToStop string, allowed func(*localContainer) bool) error {
 	m.containersMutex.Lock()
 	defer m.containersMutex.Unlock()
 
 	for buyerIP, containersMap := range m.containersMap {
 		for containerID, container := range containersMap {
 			if containerID == containerIDToStop {
+				if !allowed(container) {
+					return errors.New("container is not owned by the buyer's user")
+				}
 				m.dockerClient.RemoveContainer(containerIDToStop)
 				m.supplier.ReturnResources(container.Resources(), 1)
+				m.accounting.Finish(containerID)
 				delete(containersMap, containerID)
 				return nil
 			}
@@ -143,7 +228,183 @@ func (m *Manager) StopContainer(containerIDToStop string) error {
 		}
 	}
 
-	return errors.New("container does not exist")
+	if container, exited := m.exited[containerIDToStop]; exited && allowed(container) {
+		delete(m.exited, containerIDToStop)
+		return types.NewContainerNotFoundError(containerIDToStop, true)
+	}
+	return types.NewContainerNotFoundError(containerIDToStop, false)
+}
+
+// containerExited removes a local container that exited on its own, remembering it until its buyer stops it.
+func (m *Manager) containerExited(containerID string) {
+	m.containersMutex.Lock()
+	var exitedContainer *localContainer
+	for _, containersMap := range m.containersMap {
+		if container, exist := containersMap[containerID]; exist {
+			exitedContainer = container
+		}
+	}
+	m.containersMutex.Unlock()
+
+	if exitedContainer != nil && m.StopContainer(containerID) == nil {
+		m.containersMutex.Lock()
+		m.exited[containerID] = exitedContainer
+		m.containersMutex.Unlock()
+	}
+}
+
+// Drain asks the buyers of all the local containers to reschedule them in other suppliers and waits, until the
+// timeout, for them to stop the local containers. It returns the number of containers moved and still running.
+func (m *Manager) Drain(ctx context.Context, timeout time.Duration) (int, int) {
+	m.containersMutex.Lock()
+	containersBuyer := make(map[string]string) // ContainerID->BuyerIP
+	for buyerIP, containersMap := range m.containersMap {
+		for containerID := range containersMap {
+			containersBuyer[containerID] = buyerIP
+		}
+	}
+	m.containersMutex.Unlock()
+
+	rescheduleCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for containerID, buyerIP := range containersBuyer {
+		go func(containerID, buyerIP string) {
+			log.Debugf(util.LogTag("CONTAINER")+"DRAINING Container: %s, Buyer: %s", containerID[0:12], buyerIP)
+			err := m.client.RescheduleContainer(rescheduleCtx, &types.Node{IP: m.config.HostIP()},
+				&types.Node{IP: buyerIP}, containerID)
+			if err != nil {
+				log.Debugf(util.LogTag("CONTAINER")+"Reschedule FAILED, Container: %s, Buyer: %s, error: %s",
+					containerID[0:12], buyerIP, err)
+			}
+		}(containerID, buyerIP)
+	}
+
+	for {
+		remaining := m.runningContainers(containersBuyer)
+		select {
+		case <-rescheduleCtx.Done():
+			return len(containersBuyer) - remaining, remaining
+		default:
+			if remaining == 0 {
+				return len(containersBuyer), 0
+			}
+			time.Sleep(drainCheckInterval)
+		}
+	}
+}
+
+// runningContainers returns how many of the given containers are still running in the node.
+func (m *Manager) runningContainers(containersBuyer map[string]string) int {
+	m.containersMutex.Lock()
+	defer m.containersMutex.Unlock()
+
+	running := 0
+	for containerID, buyerIP := range containersBuyer {
+		if _, exist := m.containersMap[buyerIP][containerID]; exist {
+			running++
+		}
+	}
+	return running
+}
+
+// keepContainers records the running containers in the state file, so they keep running while the node is stopped
+// and are re-registered when it restarts. It returns false if the containers could not be recorded.
+func (m *Manager) keepContainers() bool {
+	containersState := make([]containerState, 0)
+	for _, containers := range m.containersMap {
+		for containerID, container := range containers {
+			contResources := container.Resources()
+			containersState = append(containersState, containerState{
+				ContainerID:  containerID,
+				Name:         container.Name(),
+				ImageKey:     container.ImageKey(),
+				Args:         container.Args(),
+				PortMappings: container.PortMappings(),
+				Resources: types.Resources{
+					CPUClass: types.CPUClass(contResources.CPUClass()),
+					CPUs:     contResources.CPUs(),
+					Memory:   contResources.Memory(),
+				},
+				BuyerIP: container.BuyerIP(),
+				UserID:  container.UserID(),
+				Start:   m.accounting.StartTime(containerID),
+			})
+		}
+	}
+
+	if err := saveContainersState(m.config.ContainersStateFile(), containersState); err != nil {
+		log.Errorf(util.LogTag("CONTAINER")+"Impossible keep the containers running, error: %s", err)
+		return false
+	}
+	log.Debugf(util.LogTag("CONTAINER")+"%d containers KEPT running", len(containersState))
+	return true
+}
+
+// restoreContainers re-registers the containers that kept running while the node was stopped and re-establishes
+// their ownership with the buyers. The containers that died meanwhile are removed.
+func (m *Manager) restoreContainers() {
+	containersState, err := loadContainersState(m.config.ContainersStateFile())
+	if err != nil {
+		log.Errorf(util.LogTag("CONTAINER")+"Impossible read the kept containers, error: %s", err)
+		return
+	}
+
+	m.containersMutex.Lock()
+	defer m.containersMutex.Unlock()
+
+	for _, state := range containersState {
+		status, err := m.dockerClient.CheckContainerStatus(state.ContainerID)
+		if err != nil || !status.IsRunning() {
+			log.Debugf(util.LogTag("CONTAINER")+"Kept container %s DIED meanwhile", state.ContainerID[0:12])
+			m.dockerClient.RemoveContainer(state.ContainerID)
+			continue
+		}
+
+		contResources := resources.NewResourcesCPUClass(int(state.Resources.CPUClass), state.Resources.CPUs,
+			state.Resources.Memory)
+		container := newContainer(state.Name, state.ImageKey, state.Args, state.PortMappings, *contResources,
+			state.ContainerID, state.BuyerIP, state.UserID)
+		if _, ok := m.containersMap[state.BuyerIP]; !ok {
+			m.containersMap[state.BuyerIP] = make(map[string]*localContainer)
+		}
+		m.containersMap[state.BuyerIP][state.ContainerID] = container
+		m.accounting.Resume(container, state.Start)
+		m.supplier.RecoverResources(*contResources, 1)
+
+		containerStatus := &types.ContainerStatus{
+			ContainerConfig: types.ContainerConfig{
+				Name:         state.Name,
+				ImageKey:     state.ImageKey,
+				Args:         state.Args,
+				PortMappings: state.PortMappings,
+				Resources:    state.Resources,
+			},
+			SupplierIP:  m.config.HostIP(),
+			ContainerID: state.ContainerID,
+			Status:      "Running",
+		}
+		go func(buyerIP, userID string) {
+			err := m.client.AdoptContainer(context.Background(), &types.Node{IP: m.config.HostIP()},
+				&types.Node{IP: buyerIP}, userID, containerStatus)
+			if _, refused := err.(*types.QuotaExceededError); refused { // Nobody owns the container anymore.
+				log.Debugf(util.LogTag("CONTAINER")+"Adopt container %s REFUSED, Buyer: %s, error: %s",
+					containerStatus.ContainerID[0:12], buyerIP, err)
+				m.StopContainer(containerStatus.ContainerID)
+			} else if err != nil {
+				log.Debugf(util.LogTag("CONTAINER")+"Adopt container %s FAILED, Buyer: %s, error: %s",
+					containerStatus.ContainerID[0:12], buyerIP, err)
+			}
+		}(state.BuyerIP, state.UserID)
+
+		log.Debugf(util.LogTag("CONTAINER")+"Container %s RESTORED, Buyer: %s, Img: %s", state.ContainerID[0:12],
+			state.BuyerIP, state.ImageKey)
+	}
+}
+
+// UsageRecords returns the resources used by the buyers' containers since the given time.
+func (m *Manager) UsageRecords(since time.Time) ([]types.UsageRecord, error) {
+	return m.accounting.Records(since)
 }
 
 // ===============================================================================
@@ -155,6 +416,7 @@ func (m *Manager) Start() {
 		if !m.config.Simulation() {
 			eventsChan := m.dockerClient.Start()
 			m.receiveDockerEvents(eventsChan)
+			m.restoreContainers()
 		}
 	})
 }
@@ -164,10 +426,16 @@ func (m *Manager) Stop() {
 		m.containersMutex.Lock()
 		defer m.containersMutex.Unlock()
 
+		if m.config.ShutdownMode() == "keep" && !m.config.Simulation() && m.keepContainers() {
+			m.quitChan <- true
+			return
+		}
+
 		// Stop and remove all the running containers from the docker engine
 		for _, containers := range m.containersMap {
 			for containerID := range containers {
 				m.dockerClient.RemoveContainer(containerID)
+				m.accounting.Finish(containerID)
 				log.Debugf(util.LogTag("CONTAINER")+"Container, %s STOPPED and REMOVED", containerID)
 			}
 		}
@@ -188,6 +456,7 @@ func (m *Manager) DebugSizeBytes() int {
 	localContainerSize := func(container *localContainer) uintptr {
 		contSizeBytes := unsafe.Sizeof(*container)
 		contSizeBytes += debug.SizeofString(container.buyerIP)
+		contSizeBytes += debug.SizeofString(container.userID)
 		// common.Container
 		contSizeBytes += unsafe.Sizeof(*container.Container)
 		contSizeBytes += debug.SizeofString(container.Name())
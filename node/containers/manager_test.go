@@ -0,0 +1,129 @@
+package containers
+
+import (
+	"context"
+	"errors"
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/configuration"
+	"github.com/strabox/caravela/docker/fake"
+	"github.com/strabox/caravela/node/common/resources"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+// supplierStub always provides the resources asked by the containers manager.
+type supplierStub struct {
+	returned int
+}
+
+func (s *supplierStub) ObtainResources(int64, resources.Resources, int) bool {
+	return true
+}
+
+func (s *supplierStub) ReturnResources(_ resources.Resources, numContainersStopped int) {
+	s.returned += numContainersStopped
+}
+
+func (s *supplierStub) RecoverResources(resources.Resources, int) {}
+
+// buyerStub stops the containers that the supplier asks to reschedule, unless it is unreachable.
+type buyerStub struct {
+	manager     *Manager
+	unreachable bool
+	mutex       sync.Mutex // The supplier asks to reschedule the containers concurrently
+}
+
+func (b *buyerStub) RescheduleContainer(_ context.Context, _, toBuyer *types.Node, containerID string) error {
+	b.mutex.Lock()
+	unreachable := b.unreachable
+	b.mutex.Unlock()
+	if unreachable {
+		return errors.New("buyer unreachable")
+	}
+	return b.manager.StopUserContainer(toBuyer.IP, "alice", containerID)
+}
+
+func (b *buyerStub) setUnreachable(unreachable bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.unreachable = unreachable
+}
+
+func (b *buyerStub) AdoptContainer(context.Context, *types.Node, *types.Node, string, *types.ContainerStatus) error {
+	return nil
+}
+
+func TestManager_StopUserContainer(t *testing.T) {
+	config := configuration.Default("10.0.0.1")
+	config.Caravela.Simulation = true
+	supplier := &supplierStub{}
+	manager := NewManager(config, fake.NewClient(0, 4, 4096), supplier, nil)
+	manager.Start()
+	containersStatus, err := manager.StartContainer(&types.Node{IP: "10.0.0.2"}, "alice", &types.Offer{ID: 1},
+		[]types.ContainerConfig{{ImageKey: "redis", Resources: types.Resources{CPUs: 1, Memory: 256}}},
+		*resources.NewResourcesCPUClass(0, 1, 256))
+	if err != nil {
+		t.Fatal(err)
+	}
+	containerID := containersStatus[0].ContainerID
+
+	assert.Error(t, manager.StopUserContainer("10.0.0.3", "alice", containerID), "Other buyer stopped the container")
+	assert.Error(t, manager.StopUserContainer("10.0.0.2", "bob", containerID), "Other user stopped the container")
+	assert.Equal(t, 0, supplier.returned, "Resources returned without stopping the container")
+
+	assert.NoError(t, manager.StopUserContainer("10.0.0.2", "alice", containerID))
+	assert.Equal(t, 1, supplier.returned, "Container's resources not returned")
+	assert.Error(t, manager.StopUserContainer("10.0.0.2", "alice", containerID), "Stopped container still exists")
+}
+
+func TestManager_StopUserContainer_Exited(t *testing.T) {
+	config := configuration.Default("10.0.0.1")
+	config.Caravela.Simulation = true
+	manager := NewManager(config, fake.NewClient(0, 4, 4096), &supplierStub{}, nil)
+	manager.Start()
+	containersStatus, err := manager.StartContainer(&types.Node{IP: "10.0.0.2"}, "alice", &types.Offer{ID: 1},
+		[]types.ContainerConfig{{ImageKey: "redis", Resources: types.Resources{CPUs: 1, Memory: 256}}},
+		*resources.NewResourcesCPUClass(0, 1, 256))
+	if err != nil {
+		t.Fatal(err)
+	}
+	containerID := containersStatus[0].ContainerID
+
+	manager.containerExited(containerID)
+	otherErr := manager.StopUserContainer("10.0.0.3", "alice", containerID)
+	buyerErr := manager.StopUserContainer("10.0.0.2", "alice", containerID)
+	lostErr := manager.StopUserContainer("10.0.0.2", "alice", containerID)
+
+	assert.Equal(t, types.NewContainerNotFoundError(containerID, false), otherErr, "Other buyer knows the container exited")
+	assert.Equal(t, types.NewContainerNotFoundError(containerID, true), buyerErr, "Container exited on its own")
+	assert.Equal(t, types.NewContainerNotFoundError(containerID, false), lostErr, "Container exited only once")
+}
+
+func TestManager_Drain(t *testing.T) {
+	config := configuration.Default("10.0.0.1")
+	config.Caravela.Simulation = true
+	buyer := &buyerStub{}
+	manager := NewManager(config, fake.NewClient(0, 4, 4096), &supplierStub{}, buyer)
+	buyer.manager = manager
+	manager.Start()
+	for i := 0; i < 2; i++ {
+		_, err := manager.StartContainer(&types.Node{IP: "10.0.0.2"}, "alice", &types.Offer{ID: int64(i)},
+			[]types.ContainerConfig{{ImageKey: "redis", Resources: types.Resources{CPUs: 1, Memory: 256}}},
+			*resources.NewResourcesCPUClass(0, 1, 256))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	buyer.setUnreachable(true)
+	moved, remaining := manager.Drain(context.Background(), 50*time.Millisecond)
+	assert.Equal(t, 0, moved, "Containers moved without the buyer")
+	assert.Equal(t, 2, remaining)
+
+	buyer.setUnreachable(false)
+	moved, remaining = manager.Drain(context.Background(), 5*time.Second)
+	assert.Equal(t, 2, moved, "Containers not moved by the buyer")
+	assert.Equal(t, 0, remaining)
+}
@@ -0,0 +1,48 @@
+package containers
+
+import (
+	"encoding/json"
+	"github.com/strabox/caravela/api/types"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+// containerState holds what is necessary to re-register a container that kept running while the node restarted.
+type containerState struct {
+	ContainerID  string              `json:"ContainerID"`
+	Name         string              `json:"Name"`
+	ImageKey     string              `json:"ImageKey"`
+	Args         []string            `json:"Args"`
+	PortMappings []types.PortMapping `json:"PortMappings"`
+	Resources    types.Resources     `json:"Resources"`
+	BuyerIP      string              `json:"BuyerIP"`
+	UserID       string              `json:"UserID"`
+	Start        time.Time           `json:"Start"` // When the container started being accounted
+}
+
+// saveContainersState writes the state of the containers kept running into the file.
+func saveContainersState(stateFile string, containersState []containerState) error {
+	stateJSON, err := json.MarshalIndent(containersState, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(stateFile, stateJSON, 0600)
+}
+
+// loadContainersState reads the state of the containers kept running during the restart and removes the file, so
+// the containers are only re-registered once. A missing file means that no container was kept.
+func loadContainersState(stateFile string) ([]containerState, error) {
+	stateJSON, err := ioutil.ReadFile(stateFile)
+	if os.IsNotExist(err) {
+		return make([]containerState, 0), nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var containersState []containerState
+	if err := json.Unmarshal(stateJSON, &containersState); err != nil {
+		return nil, err
+	}
+	return containersState, os.Remove(stateFile)
+}
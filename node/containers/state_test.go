@@ -0,0 +1,44 @@
+package containers
+
+import (
+	"github.com/strabox/caravela/api/types"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestContainersState_SaveLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "caravela_containers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	stateFile := filepath.Join(dir, "containers.json")
+
+	kept := []containerState{{
+		ContainerID: "0123456789abcdef",
+		ImageKey:    "redis",
+		Resources:   types.Resources{CPUs: 1, Memory: 256},
+		BuyerIP:     "10.0.0.1",
+		UserID:      "alice",
+		Start:       time.Unix(1000, 0).UTC(),
+	}}
+	assert.Nil(t, saveContainersState(stateFile, kept))
+
+	loaded, err := loadContainersState(stateFile)
+
+	assert.Nil(t, err, "Load of the kept containers should succeed")
+	assert.Equal(t, kept, loaded)
+	_, err = os.Stat(stateFile)
+	assert.True(t, os.IsNotExist(err), "State file should be removed after being loaded")
+}
+
+func TestContainersState_LoadMissing(t *testing.T) {
+	loaded, err := loadContainersState(filepath.Join(os.TempDir(), "caravela_missing_containers.json"))
+
+	assert.Nil(t, err, "Missing state file means no containers were kept")
+	assert.Empty(t, loaded)
+}
@@ -0,0 +1,12 @@
+package credits
+
+import "time"
+
+// bill represents a container, launched by this node in a supplier, whose resource-time is being paid.
+type bill struct {
+	supplierIP string    // Supplier where the container runs.
+	userID     string    // User that owns the container.
+	cpus       int       // CPUs of the container.
+	memory     int       // Memory of the container.
+	paidUntil  time.Time // Resource-time already paid.
+}
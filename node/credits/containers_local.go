@@ -0,0 +1,10 @@
+package credits
+
+import (
+	"github.com/strabox/caravela/api/types"
+	"time"
+)
+
+type containersLocal interface {
+	UsageRecords(since time.Time) ([]types.UsageRecord, error)
+}
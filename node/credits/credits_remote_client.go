@@ -0,0 +1,13 @@
+package credits
+
+import (
+	"context"
+	"github.com/strabox/caravela/api/types"
+)
+
+// Interface that provides the necessary methods to talk with other nodes.
+type creditsRemoteClient interface {
+	ExchangeReceipt(ctx context.Context, fromBuyer, toSupplier *types.Node, receipt *types.CreditReceipt) (*types.CreditReceipt, error)
+	PostReceipt(ctx context.Context, fromNode, toNode *types.Node, receipt *types.CreditReceipt) error
+	GetBalance(ctx context.Context, fromNode, toNode *types.Node, nodeIP string) (float64, error)
+}
@@ -0,0 +1,45 @@
+package credits
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"os"
+)
+
+// loadKey loads the node's receipts signing key from the key file, creating it the first time so the node keeps
+// the same key (identity in the receipts) across restarts. An empty file name returns a new key.
+func loadKey(keyFile string) (*ecdsa.PrivateKey, error) {
+	if keyFile == "" {
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	}
+
+	keyPEM, err := ioutil.ReadFile(keyFile)
+	if os.IsNotExist(err) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+		if err := ioutil.WriteFile(keyFile, keyPEM, 0600); err != nil {
+			return nil, err
+		}
+		return key, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, errors.New("receipts key is not PEM encoded")
+	}
+	return x509.ParseECPrivateKey(keyBlock.Bytes)
+}
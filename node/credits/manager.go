@@ -0,0 +1,565 @@
+package credits
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	log "github.com/Sirupsen/logrus"
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/configuration"
+	"github.com/strabox/caravela/node/common"
+	"github.com/strabox/caravela/node/common/guid"
+	"github.com/strabox/caravela/overlay"
+	"github.com/strabox/caravela/util"
+	"os"
+	"sync"
+	"time"
+)
+
+// clockSkew is the difference tolerated between the clocks of the buyers and the suppliers.
+const clockSkew = 30 * time.Second
+
+// Credits manager is responsible for the fair-share between the nodes: the buyers pay credits to the suppliers for
+// the resource-time used by their containers, periodically while the containers run and when they are stopped.
+// Each payment is a receipt signed by the buyer and countersigned by the supplier, after checking that it delivered
+// that resource-time, which both post into the nodes responsible (in the overlay) for the buyer's and supplier's
+// accounts. The suppliers consult the buyer's account to refuse or deprioritize the buyers with a negative balance.
+// The nodes keep their signing keys across restarts and each key is bound to the first node that proves to own it,
+// so a node can't sign receipts on behalf of another.
+type Manager struct {
+	common.NodeComponent // Base component.
+
+	config       *configuration.Configuration // System's configurations.
+	overlay      overlay.Overlay              // Overlay component.
+	remoteClient creditsRemoteClient          // Client to collaborate with other CARAVELA's nodes.
+	containers   containersLocal              // Local Containers Manager component.
+
+	key       *ecdsa.PrivateKey // Key used to sign the receipts.
+	publicKey []byte            // Public key (PKIX DER) that goes in the receipts.
+
+	accountsMutex sync.Mutex         // Mutex to control access to the accounts.
+	balances      map[string]float64 // Balance changes of the nodes' accounts (nodeIP->Credits).
+	applied       map[string]bool    // Receipts already applied into the accounts (receiptID).
+	keys          map[string][]byte  // Receipts' public keys bound to the nodes (nodeIP->PKIX DER).
+
+	billingMutex sync.Mutex       // Mutex to serialize the payments of the containers' resource-time.
+	billsMutex   sync.Mutex       // Mutex to control access to the bills.
+	bills        map[string]*bill // Containers launched by this node that are being paid (containerID->Bill).
+	now          func() time.Time // Clock used to bill the containers' resource-time.
+
+	quitChan      chan bool        // Channel to alert that the node is stopping.
+	billingTicker <-chan time.Time // Timer to pay the resource-time of the running containers.
+}
+
+// NewManager creates a new credits manager component.
+func NewManager(config *configuration.Configuration, overlay overlay.Overlay, remoteClient creditsRemoteClient,
+	containers containersLocal) *Manager {
+	keyFile := config.CreditsKeyFile()
+	if !config.CreditsEnabled() || config.Simulation() {
+		keyFile = ""
+	}
+	key, err := loadKey(keyFile)
+	if err != nil {
+		log.Panicf(util.LogTag("CREDITS")+"Impossible load receipts key: %s", err)
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		log.Panicf(util.LogTag("CREDITS")+"Impossible encode receipts key: %s", err)
+	}
+
+	var billingTicker <-chan time.Time = nil // Nil channel never fires, the containers are only paid when stopped.
+	if config.CreditsEnabled() && !config.Simulation() {
+		billingTicker = time.NewTicker(config.CreditsBillingInterval()).C
+	}
+
+	res := &Manager{
+		config:       config,
+		overlay:      overlay,
+		remoteClient: remoteClient,
+		containers:   containers,
+
+		key:       key,
+		publicKey: publicKey,
+
+		accountsMutex: sync.Mutex{},
+		balances:      make(map[string]float64),
+		applied:       make(map[string]bool),
+		keys:          make(map[string][]byte),
+
+		billingMutex: sync.Mutex{},
+		billsMutex:   sync.Mutex{},
+		bills:        make(map[string]*bill),
+		now:          time.Now,
+
+		quitChan:      make(chan bool),
+		billingTicker: billingTicker,
+	}
+
+	if config.CreditsEnabled() && !config.Simulation() {
+		if err := res.loadReceipts(); err != nil {
+			log.Panicf(util.LogTag("CREDITS")+"Impossible load receipts: %s", err)
+		}
+	}
+	res.keys[config.HostIP()] = publicKey
+	return res
+}
+
+// start controls the time dependant actions like paying the resource-time of the running containers.
+func (m *Manager) start() {
+	for {
+		select {
+		case <-m.billingTicker: // Pay the resource-time used by the running containers since the last payment.
+			m.billContainers(context.Background())
+		case quit := <-m.quitChan: // Stopping the credits management
+			if quit {
+				log.Info(util.LogTag("CREDITS") + "STOPPED")
+				return
+			}
+		}
+	}
+}
+
+// Admit decides if a buyer's launch request is admitted according with the buyer's balance.
+// The requests of buyers with a negative balance are refused or delayed (deprioritized).
+func (m *Manager) Admit(ctx context.Context, buyerIP string) error {
+	if !m.config.CreditsEnabled() {
+		return nil
+	}
+
+	balance, err := m.Balance(ctx, buyerIP)
+	if err != nil || balance >= 0 { // Unknown balances are admitted.
+		return nil
+	}
+
+	if m.config.CreditsNegativeBalancePolicy() == "refuse" {
+		return fmt.Errorf("buyer %s has a negative balance: %.2f credits", buyerIP, balance)
+	}
+
+	log.Debugf(util.LogTag("CREDITS")+"Deprioritized buyer: %s, Balance: %.2f", buyerIP, balance)
+	select {
+	case <-time.After(m.config.CreditsDeprioritizeDelay()):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Pay starts paying the supplier for the resources of the containers launched in it. Their resource-time is paid
+// periodically while they run and when they are stopped.
+func (m *Manager) Pay(_ context.Context, supplierIP string, userID string, containersStatus []types.ContainerStatus) {
+	if !m.config.CreditsEnabled() {
+		return
+	}
+
+	m.billsMutex.Lock()
+	defer m.billsMutex.Unlock()
+
+	now := m.now()
+	for _, contStatus := range containersStatus {
+		m.bills[contStatus.ContainerID] = &bill{
+			supplierIP: supplierIP,
+			userID:     userID,
+			cpus:       contStatus.Resources.CPUs,
+			memory:     contStatus.Resources.Memory,
+			paidUntil:  now,
+		}
+	}
+}
+
+// Settle pays the resource-time used by a container since the last payment, it is called when the container stops.
+func (m *Manager) Settle(ctx context.Context, containerID string) {
+	if !m.config.CreditsEnabled() {
+		return
+	}
+
+	m.billingMutex.Lock()
+	defer m.billingMutex.Unlock()
+
+	m.billsMutex.Lock()
+	contBill, exist := m.bills[containerID]
+	delete(m.bills, containerID)
+	m.billsMutex.Unlock()
+	if !exist {
+		return
+	}
+
+	err := m.pay(ctx, contBill.supplierIP, contBill.userID, []string{containerID}, contBill.cpus, contBill.memory,
+		contBill.paidUntil, m.now())
+	if err != nil {
+		log.Errorf(util.LogTag("CREDITS")+"Settle container %s FAILED, error: %s", containerID, err)
+	}
+}
+
+// billContainers pays the resource-time used by the running containers since their last payment, in a receipt for
+// each group of containers of the same supplier and user that were paid until the same time.
+func (m *Manager) billContainers(ctx context.Context) {
+	m.billingMutex.Lock()
+	defer m.billingMutex.Unlock()
+
+	type billGroup struct {
+		supplierIP string
+		userID     string
+		paidUntil  time.Time
+	}
+
+	m.billsMutex.Lock()
+	groups := make(map[billGroup][]string)
+	groupsResources := make(map[billGroup][2]int)
+	for containerID, contBill := range m.bills {
+		group := billGroup{supplierIP: contBill.supplierIP, userID: contBill.userID, paidUntil: contBill.paidUntil}
+		groups[group] = append(groups[group], containerID)
+		groupResources := groupsResources[group]
+		groupsResources[group] = [2]int{groupResources[0] + contBill.cpus, groupResources[1] + contBill.memory}
+	}
+	m.billsMutex.Unlock()
+
+	now := m.now()
+	for group, containerIDs := range groups {
+		err := m.pay(ctx, group.supplierIP, group.userID, containerIDs, groupsResources[group][0],
+			groupsResources[group][1], group.paidUntil, now)
+		if err != nil {
+			log.Errorf(util.LogTag("CREDITS")+"Pay to %s FAILED, error: %s", group.supplierIP, err)
+			continue
+		}
+
+		m.billsMutex.Lock()
+		for _, containerID := range containerIDs {
+			if contBill, exist := m.bills[containerID]; exist {
+				contBill.paidUntil = now
+			}
+		}
+		m.billsMutex.Unlock()
+	}
+}
+
+// pay pays the supplier for the resource-time used by the containers between since and until, the supplier
+// countersigns the receipt. The countersigned receipt is posted into the accounts of the buyer and the supplier.
+func (m *Manager) pay(ctx context.Context, supplierIP string, userID string, containerIDs []string, cpus, memory int,
+	since, until time.Time) error {
+	receiptID, err := newReceiptID()
+	if err != nil {
+		return err
+	}
+	receipt := &types.CreditReceipt{
+		ID:           receiptID,
+		BuyerIP:      m.config.HostIP(),
+		SupplierIP:   supplierIP,
+		UserID:       userID,
+		ContainerIDs: containerIDs,
+		CPUs:         cpus,
+		Memory:       memory,
+		Credits:      m.price(cpus, memory, until.Sub(since)),
+		Since:        since,
+		Time:         until,
+		BuyerKey:     m.publicKey,
+	}
+	if receipt.BuyerSignature, err = signReceipt(m.key, receipt); err != nil {
+		return err
+	}
+
+	countersigned, err := m.remoteClient.ExchangeReceipt(ctx, m.localNode(), &types.Node{IP: supplierIP}, receipt)
+	if err != nil {
+		return err
+	}
+	if err := verifyCountersigned(receipt, countersigned); err != nil {
+		return err
+	}
+	if err := m.bindKey(supplierIP, countersigned.SupplierKey); err != nil {
+		return err
+	}
+	if err := m.apply(countersigned); err != nil {
+		return err
+	}
+
+	log.Debugf(util.LogTag("CREDITS")+"PAID %.2f credits to %s, Receipt: %s", receipt.Credits, supplierIP, receipt.ID)
+	m.post(countersigned)
+	return nil
+}
+
+// AcceptReceipt verifies that the receipt pays the resource-time used by the buyer's containers in this node and
+// countersigns it. The countersigned receipt is posted into the accounts of the buyer and the supplier.
+func (m *Manager) AcceptReceipt(_ context.Context, fromBuyer *types.Node, receipt *types.CreditReceipt) (*types.CreditReceipt, error) {
+	if !m.config.CreditsEnabled() {
+		return nil, errors.New("credits are not enabled")
+	}
+
+	if receipt.BuyerIP != fromBuyer.IP || receipt.SupplierIP != m.config.HostIP() {
+		return nil, errors.New("receipt is not between the buyer and this node")
+	}
+	if err := verifyReceipt(receipt, false); err != nil {
+		return nil, err
+	}
+	if err := m.verifyDelivered(receipt); err != nil {
+		return nil, err
+	}
+	if err := m.bindKey(fromBuyer.IP, receipt.BuyerKey); err != nil {
+		return nil, err
+	}
+
+	countersigned := *receipt
+	countersigned.SupplierKey = m.publicKey
+	signature, err := signReceipt(m.key, &countersigned)
+	if err != nil {
+		return nil, err
+	}
+	countersigned.SupplierSignature = signature
+
+	if err := m.apply(&countersigned); err != nil {
+		return nil, err
+	}
+	log.Debugf(util.LogTag("CREDITS")+"EARNED %.2f credits from %s, Receipt: %s", receipt.Credits, fromBuyer.IP,
+		receipt.ID)
+	if m.config.Simulation() {
+		m.post(&countersigned)
+	} else {
+		go m.post(&countersigned)
+	}
+	return &countersigned, nil
+}
+
+// verifyDelivered verifies that the buyer's containers ran in this node, with the resources paid, during the whole
+// period paid by the receipt.
+func (m *Manager) verifyDelivered(receipt *types.CreditReceipt) error {
+	now := m.now()
+	if !receipt.Time.After(receipt.Since) || receipt.Time.After(now.Add(clockSkew)) {
+		return errors.New("invalid receipt's period")
+	}
+
+	records, err := m.containers.UsageRecords(receipt.Since)
+	if err != nil {
+		return err
+	}
+	buyerRecords := make(map[string]types.UsageRecord)
+	for _, record := range records {
+		if record.BuyerIP == receipt.BuyerIP {
+			buyerRecords[record.ContainerID] = record
+		}
+	}
+
+	cpus, memory := 0, 0
+	for _, containerID := range receipt.ContainerIDs {
+		record, exist := buyerRecords[containerID]
+		end := record.End
+		if record.Running() {
+			end = now
+		}
+		if !exist || record.Start.After(receipt.Since.Add(clockSkew)) || end.Before(receipt.Time.Add(-clockSkew)) {
+			return fmt.Errorf("container %s of the buyer did not run during the receipt's period", containerID)
+		}
+		cpus += record.CPUs
+		memory += record.Memory
+	}
+	if cpus != receipt.CPUs || memory != receipt.Memory ||
+		!samePrice(receipt.Credits, m.price(cpus, memory, receipt.Time.Sub(receipt.Since))) {
+		return errors.New("receipt does not pay the containers' resource-time")
+	}
+	return nil
+}
+
+// PostReceipt applies a countersigned receipt, posted by its buyer or supplier, into the accounts kept in this node.
+// The poster's key is bound to it and the receipt is only applied when the keys of both are bound, i.e. when both
+// posted it (or previous receipts).
+func (m *Manager) PostReceipt(fromNode *types.Node, receipt *types.CreditReceipt) error {
+	if !m.config.CreditsEnabled() {
+		return errors.New("credits are not enabled")
+	}
+
+	var posterKey []byte
+	switch fromNode.IP {
+	case receipt.BuyerIP:
+		posterKey = receipt.BuyerKey
+	case receipt.SupplierIP:
+		posterKey = receipt.SupplierKey
+	default:
+		return errors.New("receipt must be posted by its buyer or supplier")
+	}
+	if err := verifyReceipt(receipt, true); err != nil {
+		return err
+	}
+	if err := m.bindKey(fromNode.IP, posterKey); err != nil {
+		return err
+	}
+
+	return m.apply(receipt)
+}
+
+// Balance returns the balance of a node's account, obtained from the nodes responsible for it.
+func (m *Manager) Balance(ctx context.Context, nodeIP string) (float64, error) {
+	for _, node := range m.accountNodes(ctx, nodeIP) {
+		if node.IP == m.config.HostIP() {
+			return m.LocalBalance(nodeIP), nil
+		}
+		if balance, err := m.remoteClient.GetBalance(ctx, m.localNode(), node, nodeIP); err == nil {
+			return balance, nil
+		}
+	}
+	return 0, errors.New("impossible reach the nodes responsible for the account")
+}
+
+// LocalBalance returns the balance of a node's account with the receipts known by this node.
+func (m *Manager) LocalBalance(nodeIP string) float64 {
+	m.accountsMutex.Lock()
+	defer m.accountsMutex.Unlock()
+
+	return m.config.CreditsInitialBalance() + m.balances[nodeIP]
+}
+
+// post sends the receipt to the nodes responsible for the buyer's and supplier's accounts.
+func (m *Manager) post(receipt *types.CreditReceipt) {
+	ctx := context.Background()
+	posted := map[string]bool{m.config.HostIP(): true}
+	for _, accountIP := range []string{receipt.BuyerIP, receipt.SupplierIP} {
+		for _, node := range m.accountNodes(ctx, accountIP) {
+			if posted[node.IP] {
+				continue
+			}
+			posted[node.IP] = true
+			if err := m.remoteClient.PostReceipt(ctx, m.localNode(), node, receipt); err != nil {
+				log.Debugf(util.LogTag("CREDITS")+"Post receipt to %s FAILED, error: %s", node.IP, err)
+			}
+		}
+	}
+}
+
+// bindKey binds a receipts' public key to the node that proved to own it, the first key bound to a node is kept.
+func (m *Manager) bindKey(nodeIP string, key []byte) error {
+	m.accountsMutex.Lock()
+	defer m.accountsMutex.Unlock()
+
+	if boundKey, bound := m.keys[nodeIP]; bound && !bytes.Equal(boundKey, key) {
+		return fmt.Errorf("receipt's key is not the key of %s", nodeIP)
+	} else if !bound {
+		m.keys[nodeIP] = key
+	}
+	return nil
+}
+
+// apply applies a countersigned receipt into the accounts, only once, and persists it. The receipt waits (it is not
+// applied) while the key of the buyer or the supplier is not bound yet.
+func (m *Manager) apply(receipt *types.CreditReceipt) error {
+	m.accountsMutex.Lock()
+	defer m.accountsMutex.Unlock()
+
+	for nodeIP, key := range map[string][]byte{receipt.BuyerIP: receipt.BuyerKey, receipt.SupplierIP: receipt.SupplierKey} {
+		if boundKey, bound := m.keys[nodeIP]; !bound {
+			log.Debugf(util.LogTag("CREDITS")+"Receipt %s waits for the key of %s", receipt.ID, nodeIP)
+			return nil
+		} else if !bytes.Equal(boundKey, key) {
+			return fmt.Errorf("receipt's key is not the key of %s", nodeIP)
+		}
+	}
+
+	if !m.applyReceipt(receipt) {
+		return nil
+	}
+	if err := m.persistReceipt(receipt); err != nil {
+		log.Errorf(util.LogTag("CREDITS")+"Persisting receipt %s error: %s", receipt.ID, err)
+	}
+	return nil
+}
+
+// applyReceipt transfers the receipt's credits from the buyer to the supplier, returns false if already applied.
+func (m *Manager) applyReceipt(receipt *types.CreditReceipt) bool {
+	if m.applied[receipt.ID] {
+		return false
+	}
+	m.applied[receipt.ID] = true
+	m.balances[receipt.BuyerIP] -= receipt.Credits
+	m.balances[receipt.SupplierIP] += receipt.Credits
+	return true
+}
+
+// persistReceipt appends a receipt to the receipts file.
+func (m *Manager) persistReceipt(receipt *types.CreditReceipt) error {
+	if m.config.CreditsReceiptsFile() == "" || m.config.Simulation() {
+		return nil
+	}
+
+	entry, err := json.Marshal(receipt)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(m.config.CreditsReceiptsFile(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(entry, '\n'))
+	return err
+}
+
+// loadReceipts applies the receipts persisted in the receipts file, restoring the accounts.
+func (m *Manager) loadReceipts() error {
+	if m.config.CreditsReceiptsFile() == "" {
+		return nil
+	}
+
+	file, err := os.Open(m.config.CreditsReceiptsFile())
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var receipt types.CreditReceipt
+		if err := json.Unmarshal(scanner.Bytes(), &receipt); err != nil {
+			continue // Ignore partially written receipts.
+		}
+		m.applyReceipt(&receipt)
+		for nodeIP, key := range map[string][]byte{receipt.BuyerIP: receipt.BuyerKey, receipt.SupplierIP: receipt.SupplierKey} {
+			if _, bound := m.keys[nodeIP]; !bound {
+				m.keys[nodeIP] = key
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// accountNodes returns the nodes (without repetitions) that keep a node's account.
+func (m *Manager) accountNodes(ctx context.Context, nodeIP string) []*types.Node {
+	return common.ResponsibleNodes(ctx, m.overlay, guid.NewGUIDHash("account:", nodeIP))
+}
+
+// price returns the credits charged for the resources used during the given period.
+func (m *Manager) price(cpus, memory int, period time.Duration) float64 {
+	return price(cpus, memory, m.config.CreditsCPUPrice(), m.config.CreditsMemoryPrice(), period)
+}
+
+// localNode returns the representation of the local node used in the remote messages.
+func (m *Manager) localNode() *types.Node {
+	return &types.Node{IP: m.config.HostIP()}
+}
+
+// ===============================================================================
+// =							SubComponent Interface                           =
+// ===============================================================================
+
+func (m *Manager) Start() {
+	m.Started(m.config.Simulation(), func() {
+		if !m.config.Simulation() {
+			go m.start()
+		}
+	})
+}
+
+func (m *Manager) Stop() {
+	m.Stopped(func() {
+		if !m.config.Simulation() {
+			m.quitChan <- true
+		}
+	})
+}
+
+func (m *Manager) IsWorking() bool {
+	return m.Working()
+}
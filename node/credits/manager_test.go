@@ -0,0 +1,152 @@
+package credits
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/configuration"
+	"github.com/strabox/caravela/overlay"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var creditsStartTest = time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// overlayStub keeps all the accounts in the same node.
+type overlayStub struct {
+	overlay.Overlay
+	accountsIP string
+}
+
+func (o *overlayStub) Lookup(context.Context, []byte) ([]*overlay.OverlayNode, error) {
+	return []*overlay.OverlayNode{overlay.NewOverlayNode(o.accountsIP, 8000, []byte{0})}, nil
+}
+
+// remoteClientStub delivers the credits messages directly to the managers of the other nodes.
+type remoteClientStub struct {
+	managers map[string]*Manager
+}
+
+func (r *remoteClientStub) ExchangeReceipt(ctx context.Context, fromBuyer, toSupplier *types.Node,
+	receipt *types.CreditReceipt) (*types.CreditReceipt, error) {
+	return r.managers[toSupplier.IP].AcceptReceipt(ctx, fromBuyer, receipt)
+}
+
+func (r *remoteClientStub) PostReceipt(_ context.Context, fromNode, toNode *types.Node, receipt *types.CreditReceipt) error {
+	return r.managers[toNode.IP].PostReceipt(fromNode, receipt)
+}
+
+func (r *remoteClientStub) GetBalance(_ context.Context, _, toNode *types.Node, nodeIP string) (float64, error) {
+	return r.managers[toNode.IP].LocalBalance(nodeIP), nil
+}
+
+// containersStub returns the usage records of the containers running in the supplier.
+type containersStub struct {
+	records []types.UsageRecord
+}
+
+func (c *containersStub) UsageRecords(time.Time) ([]types.UsageRecord, error) {
+	return c.records, nil
+}
+
+func TestManager_Admit(t *testing.T) {
+	config := configuration.Default("10.0.0.2")
+	config.Caravela.Simulation = true
+	config.Caravela.Credits.Enabled = true
+	config.Caravela.Credits.InitialBalance = 0
+	manager := NewManager(config, &overlayStub{accountsIP: "10.0.0.2"}, nil, nil)
+	manager.balances["10.0.0.1"] = -5
+	manager.balances["10.0.0.3"] = 5
+
+	assert.Error(t, manager.Admit(context.Background(), "10.0.0.1"), "Buyer with negative balance admitted")
+	assert.NoError(t, manager.Admit(context.Background(), "10.0.0.3"))
+
+	config.Caravela.Credits.NegativeBalancePolicy = "deprioritize"
+	config.Caravela.Credits.DeprioritizeDelay.Duration = time.Millisecond
+	assert.NoError(t, manager.Admit(context.Background(), "10.0.0.1"), "Deprioritized buyer should be admitted")
+
+	config.Caravela.Credits.DeprioritizeDelay.Duration = time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Error(t, manager.Admit(ctx, "10.0.0.1"), "Deprioritized request should stop when cancelled")
+}
+
+func TestManager_AcceptReceipt_ResourceTime(t *testing.T) {
+	remoteClient := &remoteClientStub{managers: make(map[string]*Manager)}
+	containers := &containersStub{records: []types.UsageRecord{{ContainerID: "c1", BuyerIP: "10.0.0.1", CPUs: 2,
+		Memory: 1024, Start: creditsStartTest}}}
+	for _, nodeIP := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"} {
+		config := configuration.Default(nodeIP)
+		config.Caravela.Simulation = true
+		config.Caravela.Credits.Enabled = true
+		remoteClient.managers[nodeIP] = NewManager(config, &overlayStub{accountsIP: "10.0.0.3"}, remoteClient,
+			containers)
+		remoteClient.managers[nodeIP].now = func() time.Time { return creditsStartTest.Add(time.Hour) }
+	}
+	buyer, supplier, accounts := remoteClient.managers["10.0.0.1"], remoteClient.managers["10.0.0.2"],
+		remoteClient.managers["10.0.0.3"]
+
+	// The buyer pays the hour that the container ran when it is stopped.
+	buyer.now = func() time.Time { return creditsStartTest }
+	buyer.Pay(context.Background(), "10.0.0.2", "alice", []types.ContainerStatus{{ContainerID: "c1",
+		ContainerConfig: types.ContainerConfig{Resources: types.Resources{CPUs: 2, Memory: 1024}}}})
+	buyer.now = func() time.Time { return creditsStartTest.Add(time.Hour) }
+	buyer.Settle(context.Background(), "c1")
+
+	assert.Equal(t, 97.0, buyer.LocalBalance("10.0.0.1"), "Buyer should pay 2 CPUs and 1GB for an hour")
+	assert.Equal(t, 103.0, supplier.LocalBalance("10.0.0.2"))
+	assert.Equal(t, 97.0, accounts.LocalBalance("10.0.0.1"), "Receipt not posted into the buyer's account")
+	assert.Equal(t, 103.0, accounts.LocalBalance("10.0.0.2"), "Receipt not posted into the supplier's account")
+
+	// The supplier refuses to be paid for resource-time it did not deliver.
+	buyer.now = func() time.Time { return creditsStartTest.Add(-time.Hour) }
+	buyer.Pay(context.Background(), "10.0.0.2", "alice", []types.ContainerStatus{{ContainerID: "c1",
+		ContainerConfig: types.ContainerConfig{Resources: types.Resources{CPUs: 2, Memory: 1024}}}})
+	buyer.now = func() time.Time { return creditsStartTest.Add(time.Hour) }
+	buyer.Settle(context.Background(), "c1")
+
+	assert.Equal(t, 103.0, supplier.LocalBalance("10.0.0.2"), "Receipt for resource-time not delivered accepted")
+}
+
+func TestManager_PostReceipt(t *testing.T) {
+	config := configuration.Default("10.0.0.3")
+	config.Caravela.Simulation = true
+	config.Caravela.Credits.Enabled = true
+	manager := NewManager(config, &overlayStub{accountsIP: "10.0.0.3"}, nil, nil)
+	buyerKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	supplierKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	buyerKeyDER, _ := x509.MarshalPKIXPublicKey(&buyerKey.PublicKey)
+	supplierKeyDER, _ := x509.MarshalPKIXPublicKey(&supplierKey.PublicKey)
+	receipt := &types.CreditReceipt{ID: "receipt", BuyerIP: "10.0.0.1", SupplierIP: "10.0.0.2",
+		ContainerIDs: []string{"c1"}, CPUs: 2, Memory: 1024, Credits: 3, Since: creditsStartTest,
+		Time: creditsStartTest.Add(time.Hour), BuyerKey: buyerKeyDER}
+	receipt.BuyerSignature, _ = signReceipt(buyerKey, receipt)
+	receipt.SupplierKey = supplierKeyDER
+	receipt.SupplierSignature, _ = signReceipt(supplierKey, receipt)
+
+	assert.Error(t, manager.PostReceipt(&types.Node{IP: "10.0.0.9"}, receipt), "Receipt posted by other node")
+
+	assert.NoError(t, manager.PostReceipt(&types.Node{IP: "10.0.0.2"}, receipt))
+	assert.Equal(t, 100.0, manager.LocalBalance("10.0.0.2"), "Receipt applied without the buyer's key")
+
+	assert.NoError(t, manager.PostReceipt(&types.Node{IP: "10.0.0.1"}, receipt))
+	assert.Equal(t, 97.0, manager.LocalBalance("10.0.0.1"))
+	assert.Equal(t, 103.0, manager.LocalBalance("10.0.0.2"))
+
+	// A supplier can't forge the receipts of the buyer with another key.
+	forgedKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	forgedKeyDER, _ := x509.MarshalPKIXPublicKey(&forgedKey.PublicKey)
+	forged := &types.CreditReceipt{ID: "forged", BuyerIP: "10.0.0.1", SupplierIP: "10.0.0.2",
+		ContainerIDs: []string{"c1"}, CPUs: 2, Memory: 1024, Credits: 50, Since: creditsStartTest,
+		Time: creditsStartTest.Add(time.Hour), BuyerKey: forgedKeyDER}
+	forged.BuyerSignature, _ = signReceipt(forgedKey, forged)
+	forged.SupplierKey = supplierKeyDER
+	forged.SupplierSignature, _ = signReceipt(supplierKey, forged)
+
+	assert.Error(t, manager.PostReceipt(&types.Node{IP: "10.0.0.2"}, forged), "Forged receipt accepted")
+	assert.Equal(t, 97.0, manager.LocalBalance("10.0.0.1"), "Forged receipt applied")
+}
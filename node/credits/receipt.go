@@ -0,0 +1,115 @@
+package credits
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/strabox/caravela/api/types"
+	"math"
+	"math/big"
+	"time"
+)
+
+// price returns the credits of the resources used during the given period.
+func price(cpus, memory int, cpuPrice, memoryPrice float64, period time.Duration) float64 {
+	return (float64(cpus)*cpuPrice + float64(memory)/1024*memoryPrice) * period.Hours()
+}
+
+// samePrice returns true if two prices are equal, ignoring the rounding errors of the JSON encoding.
+func samePrice(price1, price2 float64) bool {
+	return math.Abs(price1-price2) < 1e-6
+}
+
+// newReceiptID creates a random identifier for a receipt.
+func newReceiptID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// receiptDigest returns the digest of the receipt's content signed by both nodes.
+func receiptDigest(receipt *types.CreditReceipt) ([]byte, error) {
+	content := *receipt
+	content.BuyerSignature = nil
+	content.SupplierKey = nil
+	content.SupplierSignature = nil
+
+	contentJSON, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256(contentJSON)
+	return digest[:], nil
+}
+
+// signReceipt signs the receipt's content with the given key.
+func signReceipt(key *ecdsa.PrivateKey, receipt *types.CreditReceipt) ([]byte, error) {
+	digest, err := receiptDigest(receipt)
+	if err != nil {
+		return nil, err
+	}
+	return key.Sign(rand.Reader, digest, crypto.SHA256)
+}
+
+// verifyReceipt verifies the signatures of the buyer and, if countersigned, the supplier.
+func verifyReceipt(receipt *types.CreditReceipt, countersigned bool) error {
+	digest, err := receiptDigest(receipt)
+	if err != nil {
+		return err
+	}
+
+	if !verifySignature(receipt.BuyerKey, digest, receipt.BuyerSignature) {
+		return errors.New("invalid buyer's signature")
+	}
+	if countersigned && !verifySignature(receipt.SupplierKey, digest, receipt.SupplierSignature) {
+		return errors.New("invalid supplier's signature")
+	}
+	return nil
+}
+
+// verifyCountersigned verifies that the countersigned receipt is the receipt sent to the supplier and that the
+// supplier's signature is valid.
+func verifyCountersigned(receipt, countersigned *types.CreditReceipt) error {
+	digest, err := receiptDigest(receipt)
+	if err != nil {
+		return err
+	}
+	countersignedDigest, err := receiptDigest(countersigned)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(digest, countersignedDigest) {
+		return errors.New("countersigned receipt is not the receipt sent")
+	}
+	return verifyReceipt(countersigned, true)
+}
+
+// verifySignature verifies an ECDSA (ASN.1) signature of the digest with a PKIX encoded public key.
+func verifySignature(publicKeyDER []byte, digest []byte, signature []byte) bool {
+	publicKey, err := x509.ParsePKIXPublicKey(publicKeyDER)
+	if err != nil {
+		return false
+	}
+	ecdsaKey, ok := publicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return false
+	}
+
+	var ecdsaSignature struct {
+		R, S *big.Int
+	}
+	if _, err := asn1.Unmarshal(signature, &ecdsaSignature); err != nil {
+		return false
+	}
+	return ecdsa.Verify(ecdsaKey, digest, ecdsaSignature.R, ecdsaSignature.S)
+}
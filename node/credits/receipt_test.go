@@ -0,0 +1,46 @@
+package credits
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"github.com/strabox/caravela/api/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestReceipt_Price(t *testing.T) {
+	assert.True(t, samePrice(3, price(2, 1024, 1, 1, time.Hour)), "2 CPUs and 1GB for an hour should cost 3")
+	assert.True(t, samePrice(1.5, price(2, 1024, 1, 1, 30*time.Minute)), "Price should be proportional to the period")
+}
+
+func TestReceipt_VerifyReceipt_Valid(t *testing.T) {
+	buyerKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	supplierKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	buyerKeyDER, _ := x509.MarshalPKIXPublicKey(&buyerKey.PublicKey)
+	supplierKeyDER, _ := x509.MarshalPKIXPublicKey(&supplierKey.PublicKey)
+	receipt := &types.CreditReceipt{ID: "receipt", BuyerIP: "10.0.0.1", SupplierIP: "10.0.0.2",
+		ContainerIDs: []string{"container"}, CPUs: 2, Memory: 1024, Credits: 3, Time: time.Unix(0, 0).UTC(),
+		BuyerKey: buyerKeyDER}
+	receipt.BuyerSignature, _ = signReceipt(buyerKey, receipt)
+	receipt.SupplierKey = supplierKeyDER
+	receipt.SupplierSignature, _ = signReceipt(supplierKey, receipt)
+
+	assert.Nil(t, verifyReceipt(receipt, false), "Buyer's signature should be valid")
+	assert.Nil(t, verifyReceipt(receipt, true), "Supplier's countersignature should be valid")
+}
+
+func TestReceipt_VerifyReceipt_Tampered(t *testing.T) {
+	buyerKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	buyerKeyDER, _ := x509.MarshalPKIXPublicKey(&buyerKey.PublicKey)
+	receipt := &types.CreditReceipt{ID: "receipt", BuyerIP: "10.0.0.1", SupplierIP: "10.0.0.2",
+		ContainerIDs: []string{"container"}, CPUs: 2, Memory: 1024, Credits: 3, Time: time.Unix(0, 0).UTC(),
+		BuyerKey: buyerKeyDER}
+	receipt.BuyerSignature, _ = signReceipt(buyerKey, receipt)
+
+	receipt.Credits = 1
+
+	assert.NotNil(t, verifyReceipt(receipt, false), "Tampered receipt should be rejected")
+}
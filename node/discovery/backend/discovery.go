@@ -6,6 +6,7 @@ import (
 	"github.com/strabox/caravela/node/common"
 	"github.com/strabox/caravela/node/common/guid"
 	"github.com/strabox/caravela/node/common/resources"
+	"github.com/strabox/caravela/overlay"
 )
 
 // Discovery is the interface for the resource discovery component of the system.
@@ -23,12 +24,24 @@ type Discovery interface {
 	// =========================== Internal Services (Mandatory to Implement) =====================
 	//
 	AddTrader(traderGUID guid.GUID)
+	// Removes a local "virtual" trader that is leaving the overlay, handing over its offers into the successor.
+	RemoveTrader(traderGUID guid.GUID, successor *types.Node)
+	// Reacts to changes in the overlay's neighborhood of the local "virtual" traders (e.g. a successor failed).
+	MembershipChanged(event *overlay.MembershipEvent)
 	//
 	FindOffers(ctx context.Context, resources resources.Resources) []types.AvailableOffer
+	// Statistics (e.g. latency) of the offers' searches made by the node.
+	SearchStats() types.SearchStats
 	//
 	ObtainResources(offerID int64, resourcesNecessary resources.Resources, numContainersToRun int) bool
 	//
 	ReturnResources(resources resources.Resources, numContainerStopped int)
+	// Marks as used the resources of containers that kept running while the node restarted.
+	RecoverResources(resources resources.Resources, numContainersRunning int)
+	// Stops offering the node's resources and withdraws its offers from the system (maintenance).
+	Drain()
+	// Offers the node's resources again after a drain.
+	Undrain()
 
 	// ================================== External/Remote Services ================================
 	//
@@ -43,6 +56,12 @@ type Discovery interface {
 	GetOffers(ctx context.Context, fromNode, toTrader *types.Node, relay bool) []types.AvailableOffer
 	//
 	AdvertiseNeighborOffers(fromTrader, toNeighborTrader, traderOffering *types.Node)
+	// Stores the replica of the offers managed by a predecessor trader.
+	ReplicateOffers(fromTrader, toTrader *types.Node, offers []types.ReplicatedOffer)
+	// Receives the offers of a predecessor trader that left the overlay.
+	HandOverOffers(fromTrader, toTrader *types.Node, offers []types.ReplicatedOffer)
+	// Changes the trader responsible for a local offer, as requested by the leaving responsible trader.
+	ChangeOfferTrader(fromTrader, newTrader *types.Node, offer *types.Offer)
 
 	// ========================== External/Remote Services (Only Simulation) =======================
 	//
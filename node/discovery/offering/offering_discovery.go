@@ -24,6 +24,7 @@ import (
 type Discovery struct {
 	common.NodeComponent // Base component
 
+	node    common.Node                  // Local node.
 	config  *configuration.Configuration // System's configurations.
 	overlay overlay.Overlay              // Overlay component.
 	client  external.Caravela            // Remote caravela's client.
@@ -38,6 +39,7 @@ func NewOfferingDiscovery(node common.Node, config *configuration.Configuration,
 	client external.Caravela, resourcesMap *resources.Mapping, maxResources resources.Resources) (backend.Discovery, error) {
 
 	return &Discovery{
+		node:    node,
 		config:  config,
 		overlay: overlay,
 		client:  client,
@@ -50,6 +52,9 @@ func NewOfferingDiscovery(node common.Node, config *configuration.Configuration,
 }
 
 func (d *Discovery) GUID() string {
+	if d.nodeGUID == nil { // The node did not join the overlay yet.
+		return ""
+	}
 	return d.nodeGUID.String()
 }
 
@@ -59,7 +64,8 @@ func (d *Discovery) GUID() string {
 func (d *Discovery) AddTrader(traderGUID guid.GUID) {
 	d.nodeGUID = &traderGUID
 
-	newTrader := trader.NewTrader(d.config, d.overlay, d.client, traderGUID, d.resourcesMap)
+	newTrader := trader.NewTrader(d.config, d.overlay, d.client, traderGUID, d.resourcesMap,
+		d.node.SupplierReputations())
 	d.traders.Store(traderGUID.String(), newTrader)
 
 	newTrader.Start() // Start the node's trader module.
@@ -67,10 +73,62 @@ func (d *Discovery) AddTrader(traderGUID guid.GUID) {
 	log.Debugf(util.LogTag("DISCOVERY")+"NEW TRADER GUID: %s, Res: %s", traderGUID.Short(), newTraderResources.String())
 }
 
+// Removes a local "virtual" trader that is leaving the overlay, handing over its offers into the successor.
+func (d *Discovery) RemoveTrader(traderGUID guid.GUID, successor *types.Node) {
+	t, exist := d.traders.Load(traderGUID.String())
+	leavingTrader, ok := t.(*trader.Trader)
+	if !exist || !ok {
+		return
+	}
+
+	if successor != nil {
+		leavingTrader.HandOverOffers(successor)
+	}
+	d.traders.Delete(traderGUID.String())
+	log.Debugf(util.LogTag("DISCOVERY")+"REMOVED TRADER GUID: %s", traderGUID.Short())
+}
+
+// Reacts to changes in the neighborhood of the local traders: the nearby traders that are gone are forgotten and the
+// offers managed by a failed predecessor are taken over. The suppliers of the taken over offers learn about their
+// new trader when it refreshes them.
+func (d *Discovery) MembershipChanged(event *overlay.MembershipEvent) {
+	switch event.Type {
+	case overlay.PredecessorChanged:
+		if event.PreviousNode == nil {
+			return
+		}
+		t, exist := d.traders.Load(guid.NewGUIDBytes(event.LocalNodeID).String())
+		localTrader, ok := t.(*trader.Trader)
+		if exist && ok {
+			var predecessorGUID *guid.GUID = nil
+			if event.Node != nil {
+				predecessorGUID = guid.NewGUIDBytes(event.Node.GUID())
+			}
+			localTrader.PredecessorChanged(predecessorGUID, guid.NewGUIDBytes(event.PreviousNode.GUID()))
+		}
+	case overlay.SuccessorFailed:
+		if event.Node == nil {
+			return
+		}
+		failedGUID := guid.NewGUIDBytes(event.Node.GUID())
+		d.traders.Range(func(_, value interface{}) bool {
+			currentTrader, ok := value.(*trader.Trader)
+			if ok {
+				currentTrader.ForgetNearbyTrader(*failedGUID)
+			}
+			return true
+		})
+	}
+}
+
 func (d *Discovery) FindOffers(ctx context.Context, resources resources.Resources) []types.AvailableOffer {
 	return d.supplier.FindOffers(ctx, resources)
 }
 
+func (d *Discovery) SearchStats() types.SearchStats {
+	return d.supplier.SearchStats()
+}
+
 func (d *Discovery) ObtainResources(offerID int64, resourcesNecessary resources.Resources, numContainersToRun int) bool {
 	return d.supplier.ObtainResources(offerID, resourcesNecessary, numContainersToRun)
 }
@@ -79,6 +137,18 @@ func (d *Discovery) ReturnResources(resources resources.Resources, numContainers
 	d.supplier.ReturnResources(resources, numContainersStopped)
 }
 
+func (d *Discovery) RecoverResources(resources resources.Resources, numContainersRunning int) {
+	d.supplier.RecoverResources(resources, numContainersRunning)
+}
+
+func (d *Discovery) Drain() {
+	d.supplier.Drain()
+}
+
+func (d *Discovery) Undrain() {
+	d.supplier.Undrain()
+}
+
 // ======================= External Services (Consumed by other Nodes) ==============================
 
 func (d *Discovery) CreateOffer(fromSupp *types.Node, toTrader *types.Node, offer *types.Offer) {
@@ -127,6 +197,26 @@ func (d *Discovery) AdvertiseNeighborOffers(fromTrader, toNeighborTrader, trader
 	}
 }
 
+func (d *Discovery) ReplicateOffers(fromTrader, toTrader *types.Node, offers []types.ReplicatedOffer) {
+	t, exist := d.traders.Load(toTrader.GUID)
+	targetTrader, ok := t.(*trader.Trader)
+	if exist && ok {
+		targetTrader.ReplicateOffers(fromTrader, offers)
+	}
+}
+
+func (d *Discovery) HandOverOffers(fromTrader, toTrader *types.Node, offers []types.ReplicatedOffer) {
+	t, exist := d.traders.Load(toTrader.GUID)
+	targetTrader, ok := t.(*trader.Trader)
+	if exist && ok {
+		targetTrader.AcceptOffers(fromTrader, offers)
+	}
+}
+
+func (d *Discovery) ChangeOfferTrader(fromTrader, newTrader *types.Node, offer *types.Offer) {
+	d.supplier.ChangeOfferTrader(fromTrader, newTrader, offer)
+}
+
 // ======================= External Services (Consumed during simulation ONLY) =========================
 
 // Simulation
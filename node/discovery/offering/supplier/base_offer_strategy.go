@@ -33,7 +33,8 @@ type baseOfferStrategy struct {
 	configs          *configuration.Configuration
 }
 
-func (b *baseOfferStrategy) createAnOffer(ctx context.Context, newOfferID int64, targetResources, realAvailableRes, usedResources resources.Resources) (*supplierOffer, error) {
+func (b *baseOfferStrategy) createAnOffer(ctx context.Context, newOfferID int64, targetResources, realAvailableRes, usedResources resources.Resources,
+	imagesFilter []byte) (*supplierOffer, error) {
 	var err error
 	var overlayNodes []*overlay.OverlayNode = nil
 
@@ -75,8 +76,9 @@ func (b *baseOfferStrategy) createAnOffer(ctx context.Context, newOfferID int64,
 		&types.Node{IP: b.configs.HostIP()},
 		&types.Node{IP: chosenNode.IP(), GUID: chosenNodeGUID.String()},
 		&types.Offer{
-			ID:     newOfferID,
-			Amount: 1,
+			ID:           newOfferID,
+			Amount:       1,
+			ImagesFilter: imagesFilter,
 			FreeResources: types.Resources{
 				CPUClass: types.CPUClass(realAvailableRes.CPUClass()),
 				CPUs:     realAvailableRes.CPUs(),
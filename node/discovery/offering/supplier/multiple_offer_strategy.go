@@ -5,7 +5,6 @@ import (
 	"github.com/strabox/caravela/api/types"
 	"github.com/strabox/caravela/configuration"
 	"github.com/strabox/caravela/node/common"
-	"github.com/strabox/caravela/node/common/guid"
 	"github.com/strabox/caravela/node/common/resources"
 	"github.com/strabox/caravela/node/external"
 	"github.com/strabox/caravela/overlay"
@@ -35,38 +34,11 @@ func (m *multipleOfferStrategy) Init(supp *Supplier, resourcesMapping *resources
 }
 
 func (m *multipleOfferStrategy) FindOffers(ctx context.Context, targetResources resources.Resources) []types.AvailableOffer {
-	availableOffers := make([]types.AvailableOffer, 0)
-
-	for r := 0; r < m.configs.MaxPartitionsSearch(); r++ {
-		destinationGUID, err := m.resourcesMapping.RandGUIDFittestSearch(targetResources)
-		if err != nil { // System can't handle that many resources
-			return availableOffers
-		}
-
-		overlayNodes, _ := m.overlay.Lookup(ctx, destinationGUID.Bytes())
-		overlayNodes = m.removeNonTargetNodes(overlayNodes, *destinationGUID)
-
-		for _, node := range overlayNodes {
-			offers, err := m.remoteClient.GetOffers(
-				ctx,
-				&types.Node{}, //TODO: Remove this crap!
-				&types.Node{IP: node.IP(), GUID: guid.NewGUIDBytes(node.GUID()).String()},
-				true)
-			if err == nil && len(offers) != 0 {
-				availableOffers = append(availableOffers, offers...)
-				break
-			}
-		}
-
-		if len(availableOffers) > 0 {
-			return availableOffers
-		}
-	}
-
-	return availableOffers
+	return m.searchOffers(ctx, targetResources)
 }
 
 func (m *multipleOfferStrategy) UpdateOffers(ctx context.Context, availableResources, usedResources resources.Resources) {
+	imagesFilter := m.node.ImagesFilter() // Computed once for all the offers' messages of this update.
 	lowerPartitions, _ := m.resourcesMapping.LowerPartitionsOffer(availableResources)
 	offersToRemove := make([]supplierOffer, 0)
 
@@ -84,7 +56,8 @@ OfferLoop:
 	}
 
 	for _, resourcePartitionTarget := range lowerPartitions {
-		offer, err := m.createAnOffer(ctx, int64(m.localSupplier.newOfferID()), resourcePartitionTarget, availableResources, usedResources)
+		offer, err := m.createAnOffer(ctx, int64(m.localSupplier.newOfferID()), resourcePartitionTarget, availableResources,
+			usedResources, imagesFilter)
 		if err == nil {
 			m.localSupplier.addOffer(offer)
 		}
@@ -120,6 +93,7 @@ OfferLoop:
 							ID:                int64(suppOffer.ID()),
 							Amount:            1,
 							ContainersRunning: m.localSupplier.numContainersRunning(),
+							ImagesFilter:      imagesFilter,
 							FreeResources: types.Resources{
 								CPUClass: types.CPUClass(availableResources.CPUClass()),
 								CPUs:     availableResources.CPUs(),
@@ -0,0 +1,143 @@
+package supplier
+
+import (
+	"context"
+	log "github.com/Sirupsen/logrus"
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/node/common/guid"
+	"github.com/strabox/caravela/node/common/resources"
+	"github.com/strabox/caravela/overlay"
+	"github.com/strabox/caravela/util"
+	"time"
+)
+
+// searchOffers searches for offers in several random partitions (that fit the target resources) at the same time.
+// The search stops when enough offers were collected, when the partitions to search are exhausted or when its
+// deadline expires, cancelling all the requests that are still outstanding.
+func (b *baseOfferStrategy) searchOffers(ctx context.Context, targetResources resources.Resources) []types.AvailableOffer {
+	searchStart := time.Now()
+	searchCtx, cancel := context.WithTimeout(ctx, b.configs.SearchTimeout())
+	defer cancel()
+
+	partitionsOffers := make(chan []types.AvailableOffer, b.configs.MaxPartitionsSearch())
+	searchPartition := func() bool {
+		destinationGUID, err := b.resourcesMapping.RandGUIDFittestSearch(targetResources)
+		if err != nil { // System can't handle that many resources
+			return false
+		}
+
+		search := func() {
+			overlayNodes, _ := b.overlay.Lookup(searchCtx, destinationGUID.Bytes())
+			overlayNodes = b.removeNonTargetNodes(overlayNodes, *destinationGUID)
+			partitionsOffers <- b.searchPartition(searchCtx, overlayNodes) // Never blocks, one slot per partition
+		}
+		if b.configs.Simulation() { // Partitions searched one at a time (deterministic simulation)
+			search()
+		} else {
+			go search()
+		}
+		return true
+	}
+
+	availableOffers := make([]types.AvailableOffer, 0)
+	partitionsSearched, partitionsRunning := 0, 0
+	for partitionsRunning < b.searchParallelism() && partitionsSearched < b.configs.MaxPartitionsSearch() {
+		if !searchPartition() {
+			break
+		}
+		partitionsSearched++
+		partitionsRunning++
+	}
+
+SearchLoop:
+	for partitionsRunning > 0 {
+		select {
+		case offers := <-partitionsOffers:
+			partitionsRunning--
+			availableOffers = append(availableOffers, offers...)
+			if len(availableOffers) >= b.configs.SearchMinOffers() {
+				break SearchLoop
+			}
+			if partitionsSearched < b.configs.MaxPartitionsSearch() && searchPartition() {
+				partitionsSearched++
+				partitionsRunning++
+			}
+		case <-searchCtx.Done():
+			break SearchLoop
+		}
+	}
+
+	log.Debugf(util.LogTag("SUPPLIER")+"Offers SEARCH Resources: %s, Offers: %d, Partitions: %d, Latency: %s",
+		targetResources.String(), len(availableOffers), partitionsSearched, time.Since(searchStart).String())
+	return availableOffers
+}
+
+// searchPartition asks the traders of a partition for offers returning the offers of the first one that has them.
+// The traders are asked one at a time but if a trader does not answer within the hedge delay the next one
+// is asked too (hedged requests), so a slow trader does not stall the partition's search.
+func (b *baseOfferStrategy) searchPartition(ctx context.Context, overlayNodes []*overlay.OverlayNode) []types.AvailableOffer {
+	partitionCtx, cancel := context.WithCancel(ctx)
+	defer cancel() // Cancels the requests that are still outstanding
+
+	tradersOffers := make(chan []types.AvailableOffer, len(overlayNodes))
+	nextTrader, tradersAsked := 0, 0
+	askNextTrader := func() {
+		node := overlayNodes[nextTrader]
+		nextTrader++
+		tradersAsked++
+		getOffers := func() {
+			offers, err := b.remoteClient.GetOffers(
+				partitionCtx,
+				&types.Node{}, //TODO: Remove this crap!
+				&types.Node{IP: node.IP(), GUID: guid.NewGUIDBytes(node.GUID()).String()},
+				true)
+			if err != nil {
+				offers = nil
+			}
+			tradersOffers <- offers // Never blocks, one slot per trader
+		}
+		if b.configs.Simulation() { // The answer is already there when waiting for it, so it is never hedged
+			getOffers()
+		} else {
+			go getOffers()
+		}
+	}
+
+	if len(overlayNodes) == 0 {
+		return nil
+	}
+	askNextTrader()
+
+	for tradersAsked > 0 {
+		hedgeTimer := time.NewTimer(b.configs.SearchHedgeDelay())
+		select {
+		case offers := <-tradersOffers:
+			tradersAsked--
+			if len(offers) != 0 {
+				hedgeTimer.Stop()
+				return offers
+			}
+			if nextTrader < len(overlayNodes) { // Trader failed or has no offers, ask the next one right away
+				askNextTrader()
+			}
+		case <-hedgeTimer.C:
+			if nextTrader < len(overlayNodes) {
+				askNextTrader()
+			}
+		case <-ctx.Done():
+			hedgeTimer.Stop()
+			return nil
+		}
+		hedgeTimer.Stop()
+	}
+	return nil
+}
+
+// searchParallelism returns the number of partitions searched at the same time, in simulation the partitions are
+// searched one at a time in order to stop as soon as enough offers are found.
+func (b *baseOfferStrategy) searchParallelism() int {
+	if b.configs.Simulation() {
+		return 1
+	}
+	return b.configs.SearchParallelism()
+}
@@ -0,0 +1,174 @@
+package supplier
+
+import (
+	"context"
+	"fmt"
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/configuration"
+	"github.com/strabox/caravela/node/common/guid"
+	"github.com/strabox/caravela/node/common/resources"
+	"github.com/strabox/caravela/node/external"
+	"github.com/strabox/caravela/overlay"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+// overlayStub returns 2 traders of the partition for each lookup, the traders of the n-th lookup are
+// 10.0.n.1 and 10.0.n.2.
+type overlayStub struct {
+	overlay.Overlay
+	lookups int
+	mutex   sync.Mutex
+}
+
+func (o *overlayStub) Lookup(_ context.Context, key []byte) ([]*overlay.OverlayNode, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.lookups++
+	return []*overlay.OverlayNode{
+		overlay.NewOverlayNode(fmt.Sprintf("10.0.%d.1", o.lookups), 8000, key),
+		overlay.NewOverlayNode(fmt.Sprintf("10.0.%d.2", o.lookups), 8000, key),
+	}, nil
+}
+
+// remoteClientStub answers with the offers of each trader (by IP), the traders without offers listed never answer
+// and report when their request is cancelled.
+type remoteClientStub struct {
+	external.Caravela
+	offers    map[string][]types.AvailableOffer
+	asked     []string
+	cancelled chan string
+	mutex     sync.Mutex
+}
+
+func (r *remoteClientStub) GetOffers(ctx context.Context, _, toTrader *types.Node,
+	_ bool) ([]types.AvailableOffer, error) {
+	r.mutex.Lock()
+	r.asked = append(r.asked, toTrader.IP)
+	offers, answers := r.offers[toTrader.IP]
+	r.mutex.Unlock()
+	if answers {
+		return offers, nil
+	}
+	<-ctx.Done()
+	r.cancelled <- toTrader.IP
+	return nil, ctx.Err()
+}
+
+func (r *remoteClientStub) tradersAsked() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]string(nil), r.asked...)
+}
+
+func TestBaseOfferStrategy_SearchPartition_Hedged(t *testing.T) {
+	config := configuration.Default("10.0.0.1")
+	config.Caravela.DiscoveryBackend.OfferingChordBackend.SearchHedgeDelay.Duration = 10 * time.Millisecond
+	guid.Init(config.ChordHashSizeBits(), int64(config.GUIDEstimatedNetworkSize()), int64(config.GUIDScaleFactor()))
+	remoteClient := &remoteClientStub{cancelled: make(chan string, 10), offers: map[string][]types.AvailableOffer{
+		"10.0.1.2": {{SupplierIP: "10.0.0.9", ID: 1, Amount: 1}}}}
+	strategy := &baseOfferStrategy{configs: config, remoteClient: remoteClient}
+	overlayNodes, _ := (&overlayStub{}).Lookup(context.Background(), guid.NewGUIDRandom().Bytes())
+
+	offers := strategy.searchPartition(context.Background(), overlayNodes)
+
+	assert.Len(t, offers, 1, "Offers of the next trader not returned")
+	assert.Equal(t, []string{"10.0.1.1", "10.0.1.2"}, remoteClient.tradersAsked(),
+		"Next trader not asked when the first one is slow")
+	select {
+	case cancelledIP := <-remoteClient.cancelled:
+		assert.Equal(t, "10.0.1.1", cancelledIP)
+	case <-time.After(time.Second):
+		t.Fatal("Request to the slow trader not cancelled")
+	}
+}
+
+func TestBaseOfferStrategy_SearchPartition_NoOffers(t *testing.T) {
+	config := configuration.Default("10.0.0.1")
+	config.Caravela.DiscoveryBackend.OfferingChordBackend.SearchHedgeDelay.Duration = time.Hour
+	guid.Init(config.ChordHashSizeBits(), int64(config.GUIDEstimatedNetworkSize()), int64(config.GUIDScaleFactor()))
+	remoteClient := &remoteClientStub{cancelled: make(chan string, 10), offers: map[string][]types.AvailableOffer{
+		"10.0.1.1": {}, "10.0.1.2": {{SupplierIP: "10.0.0.9", ID: 1, Amount: 1}}}}
+	strategy := &baseOfferStrategy{configs: config, remoteClient: remoteClient}
+	overlayNodes, _ := (&overlayStub{}).Lookup(context.Background(), guid.NewGUIDRandom().Bytes())
+
+	offers := strategy.searchPartition(context.Background(), overlayNodes)
+
+	assert.Len(t, offers, 1, "Next trader not asked right away when a trader has no offers")
+}
+
+func TestBaseOfferStrategy_SearchOffers_Parallel(t *testing.T) {
+	config := configuration.Default("10.0.0.1")
+	config.Caravela.DiscoveryBackend.OfferingChordBackend.SearchParallelism = 2
+	config.Caravela.DiscoveryBackend.OfferingChordBackend.SearchMinOffers = 1
+	config.Caravela.DiscoveryBackend.OfferingChordBackend.SearchHedgeDelay.Duration = time.Hour
+	guid.Init(config.ChordHashSizeBits(), int64(config.GUIDEstimatedNetworkSize()), int64(config.GUIDScaleFactor()))
+	remoteClient := &remoteClientStub{cancelled: make(chan string, 10), offers: map[string][]types.AvailableOffer{
+		"10.0.2.1": {{SupplierIP: "10.0.0.9", ID: 1, Amount: 1}}}}
+	strategy := &baseOfferStrategy{configs: config, remoteClient: remoteClient, overlay: &overlayStub{},
+		resourcesMapping: resources.NewResourcesMap(resources.ObtainConfiguredPartitions(config.ResourcesPartitions()), false)}
+
+	offers := strategy.searchOffers(context.Background(), *resources.NewResourcesCPUClass(0, 1, 512))
+
+	// The slow partition does not stall the search, its request is cancelled when enough offers are found.
+	assert.Len(t, offers, 1, "Offers of the other partition searched at the same time not returned")
+	select {
+	case cancelledIP := <-remoteClient.cancelled:
+		assert.Equal(t, "10.0.1.1", cancelledIP)
+	case <-time.After(time.Second):
+		t.Fatal("Request to the slow partition not cancelled")
+	}
+}
+
+func TestBaseOfferStrategy_SearchOffers_Timeout(t *testing.T) {
+	config := configuration.Default("10.0.0.1")
+	config.Caravela.DiscoveryBackend.OfferingChordBackend.SearchParallelism = 2
+	config.Caravela.DiscoveryBackend.OfferingChordBackend.SearchTimeout.Duration = 20 * time.Millisecond
+	config.Caravela.DiscoveryBackend.OfferingChordBackend.SearchHedgeDelay.Duration = time.Hour
+	guid.Init(config.ChordHashSizeBits(), int64(config.GUIDEstimatedNetworkSize()), int64(config.GUIDScaleFactor()))
+	remoteClient := &remoteClientStub{cancelled: make(chan string, 10)}
+	strategy := &baseOfferStrategy{configs: config, remoteClient: remoteClient, overlay: &overlayStub{},
+		resourcesMapping: resources.NewResourcesMap(resources.ObtainConfiguredPartitions(config.ResourcesPartitions()), false)}
+
+	offers := strategy.searchOffers(context.Background(), *resources.NewResourcesCPUClass(0, 1, 512))
+
+	assert.Empty(t, offers)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-remoteClient.cancelled:
+		case <-time.After(time.Second):
+			t.Fatal("Outstanding requests not cancelled when the search deadline expired")
+		}
+	}
+}
+
+func TestBaseOfferStrategy_SearchOffers_Simulation(t *testing.T) {
+	config := configuration.Default("10.0.0.1")
+	config.Caravela.Simulation = true
+	guid.Init(config.ChordHashSizeBits(), int64(config.GUIDEstimatedNetworkSize()), int64(config.GUIDScaleFactor()))
+	remoteClient := &remoteClientStub{cancelled: make(chan string, 10), offers: map[string][]types.AvailableOffer{
+		"10.0.1.1": {}, "10.0.1.2": {}, "10.0.2.1": {{SupplierIP: "10.0.0.9", ID: 1, Amount: 1}}}}
+	strategy := &baseOfferStrategy{configs: config, remoteClient: remoteClient, overlay: &overlayStub{},
+		resourcesMapping: resources.NewResourcesMap(resources.ObtainConfiguredPartitions(config.ResourcesPartitions()), false)}
+
+	offers := strategy.searchOffers(context.Background(), *resources.NewResourcesCPUClass(0, 1, 512))
+
+	assert.Len(t, offers, 1)
+	assert.Equal(t, []string{"10.0.1.1", "10.0.1.2", "10.0.2.1"}, remoteClient.tradersAsked(),
+		"Partitions and traders should be searched one at a time in simulation")
+}
+
+func TestSupplier_SearchStats(t *testing.T) {
+	supplier := &Supplier{}
+
+	supplier.recordSearch(10*time.Millisecond, 2)
+	supplier.recordSearch(30*time.Millisecond, 0)
+
+	stats := supplier.SearchStats()
+	assert.Equal(t, 2, stats.Searches)
+	assert.Equal(t, 1, stats.EmptySearches)
+	assert.Equal(t, 30*time.Millisecond, stats.MaxLatency)
+	assert.Equal(t, 20*time.Millisecond, stats.AverageLatency())
+}
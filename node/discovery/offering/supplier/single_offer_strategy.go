@@ -37,7 +37,7 @@ func (s *singleOfferChordStrategy) Init(supp *Supplier, resourcesMap *resources.
 }
 
 func (s *singleOfferChordStrategy) FindOffers(ctx context.Context, targetResources resources.Resources) []types.AvailableOffer {
-	if s.configs.SchedulingPolicy() == "binpack" {
+	if s.configs.SchedulingPolicy() == "binpack" || s.configs.SchedulingPolicy() == "image-cache" {
 		return s.findOffersLowToHigher(ctx, targetResources)
 	} else if s.configs.SchedulingPolicy() == "spread" {
 		return s.findOffersHigherToLow(ctx, targetResources)
@@ -48,6 +48,7 @@ func (s *singleOfferChordStrategy) FindOffers(ctx context.Context, targetResourc
 
 func (s *singleOfferChordStrategy) UpdateOffers(ctx context.Context, availableResources, usedResources resources.Resources) {
 	activeOffers := s.localSupplier.offers()
+	imagesFilter := s.node.ImagesFilter() // Computed once for all the offers' messages of this update.
 
 	if len(activeOffers) == 1 {
 		activeOffer := activeOffers[0]
@@ -67,6 +68,7 @@ func (s *singleOfferChordStrategy) UpdateOffers(ctx context.Context, availableRe
 						ID:                int64(offer.ID()),
 						Amount:            1,
 						ContainersRunning: s.localSupplier.numContainersRunning(),
+						ImagesFilter:      imagesFilter,
 						FreeResources: types.Resources{
 							CPUClass: types.CPUClass(availableResources.CPUClass()),
 							CPUs:     availableResources.CPUs(),
@@ -110,7 +112,8 @@ func (s *singleOfferChordStrategy) UpdateOffers(ctx context.Context, availableRe
 	log.Debugf(util.LogTag("SUPPLIER")+"CREATING offer... Offer: %d, Res: <%d;%d>",
 		int64(newOfferID), availableResources.CPUs(), availableResources.Memory())
 
-	offer, err := s.createAnOffer(ctx, int64(newOfferID), availableResources, availableResources, usedResources)
+	offer, err := s.createAnOffer(ctx, int64(newOfferID), availableResources, availableResources, usedResources,
+		imagesFilter)
 	if err == nil {
 		s.localSupplier.addOffer(offer)
 	}
@@ -35,6 +35,9 @@ type Supplier struct {
 	maxResources       *resources.Resources              // The maximum resources that the Docker engine has available (Static value)
 	availableResources *resources.Resources              // CURRENT Available resources to offer
 	containersRunning  int                               // Number of containers running in the node.
+	draining           bool                              // True if the node is in maintenance, not offering resources.
+	searchStats        types.SearchStats                 // Statistics of the offers' searches made by the node
+	searchStatsMutex   sync.Mutex                        // Mutex to handle the searches' statistics
 
 	quitChan             chan bool        // Channel to alert that the node is stopping
 	supplyingTicker      <-chan time.Time // Timer to supply available resources
@@ -57,6 +60,7 @@ func NewSupplier(node nodeCommon.Node, config *configuration.Configuration, over
 		activeOffers:       make(map[common.OfferID]*supplierOffer),
 		offersMutex:        sync.Mutex{},
 		containersRunning:  0,
+		searchStatsMutex:   sync.Mutex{},
 
 		quitChan:             make(chan bool),
 		supplyingTicker:      time.NewTicker(config.SupplyingInterval()).C,
@@ -115,7 +119,31 @@ func (s *Supplier) FindOffers(ctx context.Context, targetResources resources.Res
 		targetResources = *s.resourcesMap.LowestResources()
 	}
 
-	return s.offersStrategy.FindOffers(ctx, targetResources)
+	searchStart := time.Now()
+	availableOffers := s.offersStrategy.FindOffers(ctx, targetResources)
+	s.recordSearch(time.Since(searchStart), len(availableOffers))
+	return availableOffers
+}
+
+// SearchStats returns the statistics of the offers' searches made by the node.
+func (s *Supplier) SearchStats() types.SearchStats {
+	s.searchStatsMutex.Lock()
+	defer s.searchStatsMutex.Unlock()
+	return s.searchStats
+}
+
+func (s *Supplier) recordSearch(latency time.Duration, numOffers int) {
+	s.searchStatsMutex.Lock()
+	defer s.searchStatsMutex.Unlock()
+
+	s.searchStats.Searches++
+	if numOffers == 0 {
+		s.searchStats.EmptySearches++
+	}
+	s.searchStats.TotalLatency += latency
+	if latency > s.searchStats.MaxLatency {
+		s.searchStats.MaxLatency = latency
+	}
 }
 
 // Tries refresh an offer. Called when a refresh message was received.
@@ -134,16 +162,44 @@ func (s *Supplier) RefreshOffer(fromTrader *types.Node, refreshOffer *types.Offe
 		return false
 	}
 
-	if offer.IsResponsibleTrader(*guid.NewGUIDString(fromTrader.GUID)) {
+	fromTraderGUID := guid.NewGUIDString(fromTrader.GUID)
+	if offer.IsResponsibleTrader(*fromTraderGUID) {
 		offer.Refresh()
 		log.Debugf(util.LogTag("SUPPLIER")+"Offer: %d refresh SUCCESS", refreshOffer.ID)
 		return true
+	} else if fromTrader.IP != "" && s.resourcesMap.ResourcesByGUID(*fromTraderGUID).Equals(
+		*s.resourcesMap.ResourcesByGUID(*offer.ResponsibleTraderGUID())) {
+		// A trader of the same partition, that kept a replica of the offer, took over the offer after the
+		// responsible trader failed.
+		offer.SetResponsibleTrader(fromTrader.IP, *fromTraderGUID)
+		offer.Refresh()
+		log.Debugf(util.LogTag("SUPPLIER")+"Offer: %d refresh SUCCESS (taken over by %s)", refreshOffer.ID,
+			fromTraderGUID.Short())
+		return true
 	} else {
 		log.Debugf(util.LogTag("SUPPLIER")+"Offer: %d refresh FAILED (wrong trader)", refreshOffer.ID)
 		return false
 	}
 }
 
+// Changes the trader responsible for an offer. Only the current responsible trader (when it is leaving the overlay)
+// can hand over the offer into other trader.
+func (s *Supplier) ChangeOfferTrader(fromTrader, newTrader *types.Node, changeOffer *types.Offer) {
+	s.offersMutex.Lock()
+	defer s.offersMutex.Unlock()
+
+	offer, exist := s.activeOffers[common.OfferID(changeOffer.ID)]
+	if !exist || !offer.IsResponsibleTrader(*guid.NewGUIDString(fromTrader.GUID)) {
+		log.Debugf(util.LogTag("SUPPLIER")+"Offer: %d trader change REFUSED", changeOffer.ID)
+		return
+	}
+
+	offer.SetResponsibleTrader(newTrader.IP, *guid.NewGUIDString(newTrader.GUID))
+	offer.Refresh()
+	log.Debugf(util.LogTag("SUPPLIER")+"Offer: %d trader CHANGED to <%s;%s>", changeOffer.ID, newTrader.IP,
+		newTrader.GUID)
+}
+
 // Tries to obtain a subset of the resources represented by the given offer in order to deploy  a container.
 // It updates the respective trader that manages the offer.
 func (s *Supplier) ObtainResources(offerID int64, resourcesNecessary resources.Resources, numContainersToRun int) bool {
@@ -155,7 +211,7 @@ func (s *Supplier) ObtainResources(offerID int64, resourcesNecessary resources.R
 	defer s.offersMutex.Unlock()
 
 	supOffer, exist := s.activeOffers[common.OfferID(offerID)]
-	if !exist || !supOffer.Resources().Contains(resourcesNecessary) || !s.availableResources.Contains(resourcesNecessary) { // Offer does not exist in the supplier OR asking more resources than the offer has available
+	if s.draining || !exist || !supOffer.Resources().Contains(resourcesNecessary) || !s.availableResources.Contains(resourcesNecessary) { // Offer does not exist in the supplier OR asking more resources than the offer has available
 		return false
 	} else {
 		s.availableResources.Sub(resourcesNecessary)
@@ -211,7 +267,57 @@ func (s *Supplier) ReturnResources(releasedResources resources.Resources, numCon
 	}
 }
 
+// RecoverResources marks as used the resources of containers that kept running while the node restarted.
+func (s *Supplier) RecoverResources(usedResources resources.Resources, numContainersRunning int) {
+	s.offersMutex.Lock()
+	defer s.offersMutex.Unlock()
+
+	log.Debugf(util.LogTag("SUPPLIER")+"RESOURCES RECOVERED Res: <%d;%d>", usedResources.CPUs(), usedResources.Memory())
+	s.availableResources.Sub(usedResources)
+	s.containersRunning += numContainersRunning
+	s.updateOffers()
+}
+
+// Drain stops offering the node's resources and removes the active offers from the traders that manage them.
+func (s *Supplier) Drain() {
+	if !s.IsWorking() {
+		panic(errors.New("can't drain, supplier not working"))
+	}
+
+	s.offersMutex.Lock()
+	defer s.offersMutex.Unlock()
+
+	s.draining = true
+	for offerID, offer := range s.activeOffers {
+		log.Debugf(util.LogTag("SUPPLIER")+"DRAINING Offer: %d, HandlerTrader: %s", offer.ID(),
+			offer.ResponsibleTraderIP())
+		s.client.RemoveOffer(
+			context.Background(),
+			&types.Node{IP: s.config.HostIP()},
+			&types.Node{IP: offer.ResponsibleTraderIP(), GUID: offer.ResponsibleTraderGUID().String()},
+			&types.Offer{ID: int64(offer.ID())},
+		)
+		delete(s.activeOffers, offerID)
+	}
+}
+
+// Undrain makes the supplier offer the node's available resources again.
+func (s *Supplier) Undrain() {
+	if !s.IsWorking() {
+		panic(errors.New("can't undrain, supplier not working"))
+	}
+
+	s.offersMutex.Lock()
+	defer s.offersMutex.Unlock()
+
+	s.draining = false
+	s.updateOffers()
+}
+
 func (s *Supplier) updateOffers() {
+	if s.draining { // Node in maintenance does not offer its resources.
+		return
+	}
 	s.checkResourcesInvariant() // Runtime resources assertion!!!
 	if s.availableResources.IsValid() {
 		usedResources := s.maxResources.Copy()
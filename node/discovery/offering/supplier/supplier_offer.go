@@ -63,6 +63,12 @@ func (offer *supplierOffer) ResponsibleTraderGUID() *guid.GUID {
 	return offer.responsibleTraderGUID.Copy()
 }
 
+// Changes the trader responsible for managing the offer.
+func (offer *supplierOffer) SetResponsibleTrader(traderIP string, traderGUID guid.GUID) {
+	offer.responsibleTraderIP = traderIP
+	offer.responsibleTraderGUID = &traderGUID
+}
+
 func (offer *supplierOffer) ResponsibleTraderIP() string {
 	return offer.responsibleTraderIP
 }
@@ -2,6 +2,7 @@ package trader
 
 import (
 	"github.com/strabox/caravela/node/common"
+	"github.com/strabox/caravela/node/common/guid"
 	"sync"
 )
 
@@ -57,3 +58,16 @@ func (n *nearbyTradersOffering) Neighbors() []*common.RemoteNode {
 	res[1] = n.successor
 	return res
 }
+
+// Forgets the nearby trader with the given GUID (e.g. because it left the overlay).
+func (n *nearbyTradersOffering) Remove(nodeGUID guid.GUID) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.successor != nil && n.successor.GUID().Equals(nodeGUID) {
+		n.successor = nil
+	}
+	if n.predecessor != nil && n.predecessor.GUID().Equals(nodeGUID) {
+		n.predecessor = nil
+	}
+}
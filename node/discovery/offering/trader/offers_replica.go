@@ -0,0 +1,29 @@
+package trader
+
+import (
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/node/common/guid"
+	"time"
+)
+
+// Replica of the offers table of a predecessor trader (inside the same resources partition).
+type offersReplica struct {
+	traderGUID *guid.GUID              // GUID of the trader that owns the offers
+	traderIP   string                  // IP of the trader that owns the offers
+	offers     []types.ReplicatedOffer // Offers managed by the trader at the time of the last replication
+	lastUpdate time.Time               // Last time the trader replicated its offers
+}
+
+func newOffersReplica(traderGUID guid.GUID, traderIP string, offers []types.ReplicatedOffer) *offersReplica {
+	return &offersReplica{
+		traderGUID: &traderGUID,
+		traderIP:   traderIP,
+		offers:     offers,
+		lastUpdate: time.Now(),
+	}
+}
+
+// Returns true if the trader has not replicated its offers for longer than the given timeout.
+func (r *offersReplica) Expired(timeout time.Duration) bool {
+	return time.Now().After(r.lastUpdate.Add(timeout))
+}
@@ -0,0 +1,6 @@
+package trader
+
+type suppliersReputation interface {
+	RefreshSucceeded(supplierIP string)
+	RefreshFailed(supplierIP string)
+}
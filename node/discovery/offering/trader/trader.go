@@ -30,22 +30,31 @@ type Trader struct {
 	guid             *guid.GUID           // Trader's own GUID
 	resourcesMap     *resources.Mapping   // GUID<->Resources mapping
 	handledResources *resources.Resources // Combination of resources that its responsible for managing (FIXED)
+	reputations      suppliersReputation  // Reputation of the suppliers, updated with the refreshes outcomes
 
 	nearbyTradersOffering *nearbyTradersOffering    // Nearby traders that might have offers available
 	offers                map[offerKey]*traderOffer // Map with all the offers that the trader is managing
 	offersMutex           sync.Mutex                // Mutex for managing the offers
+	replicas              map[string]*offersReplica // Replicas of the offers of the predecessor traders
+	replicasMutex         sync.Mutex                // Mutex for managing the replicas
 
-	quitChan            chan bool        // Channel to alert that the node is stopping
-	refreshOffersTicker <-chan time.Time // Time ticker for sending the refreshing offer messages
-	spreadOffersTimer   <-chan time.Time // Time ticker to spread offer information into the neighbors
+	quitChan              chan bool        // Channel to alert that the node is stopping
+	refreshOffersTicker   <-chan time.Time // Time ticker for sending the refreshing offer messages
+	spreadOffersTimer     <-chan time.Time // Time ticker to spread offer information into the neighbors
+	replicateOffersTicker <-chan time.Time // Time ticker to replicate the offers into the successors
 }
 
 // NewTrader creates a new "virtual" trader.
 func NewTrader(config *configuration.Configuration, overlay overlay.Overlay, client external.Caravela,
-	guid guid.GUID, resourcesMapping *resources.Mapping) *Trader {
+	guid guid.GUID, resourcesMapping *resources.Mapping, reputations suppliersReputation) *Trader {
 
 	handledResources := resourcesMapping.ResourcesByGUID(guid)
 
+	var replicateOffersTicker <-chan time.Time = nil // Nil channel never fires, replication is disabled.
+	if config.OfferReplicas() > 0 {
+		replicateOffersTicker = time.NewTicker(config.ReplicationInterval()).C
+	}
+
 	return &Trader{
 		config:           config,
 		overlay:          overlay,
@@ -53,14 +62,18 @@ func NewTrader(config *configuration.Configuration, overlay overlay.Overlay, cli
 		guid:             &guid,
 		resourcesMap:     resourcesMapping,
 		handledResources: handledResources,
+		reputations:      reputations,
 
 		nearbyTradersOffering: newNeighborTradersOffering(),
 		offers:                make(map[offerKey]*traderOffer),
 		offersMutex:           sync.Mutex{},
+		replicas:              make(map[string]*offersReplica),
+		replicasMutex:         sync.Mutex{},
 
-		quitChan:            make(chan bool),
-		refreshOffersTicker: time.NewTicker(config.RefreshingInterval()).C,
-		spreadOffersTimer:   time.NewTicker(config.SpreadOffersInterval()).C,
+		quitChan:              make(chan bool),
+		refreshOffersTicker:   time.NewTicker(config.RefreshingInterval()).C,
+		spreadOffersTimer:     time.NewTicker(config.SpreadOffersInterval()).C,
+		replicateOffersTicker: replicateOffersTicker,
 	}
 }
 
@@ -76,7 +89,7 @@ func (t *Trader) start() {
 					go func(offer *traderOffer) {
 						refreshed, err := t.client.RefreshOffer(
 							context.Background(),
-							&types.Node{GUID: t.guid.String()},
+							&types.Node{GUID: t.guid.String(), IP: t.config.HostIP()},
 							&types.Node{IP: offer.supplierIP},
 							&types.Offer{ID: int64(offer.ID())})
 
@@ -90,6 +103,7 @@ func (t *Trader) start() {
 							log.Debugf(util.LogTag("TRADER")+"Refresh SUCCEEDED ,supplier: %s, offer: %d",
 								offer.SupplierIP(), offer.ID())
 							offer.RefreshSucceeded()
+							t.reputations.RefreshSucceeded(offer.SupplierIP())
 						} else if err == nil && !refreshed && exist { // Offer did not exist, so it was not refreshed
 							log.Debugf(util.LogTag("TRADER")+"Refresh FAILED (offer did not exist),"+
 								" supplier: %s, offer: %d", offer.SupplierIP(), offer.ID())
@@ -98,6 +112,7 @@ func (t *Trader) start() {
 							log.Debugf(util.LogTag("TRADER")+"Refresh FAILED, supplier: %s, offer: %d",
 								offer.SupplierIP(), offer.ID())
 							offer.RefreshFailed()
+							t.reputations.RefreshFailed(offer.SupplierIP())
 							if offer.RefreshesFailed() >= t.config.MaxRefreshesFailed() {
 								log.Debugf(util.LogTag("TRADER")+"REMOVING offer, supplier: %s, offer: %d",
 									offer.SupplierIP(), offer.ID())
@@ -116,6 +131,11 @@ func (t *Trader) start() {
 				t.advertiseOffersToNeighbors(func(neighborGUID *guid.GUID) bool { return true },
 					&types.Node{GUID: t.guid.String(), IP: t.config.HostIP()})
 			}
+		case <-t.replicateOffersTicker: // Time to replicate the offers and verify if the predecessors are alive
+			go func() {
+				t.replicateOffers()
+				t.checkReplicas()
+			}()
 		case quit := <-t.quitChan: // Stopping the trader (returning the goroutine)
 			if quit {
 				log.Infof(util.LogTag("TRADER")+"Trader %s STOPPED", t.guid.Short())
@@ -137,6 +157,7 @@ func (t *Trader) CreateOffer(fromSupp *types.Node, newOffer *types.Offer) {
 		offerKey := offerKey{supplierIP: fromSupp.IP, id: common.OfferID(newOffer.ID)}
 		offer := newTraderOffer(*guid.NewGUIDString(fromSupp.GUID), fromSupp.IP, common.OfferID(newOffer.ID),
 			newOffer.Amount, *resourcesOffered)
+		offer.SetImagesFilter(newOffer.ImagesFilter)
 
 		t.offers[offerKey] = offer
 		log.Debugf(util.LogTag("TRADER")+"%s Offer CREATED %dX<%d;%d>, From: %s, Offer: %d",
@@ -154,6 +175,7 @@ func (t *Trader) UpdateOffer(fromSupp *types.Node, offer *types.Offer) {
 	if traderOffer, exist := t.offers[offerKey{id: common.OfferID(offer.ID), supplierIP: fromSupp.IP}]; exist {
 		newOfferRes := *resources.NewResourcesCPUClass(int(offer.FreeResources.CPUClass), offer.FreeResources.CPUs, offer.FreeResources.Memory)
 		traderOffer.UpdateResources(newOfferRes, offer.Amount)
+		traderOffer.SetImagesFilter(offer.ImagesFilter)
 		traderOffer.RefreshSucceeded() // Refresh the offer at the same time of update too.
 	}
 }
@@ -171,6 +193,7 @@ func (t *Trader) GetOffers(ctx context.Context, _ *types.Node, relay bool) []typ
 			allOffers[index].SupplierIP = traderOffer.SupplierIP()
 			allOffers[index].ID = int64(traderOffer.ID())
 			allOffers[index].Amount = traderOffer.Amount()
+			allOffers[index].ImagesFilter = traderOffer.ImagesFilter()
 			allOffers[index].FreeResources = types.Resources{
 				CPUClass: types.CPUClass(traderOffer.Resources().CPUClass()),
 				CPUs:     traderOffer.Resources().CPUs(),
@@ -180,45 +203,64 @@ func (t *Trader) GetOffers(ctx context.Context, _ *types.Node, relay bool) []typ
 		}
 		return allOffers
 	} else { // Ask for offers in the nearby neighbors that we think they have offers (via offer advertise relaying)
-		resOffers := make([]types.AvailableOffer, 0)
-
 		// Ask the successor (higher GUID)
-		if successor := t.nearbyTradersOffering.Successor(); successor != nil {
-			successorResourcesHandled := t.resourcesMap.ResourcesByGUID(*successor.GUID())
-			if t.handledResources.Equals(*successorResourcesHandled) {
-				offers, err := t.client.GetOffers( // Sends the get offers message
-					ctx,
-					&types.Node{GUID: t.guid.String()},
-					&types.Node{IP: successor.IP(), GUID: successor.GUID().String()},
-					false)
-				if err == nil && len(offers) != 0 {
-					resOffers = append(resOffers, offers...)
-				} else if err == nil && len(offers) == 0 {
-					t.nearbyTradersOffering.SetSuccessor(nil)
+		askSuccessor := func() []types.AvailableOffer {
+			if successor := t.nearbyTradersOffering.Successor(); successor != nil {
+				successorResourcesHandled := t.resourcesMap.ResourcesByGUID(*successor.GUID())
+				if t.handledResources.Equals(*successorResourcesHandled) {
+					offers, err := t.client.GetOffers( // Sends the get offers message
+						ctx,
+						&types.Node{GUID: t.guid.String()},
+						&types.Node{IP: successor.IP(), GUID: successor.GUID().String()},
+						false)
+					if err == nil && len(offers) != 0 {
+						return offers
+					} else if err == nil && len(offers) == 0 {
+						t.nearbyTradersOffering.SetSuccessor(nil)
+					}
 				}
 			}
-
+			return nil
 		}
 
 		// Ask the predecessor (lower GUID)
-		if predecessor := t.nearbyTradersOffering.Predecessor(); predecessor != nil {
-			predecessorResourcesHandled := t.resourcesMap.ResourcesByGUID(*predecessor.GUID())
-			if t.handledResources.Equals(*predecessorResourcesHandled) {
-				offers, err := t.client.GetOffers( // Sends the get offers message
-					ctx,
-					&types.Node{GUID: t.guid.String()},
-					&types.Node{IP: predecessor.IP(), GUID: predecessor.GUID().String()},
-					false)
-				if err == nil && len(offers) != 0 {
-					resOffers = append(resOffers, offers...)
-				} else if err == nil && len(offers) == 0 {
-					t.nearbyTradersOffering.SetPredecessor(nil)
+		askPredecessor := func() []types.AvailableOffer {
+			if predecessor := t.nearbyTradersOffering.Predecessor(); predecessor != nil {
+				predecessorResourcesHandled := t.resourcesMap.ResourcesByGUID(*predecessor.GUID())
+				if t.handledResources.Equals(*predecessorResourcesHandled) {
+					offers, err := t.client.GetOffers( // Sends the get offers message
+						ctx,
+						&types.Node{GUID: t.guid.String()},
+						&types.Node{IP: predecessor.IP(), GUID: predecessor.GUID().String()},
+						false)
+					if err == nil && len(offers) != 0 {
+						return offers
+					} else if err == nil && len(offers) == 0 {
+						t.nearbyTradersOffering.SetPredecessor(nil)
+					}
 				}
 			}
+			return nil
+		}
 
+		var successorOffers, predecessorOffers []types.AvailableOffer
+		if t.config.Simulation() {
+			successorOffers = askSuccessor()
+			predecessorOffers = askPredecessor()
+		} else { // Ask both neighbors at the same time and wait for both
+			wg := sync.WaitGroup{}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				successorOffers = askSuccessor()
+			}()
+			predecessorOffers = askPredecessor()
+			wg.Wait()
 		}
-		// TRY: OPTIONAl make the calls in parallel (2 goroutines) and wait here for both, then join the results.
-		return resOffers
+
+		resOffers := make([]types.AvailableOffer, 0, len(successorOffers)+len(predecessorOffers))
+		resOffers = append(resOffers, successorOffers...)
+		return append(resOffers, predecessorOffers...)
 	}
 }
 
@@ -262,6 +304,37 @@ func (t *Trader) AdvertiseNeighborOffer(fromTrader, traderOffering *types.Node)
 	}
 }
 
+// Stores the replica of the offers managed by a predecessor trader.
+func (t *Trader) ReplicateOffers(fromTrader *types.Node, offers []types.ReplicatedOffer) {
+	t.replicasMutex.Lock()
+	defer t.replicasMutex.Unlock()
+
+	t.replicas[fromTrader.GUID] = newOffersReplica(*guid.NewGUIDString(fromTrader.GUID), fromTrader.IP, offers)
+}
+
+// Forgets a nearby trader that was known to have offers, because it is no longer part of the overlay.
+func (t *Trader) ForgetNearbyTrader(traderGUID guid.GUID) {
+	t.nearbyTradersOffering.Remove(traderGUID)
+}
+
+// Reacts to a change of the trader's predecessor. If the previous predecessor is no longer between the new one and
+// the trader, it is gone from the overlay (e.g. it crashed) and the trader takes over the offers it replicated here.
+func (t *Trader) PredecessorChanged(predecessorGUID, previousGUID *guid.GUID) {
+	t.nearbyTradersOffering.Remove(*previousGUID)
+	if predecessorGUID == nil || !previousGUID.Between(*predecessorGUID, *t.guid) {
+		return // The predecessor is leaving (it hands over its offers) or a new trader joined after it.
+	}
+
+	t.replicasMutex.Lock()
+	replica, exist := t.replicas[previousGUID.String()]
+	delete(t.replicas, previousGUID.String())
+	t.replicasMutex.Unlock()
+
+	if exist {
+		t.takeOver(replica)
+	}
+}
+
 func (t *Trader) haveOffers() bool {
 	t.offersMutex.Lock()
 	defer t.offersMutex.Unlock()
@@ -300,6 +373,190 @@ func (t *Trader) advertiseOffersToNeighbors(isValidNeighbor func(neighborGUID *g
 	}
 }
 
+// Hands over the offers into the next trader of the partition, that takes over the trader's GUID range, when it
+// leaves the overlay. The suppliers are told about their offers' new trader, so they don't need to recreate them.
+func (t *Trader) HandOverOffers(successor *types.Node) {
+	offers := t.offersSnapshot()
+	if len(offers) == 0 {
+		return
+	}
+
+	// The overlay's successor goes first, followed by the nodes after the trader's GUID. The other virtual nodes of
+	// this node are skipped because they are leaving too.
+	nodes := []*overlay.OverlayNode{overlay.NewOverlayNode(successor.IP, 0, guid.NewGUIDString(successor.GUID).Bytes())}
+	if successors, err := t.overlay.Lookup(context.Background(), t.guid.Bytes()); err == nil {
+		nodes = append(nodes, successors...)
+	}
+	nextTrader := t.nextPartitionTrader(nodes, t.config.HostIP())
+	if nextTrader == nil {
+		log.Errorf(util.LogTag("TRADER")+"Hand over FAILED, no trader of the partition to take over %d offers",
+			len(offers))
+		return
+	}
+
+	fromTrader := &types.Node{GUID: t.guid.String(), IP: t.config.HostIP()}
+	toTrader := &types.Node{IP: nextTrader.IP(), GUID: guid.NewGUIDBytes(nextTrader.GUID()).String()}
+	if err := t.client.HandOverOffers(context.Background(), fromTrader, toTrader, offers); err != nil {
+		log.Errorf(util.LogTag("TRADER")+"Hand over FAILED, To: %s, error: %s", toTrader.IP, err)
+		return
+	}
+
+	wg := sync.WaitGroup{}
+	for i := range offers {
+		wg.Add(1)
+		go func(offer *types.ReplicatedOffer) {
+			defer wg.Done()
+			err := t.client.ChangeOfferTrader(context.Background(), fromTrader, &types.Node{IP: offer.SupplierIP},
+				toTrader, &types.Offer{ID: offer.ID})
+			if err != nil {
+				log.Debugf(util.LogTag("TRADER")+"Change offer trader FAILED, supplier: %s, offer: %d",
+					offer.SupplierIP, offer.ID)
+			}
+		}(&offers[i])
+	}
+	wg.Wait()
+
+	log.Infof(util.LogTag("TRADER")+"%s HANDED OVER %d offers to %s", t.guid.Short(), len(offers), toTrader.IP)
+}
+
+// Receives the offers of a predecessor trader that left the overlay.
+func (t *Trader) AcceptOffers(fromTrader *types.Node, offers []types.ReplicatedOffer) {
+	t.replicasMutex.Lock()
+	delete(t.replicas, fromTrader.GUID) // The replica is not necessary, we manage the offers now.
+	t.replicasMutex.Unlock()
+
+	t.addOffers(offers)
+	log.Debugf(util.LogTag("TRADER")+"%s ACCEPTED %d offers, From: %s", t.guid.Short(), len(offers), fromTrader.IP)
+}
+
+// Returns the offers managed by the trader in the format used to replicate/transfer them into other traders.
+func (t *Trader) offersSnapshot() []types.ReplicatedOffer {
+	t.offersMutex.Lock()
+	defer t.offersMutex.Unlock()
+
+	offers := make([]types.ReplicatedOffer, 0, len(t.offers))
+	for _, traderOffer := range t.offers {
+		replicatedOffer := types.ReplicatedOffer{SupplierGUID: traderOffer.supplierGUID.String()}
+		replicatedOffer.SupplierIP = traderOffer.SupplierIP()
+		replicatedOffer.ID = int64(traderOffer.ID())
+		replicatedOffer.Amount = traderOffer.Amount()
+		replicatedOffer.ImagesFilter = traderOffer.ImagesFilter()
+		replicatedOffer.FreeResources = types.Resources{
+			CPUClass: types.CPUClass(traderOffer.Resources().CPUClass()),
+			CPUs:     traderOffer.Resources().CPUs(),
+			Memory:   traderOffer.Resources().Memory(),
+		}
+		offers = append(offers, replicatedOffer)
+	}
+	return offers
+}
+
+// Adds the given offers into the offers managed by the trader (unless they are already managed).
+func (t *Trader) addOffers(offers []types.ReplicatedOffer) {
+	t.offersMutex.Lock()
+	defer t.offersMutex.Unlock()
+
+	for _, replicatedOffer := range offers {
+		offerKEY := offerKey{supplierIP: replicatedOffer.SupplierIP, id: common.OfferID(replicatedOffer.ID)}
+		if _, exist := t.offers[offerKEY]; exist {
+			continue
+		}
+
+		offerResources := resources.NewResourcesCPUClass(int(replicatedOffer.FreeResources.CPUClass),
+			replicatedOffer.FreeResources.CPUs, replicatedOffer.FreeResources.Memory)
+		offer := newTraderOffer(*guid.NewGUIDString(replicatedOffer.SupplierGUID), replicatedOffer.SupplierIP,
+			common.OfferID(replicatedOffer.ID), replicatedOffer.Amount, *offerResources)
+		offer.SetImagesFilter(replicatedOffer.ImagesFilter)
+		t.offers[offerKEY] = offer
+	}
+}
+
+// Replicates the offers table into the next successors that are inside the trader's resources partition.
+func (t *Trader) replicateOffers() {
+	offers := t.offersSnapshot()
+
+	successors, err := t.overlay.Lookup(context.Background(), t.guid.Bytes())
+	if err != nil {
+		return
+	}
+
+	replicas := 0
+	for _, successor := range successors {
+		if replicas >= t.config.OfferReplicas() {
+			break
+		}
+
+		successorGUID := guid.NewGUIDBytes(successor.GUID())
+		if successorGUID.Equals(*t.guid) || !t.handledResources.Equals(*t.resourcesMap.ResourcesByGUID(*successorGUID)) {
+			continue // Replicas are only kept by other traders of the same resources partition.
+		}
+		replicas++
+
+		replicate := func(successor *overlay.OverlayNode, successorGUID *guid.GUID) {
+			err := t.client.ReplicateOffers(
+				context.Background(),
+				&types.Node{GUID: t.guid.String(), IP: t.config.HostIP()},
+				&types.Node{IP: successor.IP(), GUID: successorGUID.String()},
+				offers)
+			if err != nil {
+				log.Debugf(util.LogTag("TRADER")+"Replication FAILED, To: %s, error: %s", successorGUID.Short(), err)
+			}
+		}
+
+		if t.config.Simulation() {
+			replicate(successor, successorGUID)
+		} else {
+			go replicate(successor, successorGUID)
+		}
+	}
+}
+
+// Verifies the replicas that were not updated for too long, which covers the failures that were not noticed through
+// the predecessor changes. If the trader that owned them left the overlay and this trader is the next one of the
+// partition after its GUID, the replicated offers are taken over (serving them and refreshing them).
+func (t *Trader) checkReplicas() {
+	t.replicasMutex.Lock()
+	expiredReplicas := make([]*offersReplica, 0)
+	for traderGUID, replica := range t.replicas {
+		if replica.Expired(t.config.ReplicaTimeout()) {
+			expiredReplicas = append(expiredReplicas, replica)
+			delete(t.replicas, traderGUID)
+		}
+	}
+	t.replicasMutex.Unlock()
+
+	for _, replica := range expiredReplicas {
+		nodes, err := t.overlay.Lookup(context.Background(), replica.traderGUID.Bytes())
+		if err != nil || len(nodes) == 0 || guid.NewGUIDBytes(nodes[0].GUID()).Equals(*replica.traderGUID) {
+			continue // The trader is alive (we stopped being its successor).
+		}
+
+		nextTrader := t.nextPartitionTrader(nodes, replica.traderIP)
+		if nextTrader != nil && guid.NewGUIDBytes(nextTrader.GUID()).Equals(*t.guid) {
+			t.takeOver(replica)
+		}
+	}
+}
+
+// Takes over the offers of a failed trader that were replicated into this trader.
+func (t *Trader) takeOver(replica *offersReplica) {
+	t.addOffers(replica.offers)
+	log.Infof(util.LogTag("TRADER")+"%s TOOK OVER %d offers of the failed trader %s", t.guid.Short(),
+		len(replica.offers), replica.traderGUID.Short())
+}
+
+// Returns the first of the given nodes, that is not in the excluded node (IP), handling the trader's resources
+// partition. It returns nil if there is no such node.
+func (t *Trader) nextPartitionTrader(nodes []*overlay.OverlayNode, excludedIP string) *overlay.OverlayNode {
+	for _, node := range nodes {
+		nodeResources := t.resourcesMap.ResourcesByGUID(*guid.NewGUIDBytes(node.GUID()))
+		if node.IP() != excludedIP && nodeResources != nil && t.handledResources.Equals(*nodeResources) {
+			return node
+		}
+	}
+	return nil
+}
+
 // ======================= External Services (Consumed during simulation ONLY) =========================
 
 //Simulation
@@ -311,7 +568,7 @@ func (t *Trader) RefreshOffersSim() {
 		if offer.Refresh() {
 			refreshed, err := t.client.RefreshOffer(
 				context.Background(),
-				&types.Node{GUID: t.guid.String()},
+				&types.Node{GUID: t.guid.String(), IP: t.config.HostIP()},
 				&types.Node{IP: offer.supplierIP},
 				&types.Offer{ID: int64(offer.ID())},
 			)
@@ -323,6 +580,7 @@ func (t *Trader) RefreshOffersSim() {
 				log.Debugf(util.LogTag("TRADER")+"Refresh SUCCEEDED ,supplier: %s, offer: %d",
 					offer.SupplierIP(), offer.ID())
 				offer.RefreshSucceeded()
+				t.reputations.RefreshSucceeded(offer.SupplierIP())
 			} else if err == nil && !refreshed && exist { // Offer did not exist, so it was not refreshed
 				log.Debugf(util.LogTag("TRADER")+"Refresh FAILED (offer did not exist),"+
 					" supplier: %s, offer: %d", offer.SupplierIP(), offer.ID())
@@ -331,6 +589,7 @@ func (t *Trader) RefreshOffersSim() {
 				log.Debugf(util.LogTag("TRADER")+"Refresh FAILED, supplier: %s, offer: %d",
 					offer.SupplierIP(), offer.ID())
 				offer.RefreshFailed()
+				t.reputations.RefreshFailed(offer.SupplierIP())
 				if offer.RefreshesFailed() >= t.config.MaxRefreshesFailed() {
 					log.Debugf(util.LogTag("TRADER")+"REMOVING offer, supplier: %s, offer: %d",
 						offer.SupplierIP(), offer.ID())
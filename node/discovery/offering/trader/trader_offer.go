@@ -20,6 +20,7 @@ type traderOffer struct {
 
 	supplierGUID *guid.GUID // GUID of the supplier offering these resources
 	supplierIP   string     // IP of the supplier offering these resources
+	imagesFilter []byte     // Bloom filter of the images cached in the supplier
 
 	lastRefreshTimestamp time.Time // Last time the offer was refreshed with/without success
 	waitingForRefresh    bool      // Marks if there is still a refresh pending for the offer (avoid multiple refreshes)
@@ -70,6 +71,14 @@ func (offer *traderOffer) RefreshSucceeded() {
 	offer.waitingForRefresh = false
 }
 
+func (offer *traderOffer) SetImagesFilter(imagesFilter []byte) {
+	offer.imagesFilter = imagesFilter
+}
+
+func (offer *traderOffer) ImagesFilter() []byte {
+	return offer.imagesFilter
+}
+
 func (offer *traderOffer) SupplierIP() string {
 	return offer.supplierIP
 }
@@ -0,0 +1,178 @@
+package trader
+
+import (
+	"context"
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/configuration"
+	"github.com/strabox/caravela/node/common/guid"
+	"github.com/strabox/caravela/node/common/resources"
+	"github.com/strabox/caravela/node/external"
+	"github.com/strabox/caravela/overlay"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+// overlayStub returns always the same nodes for any key, the first is the responsible for the key.
+type overlayStub struct {
+	overlay.Overlay
+	nodes []*overlay.OverlayNode
+}
+
+func (o *overlayStub) Lookup(context.Context, []byte) ([]*overlay.OverlayNode, error) {
+	return o.nodes, nil
+}
+
+// remoteClientStub records the offers replicated and handed over into each trader (GUID) and the suppliers told
+// about their offers' new trader.
+type remoteClientStub struct {
+	external.Caravela
+	replicated map[string][]types.ReplicatedOffer
+	handedOver map[string][]types.ReplicatedOffer
+	changed    map[string]string
+	mutex      sync.Mutex
+}
+
+func (r *remoteClientStub) ReplicateOffers(_ context.Context, _, toTrader *types.Node,
+	offers []types.ReplicatedOffer) error {
+	r.replicated[toTrader.GUID] = offers
+	return nil
+}
+
+func (r *remoteClientStub) HandOverOffers(_ context.Context, _, toTrader *types.Node,
+	offers []types.ReplicatedOffer) error {
+	r.handedOver[toTrader.GUID] = offers
+	return nil
+}
+
+func (r *remoteClientStub) ChangeOfferTrader(_ context.Context, _, toSupplier, newTrader *types.Node,
+	_ *types.Offer) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.changed[toSupplier.IP] = newTrader.GUID
+	return nil
+}
+
+func TestTrader_ReplicateOffers(t *testing.T) {
+	config := configuration.Default("10.0.0.1")
+	config.Caravela.Simulation = true
+	guid.Init(config.ChordHashSizeBits(), int64(config.GUIDEstimatedNetworkSize()), int64(config.GUIDScaleFactor()))
+	resourcesMap := resources.NewResourcesMap(resources.ObtainConfiguredPartitions(config.ResourcesPartitions()), false)
+	traderGUID, _ := resourcesMap.FirstGUIDOffer(*resources.NewResourcesCPUClass(0, 1, 512))
+	successor1GUID, successor2GUID, successor3GUID := traderGUID.Copy(), traderGUID.Copy(), traderGUID.Copy()
+	successor1GUID.AddOffset("10")
+	successor2GUID.AddOffset("20")
+	successor3GUID.AddOffset("30")
+	otherPartitionGUID, _ := resourcesMap.FirstGUIDOffer(*resources.NewResourcesCPUClass(0, 2, 512))
+	overlayNodes := &overlayStub{nodes: []*overlay.OverlayNode{
+		overlay.NewOverlayNode("10.0.0.1", 8000, traderGUID.Bytes()),
+		overlay.NewOverlayNode("10.0.0.2", 8000, successor1GUID.Bytes()),
+		overlay.NewOverlayNode("10.0.0.3", 8000, otherPartitionGUID.Bytes()),
+		overlay.NewOverlayNode("10.0.0.4", 8000, successor2GUID.Bytes()),
+		overlay.NewOverlayNode("10.0.0.5", 8000, successor3GUID.Bytes()),
+	}}
+	remoteClient := &remoteClientStub{replicated: make(map[string][]types.ReplicatedOffer)}
+	trader := NewTrader(config, overlayNodes, remoteClient, *traderGUID, resourcesMap, nil)
+	trader.CreateOffer(&types.Node{IP: "10.0.0.9", GUID: "1"}, &types.Offer{ID: 1, Amount: 1,
+		FreeResources: types.Resources{CPUs: 1, Memory: 512}})
+
+	trader.replicateOffers()
+
+	assert.Len(t, remoteClient.replicated, 2, "Offers should be replicated into 2 successors")
+	assert.Len(t, remoteClient.replicated[successor1GUID.String()], 1)
+	assert.Len(t, remoteClient.replicated[successor2GUID.String()], 1,
+		"Successors of other partitions should not keep replicas")
+}
+
+func TestTrader_PredecessorChanged(t *testing.T) {
+	config := configuration.Default("10.0.0.1")
+	config.Caravela.Simulation = true
+	guid.Init(config.ChordHashSizeBits(), int64(config.GUIDEstimatedNetworkSize()), int64(config.GUIDScaleFactor()))
+	resourcesMap := resources.NewResourcesMap(resources.ObtainConfiguredPartitions(config.ResourcesPartitions()), false)
+	lowerGUID, _ := resourcesMap.FirstGUIDOffer(*resources.NewResourcesCPUClass(0, 1, 512))
+	predecessorGUID, joinedGUID, traderGUID := lowerGUID.Copy(), lowerGUID.Copy(), lowerGUID.Copy()
+	predecessorGUID.AddOffset("10")
+	joinedGUID.AddOffset("15")
+	traderGUID.AddOffset("20")
+	trader := NewTrader(config, &overlayStub{}, &remoteClientStub{}, *traderGUID, resourcesMap, nil)
+	trader.ReplicateOffers(&types.Node{IP: "10.0.0.2", GUID: predecessorGUID.String()}, []types.ReplicatedOffer{{
+		AvailableOffer: types.AvailableOffer{SupplierIP: "10.0.0.9", ID: 1, Amount: 1,
+			FreeResources: types.Resources{CPUs: 1, Memory: 512}}, SupplierGUID: "1"}})
+
+	trader.PredecessorChanged(nil, predecessorGUID)
+	assert.Equal(t, 0, trader.NumActiveOffers(), "Leaving predecessor hands over its offers")
+
+	trader.PredecessorChanged(joinedGUID, predecessorGUID)
+	assert.Equal(t, 0, trader.NumActiveOffers(), "Predecessor is alive, a trader joined after it")
+
+	trader.PredecessorChanged(lowerGUID, predecessorGUID)
+	assert.Equal(t, 1, trader.NumActiveOffers(), "Offers of the failed predecessor not taken over")
+}
+
+func TestTrader_CheckReplicas(t *testing.T) {
+	config := configuration.Default("10.0.0.1")
+	config.Caravela.Simulation = true
+	guid.Init(config.ChordHashSizeBits(), int64(config.GUIDEstimatedNetworkSize()), int64(config.GUIDScaleFactor()))
+	resourcesMap := resources.NewResourcesMap(resources.ObtainConfiguredPartitions(config.ResourcesPartitions()), false)
+	failedGUID, _ := resourcesMap.FirstGUIDOffer(*resources.NewResourcesCPUClass(0, 1, 512))
+	traderGUID := failedGUID.Copy()
+	traderGUID.AddOffset("10")
+	otherPartitionGUID, _ := resourcesMap.FirstGUIDOffer(*resources.NewResourcesCPUClass(0, 2, 512))
+	overlayNodes := &overlayStub{nodes: []*overlay.OverlayNode{
+		overlay.NewOverlayNode("10.0.0.2", 8000, failedGUID.Bytes()),
+		overlay.NewOverlayNode("10.0.0.1", 8000, traderGUID.Bytes()),
+	}}
+	trader := NewTrader(config, overlayNodes, &remoteClientStub{}, *traderGUID, resourcesMap, nil)
+	replicateFailed := func() {
+		trader.ReplicateOffers(&types.Node{IP: "10.0.0.2", GUID: failedGUID.String()}, []types.ReplicatedOffer{{
+			AvailableOffer: types.AvailableOffer{SupplierIP: "10.0.0.9", ID: 1, Amount: 1,
+				FreeResources: types.Resources{CPUs: 1, Memory: 512}}, SupplierGUID: "1"}})
+		trader.replicas[failedGUID.String()].lastUpdate = time.Now().Add(-time.Hour)
+	}
+
+	replicateFailed()
+	trader.checkReplicas()
+	assert.Equal(t, 0, trader.NumActiveOffers(), "Offers of an alive trader taken over")
+
+	replicateFailed()
+	overlayNodes.nodes = []*overlay.OverlayNode{
+		overlay.NewOverlayNode("10.0.0.3", 8000, otherPartitionGUID.Bytes()),
+		overlay.NewOverlayNode("10.0.0.1", 8000, traderGUID.Bytes()),
+	}
+	trader.checkReplicas()
+	assert.Equal(t, 1, trader.NumActiveOffers(), "Next trader of the partition should take over the offers")
+}
+
+func TestTrader_HandOverOffers(t *testing.T) {
+	config := configuration.Default("10.0.0.1")
+	config.Caravela.Simulation = true
+	guid.Init(config.ChordHashSizeBits(), int64(config.GUIDEstimatedNetworkSize()), int64(config.GUIDScaleFactor()))
+	resourcesMap := resources.NewResourcesMap(resources.ObtainConfiguredPartitions(config.ResourcesPartitions()), false)
+	traderGUID, _ := resourcesMap.FirstGUIDOffer(*resources.NewResourcesCPUClass(0, 1, 512))
+	localGUID, nextTraderGUID := traderGUID.Copy(), traderGUID.Copy()
+	localGUID.AddOffset("10")
+	nextTraderGUID.AddOffset("20")
+	otherPartitionGUID, _ := resourcesMap.FirstGUIDOffer(*resources.NewResourcesCPUClass(0, 2, 512))
+	overlayNodes := &overlayStub{nodes: []*overlay.OverlayNode{
+		overlay.NewOverlayNode("10.0.0.1", 8000, traderGUID.Bytes()),
+		overlay.NewOverlayNode("10.0.0.1", 8000, localGUID.Bytes()),
+		overlay.NewOverlayNode("10.0.0.3", 8000, otherPartitionGUID.Bytes()),
+		overlay.NewOverlayNode("10.0.0.4", 8000, nextTraderGUID.Bytes()),
+	}}
+	remoteClient := &remoteClientStub{handedOver: make(map[string][]types.ReplicatedOffer),
+		changed: make(map[string]string)}
+	trader := NewTrader(config, overlayNodes, remoteClient, *traderGUID, resourcesMap, nil)
+	trader.CreateOffer(&types.Node{IP: "10.0.0.8", GUID: "1"}, &types.Offer{ID: 1, Amount: 1,
+		FreeResources: types.Resources{CPUs: 1, Memory: 512}})
+	trader.CreateOffer(&types.Node{IP: "10.0.0.9", GUID: "2"}, &types.Offer{ID: 1, Amount: 1,
+		FreeResources: types.Resources{CPUs: 2, Memory: 2048}})
+
+	trader.HandOverOffers(&types.Node{IP: "10.0.0.1", GUID: localGUID.String()})
+
+	assert.Len(t, remoteClient.handedOver, 1)
+	assert.Len(t, remoteClient.handedOver[nextTraderGUID.String()], 2,
+		"All the offers should be handed over into the next trader of the partition in other node")
+	assert.Equal(t, map[string]string{"10.0.0.8": nextTraderGUID.String(), "10.0.0.9": nextTraderGUID.String()},
+		remoteClient.changed, "Suppliers not told about the new trader")
+}
@@ -27,6 +27,7 @@ type Discovery struct {
 	nodeGUID         *guid.GUID           //
 	maximumResources *resources.Resources //
 	freeResources    *resources.Resources //
+	draining         bool                 // True if the node is in maintenance, not offering resources.
 	resourcesMutex   sync.Mutex           //
 }
 
@@ -57,6 +58,14 @@ func (d *Discovery) AddTrader(traderGUID guid.GUID) {
 	log.Debugf(util.LogTag("RandDisc")+"NEW TRADER GUID: %s", traderGUID.Short())
 }
 
+func (d *Discovery) RemoveTrader(_ guid.GUID, _ *types.Node) {
+	// Do Nothing - Not necessary for this backend.
+}
+
+func (d *Discovery) MembershipChanged(_ *overlay.MembershipEvent) {
+	// Do Nothing - Not necessary for this backend.
+}
+
 func (d *Discovery) FindOffers(ctx context.Context, targetResources resources.Resources) []types.AvailableOffer {
 	resultOffers := make([]types.AvailableOffer, 0)
 
@@ -99,11 +108,15 @@ func (d *Discovery) FindOffers(ctx context.Context, targetResources resources.Re
 	return resultOffers
 }
 
+func (d *Discovery) SearchStats() types.SearchStats {
+	return types.SearchStats{} // Not recorded by this backend.
+}
+
 func (d *Discovery) ObtainResources(offerID int64, resourcesNecessary resources.Resources, _ int) bool {
 	d.resourcesMutex.Lock()
 	defer d.resourcesMutex.Unlock()
 
-	if d.freeResources.Contains(resourcesNecessary) {
+	if !d.draining && d.freeResources.Contains(resourcesNecessary) {
 		d.freeResources.Sub(resourcesNecessary)
 		return true
 	}
@@ -118,6 +131,27 @@ func (d *Discovery) ReturnResources(releasedResources resources.Resources, _ int
 	d.freeResources.Add(releasedResources)
 }
 
+func (d *Discovery) RecoverResources(usedResources resources.Resources, _ int) {
+	d.resourcesMutex.Lock()
+	defer d.resourcesMutex.Unlock()
+
+	d.freeResources.Sub(usedResources)
+}
+
+func (d *Discovery) Drain() {
+	d.resourcesMutex.Lock()
+	defer d.resourcesMutex.Unlock()
+
+	d.draining = true
+}
+
+func (d *Discovery) Undrain() {
+	d.resourcesMutex.Lock()
+	defer d.resourcesMutex.Unlock()
+
+	d.draining = false
+}
+
 // ======================= External/Remote Services =========================
 
 func (d *Discovery) CreateOffer(_ *types.Node, _ *types.Node, _ *types.Offer) {
@@ -141,7 +175,7 @@ func (d *Discovery) GetOffers(_ context.Context, _, _ *types.Node, _ bool) []typ
 	d.resourcesMutex.Lock()
 	defer d.resourcesMutex.Unlock()
 
-	if d.freeResources.IsValid() {
+	if !d.draining && d.freeResources.IsValid() {
 		usedResources := d.maximumResources.Copy()
 		usedResources.Sub(*d.freeResources)
 		return []types.AvailableOffer{
@@ -172,6 +206,18 @@ func (d *Discovery) AdvertiseNeighborOffers(_, _, _ *types.Node) {
 	// Do Nothing - Not necessary for this backend.
 }
 
+func (d *Discovery) ReplicateOffers(_, _ *types.Node, _ []types.ReplicatedOffer) {
+	// Do Nothing - Not necessary for this backend.
+}
+
+func (d *Discovery) HandOverOffers(_, _ *types.Node, _ []types.ReplicatedOffer) {
+	// Do Nothing - Not necessary for this backend.
+}
+
+func (d *Discovery) ChangeOfferTrader(_, _ *types.Node, _ *types.Offer) {
+	// Do Nothing - Not necessary for this backend.
+}
+
 // ============== External/Remote Services (Only Simulation) ================
 
 func (d *Discovery) NodeInformationSim() (types.Resources, types.Resources, int, int) {
@@ -41,6 +41,7 @@ type Discovery struct {
 	containersRunning  int                  //
 	maximumResources   *resources.Resources //
 	availableResources *resources.Resources //
+	draining           bool                 // True if the node is in maintenance, not accepting containers.
 	resourcesMutex     sync.Mutex           //
 }
 
@@ -139,6 +140,14 @@ func (d *Discovery) AddTrader(traderGUID guid.GUID) {
 	d.isMasterNode = d.nodeGUID.Equals(*guid.NewGUIDInteger(mastersNodeGUID))
 }
 
+func (d *Discovery) RemoveTrader(_ guid.GUID, _ *types.Node) {
+	// Do Nothing - Not necessary for this backend.
+}
+
+func (d *Discovery) MembershipChanged(_ *overlay.MembershipEvent) {
+	// Do Nothing - Not necessary for this backend.
+}
+
 func (d *Discovery) FindOffers(_ context.Context, targetResources resources.Resources) []types.AvailableOffer {
 	if d.isMasterNode {
 		d.resourcesMutex.Lock()
@@ -182,12 +191,16 @@ func (d *Discovery) FindOffers(_ context.Context, targetResources resources.Reso
 	return make([]types.AvailableOffer, 0)
 }
 
+func (d *Discovery) SearchStats() types.SearchStats {
+	return types.SearchStats{} // Not recorded by this backend.
+}
+
 func (d *Discovery) ObtainResources(_ int64, resourcesNecessary resources.Resources, numContainersToRun int) bool {
 	if !d.isMasterNode {
 		d.resourcesMutex.Lock()
 		defer d.resourcesMutex.Unlock()
 
-		if d.availableResources.Contains(resourcesNecessary) {
+		if !d.draining && d.availableResources.Contains(resourcesNecessary) {
 			d.availableResources.Sub(resourcesNecessary)
 			d.containersRunning += numContainersToRun
 
@@ -252,6 +265,32 @@ func (d *Discovery) ReturnResources(releasedResources resources.Resources, numCo
 	}
 }
 
+// RecoverResources marks as used the resources of containers that kept running while the node restarted. The master
+// is not updated because this backend is only used in simulation, where the nodes do not restart.
+func (d *Discovery) RecoverResources(usedResources resources.Resources, numContainersRunning int) {
+	d.resourcesMutex.Lock()
+	defer d.resourcesMutex.Unlock()
+
+	d.availableResources.Sub(usedResources)
+	d.containersRunning += numContainersRunning
+}
+
+// Drain makes the node refuse new containers. The master is not updated because this backend is only used in
+// simulation to compare the discovery backends.
+func (d *Discovery) Drain() {
+	d.resourcesMutex.Lock()
+	defer d.resourcesMutex.Unlock()
+
+	d.draining = true
+}
+
+func (d *Discovery) Undrain() {
+	d.resourcesMutex.Lock()
+	defer d.resourcesMutex.Unlock()
+
+	d.draining = false
+}
+
 // ======================= External Services (Consumed by other Nodes) ==============================
 
 func (d *Discovery) CreateOffer(fromSupp *types.Node, _ *types.Node, offer *types.Offer) {
@@ -300,6 +339,18 @@ func (d *Discovery) AdvertiseNeighborOffers(_, _, _ *types.Node) {
 	// Do Nothing - Not necessary for this backend.
 }
 
+func (d *Discovery) ReplicateOffers(_, _ *types.Node, _ []types.ReplicatedOffer) {
+	// Do Nothing - Not necessary for this backend.
+}
+
+func (d *Discovery) HandOverOffers(_, _ *types.Node, _ []types.ReplicatedOffer) {
+	// Do Nothing - Not necessary for this backend.
+}
+
+func (d *Discovery) ChangeOfferTrader(_, _ *types.Node, _ *types.Offer) {
+	// Do Nothing - Not necessary for this backend.
+}
+
 // ======================= External Services (Consumed during simulation ONLY) =========================
 
 // Simulation
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/strabox/caravela/api/types"
 	"github.com/strabox/caravela/configuration"
+	"io"
 )
 
 // Caravela is the complete API/Interface for the remote client of a node.
@@ -28,16 +29,65 @@ type Caravela interface {
 	// Sends a message to a neighbor trader saying that a given trader has offers available
 	AdvertiseOffersNeighbor(ctx context.Context, fromTrader, toNeighborTrader, traderOffering *types.Node) error
 
+	// Sends the offers of a trader to a successor trader (in the same partition) that keeps a replica of them.
+	ReplicateOffers(ctx context.Context, fromTrader, toTrader *types.Node, offers []types.ReplicatedOffer) error
+
+	// Sends the offers of a trader that is leaving the overlay to the trader that takes over its GUID range.
+	HandOverOffers(ctx context.Context, fromTrader, toTrader *types.Node, offers []types.ReplicatedOffer) error
+
+	// Sends a message from a leaving trader to a supplier saying that its offer is now managed by a new trader.
+	ChangeOfferTrader(ctx context.Context, fromTrader, toSupplier, newTrader *types.Node, offer *types.Offer) error
+
 	// =============================== Scheduling ===============================
 
 	// Sends a launch container message to a supplier in order to deploy the container
 	LaunchContainer(ctx context.Context, fromBuyer, toSupplier *types.Node, offer *types.Offer,
 		containerConfig []types.ContainerConfig) ([]types.ContainerStatus, error)
 
+	// Sends a reschedule container message, from a draining supplier, to the buyer of a container in order to move it.
+	RescheduleContainer(ctx context.Context, fromSupplier, toBuyer *types.Node, containerID string) error
+
+	// Sends an adopt container message, from a restarted supplier, to the buyer of a container that kept running.
+	AdoptContainer(ctx context.Context, fromSupplier, toBuyer *types.Node, userID string,
+		containerStatus *types.ContainerStatus) error
+
 	// =============================== Containers ===============================
 
-	// Sends a stop container message to a supplier in order to stop the container
-	StopLocalContainer(ctx context.Context, toSupplier *types.Node, containerID string) error
+	// Sends a stop container message, from the container's buyer, to a supplier in order to stop the container
+	StopLocalContainer(ctx context.Context, fromBuyer, toSupplier *types.Node, containerID string) error
+
+	// ================================= Images =================================
+
+	// Sends a message to the node responsible for an image key saying that the sender node holds the image.
+	AdvertiseImage(ctx context.Context, fromNode, toNode *types.Node, imageKey string) error
+
+	// Sends a message to the node responsible for an image key to obtain the nodes that hold the image.
+	GetImageHolders(ctx context.Context, fromNode, toNode *types.Node, imageKey string) ([]types.Node, error)
+
+	// Sends a message to a node that holds an image in order to download it (in the docker save format).
+	DownloadImage(ctx context.Context, toHolder *types.Node, imageKey string) (io.ReadCloser, error)
+
+	// ================================= Quotas =================================
+
+	// Sends a message to the node responsible for a user's quota to reserve (or release, if negative) resources.
+	ReserveQuota(ctx context.Context, fromNode, toNode *types.Node, userID string, resources types.QuotaUsage) (*types.UserQuota, error)
+
+	// Sends a message with a change to the user's usage, made by the sender, to a node that keeps a replica of it.
+	ReplicateQuota(ctx context.Context, fromNode, toNode *types.Node, userID string, resources types.QuotaUsage) error
+
+	// Sends a message to a node that keeps a user's usage to obtain the user's quota.
+	GetQuota(ctx context.Context, fromNode, toNode *types.Node, userID string) (*types.UserQuota, error)
+
+	// ================================= Credits =================================
+
+	// Sends a receipt, signed by the buyer, to the supplier that launched the containers in order to be countersigned.
+	ExchangeReceipt(ctx context.Context, fromBuyer, toSupplier *types.Node, receipt *types.CreditReceipt) (*types.CreditReceipt, error)
+
+	// Sends a countersigned receipt to a node that keeps the account of the buyer or the supplier.
+	PostReceipt(ctx context.Context, fromNode, toNode *types.Node, receipt *types.CreditReceipt) error
+
+	// Sends a message to a node that keeps a node's account to obtain its balance.
+	GetBalance(ctx context.Context, fromNode, toNode *types.Node, nodeIP string) (float64, error)
 
 	// ============================== Configuration ==============================
 
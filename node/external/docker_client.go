@@ -4,6 +4,8 @@ import (
 	"github.com/strabox/caravela/api/types"
 	"github.com/strabox/caravela/docker/container"
 	"github.com/strabox/caravela/docker/events"
+	"github.com/strabox/caravela/storage"
+	"io"
 )
 
 // Interface for interacting with the Docker daemon.
@@ -22,4 +24,13 @@ type DockerClient interface {
 
 	// Remove a container from the Docker engine.
 	RemoveContainer(containerID string) error
+
+	// Lists the keys (tags and digests) of the images present in the Docker engine.
+	ListImages() ([]string, error)
+
+	// Exports an image from the Docker engine in the docker save format.
+	SaveImage(imageKey string) (io.ReadCloser, error)
+
+	// Provides a way to share the images with other nodes.
+	SetImagesPeers(peers storage.ImagesPeers)
 }
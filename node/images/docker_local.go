@@ -0,0 +1,9 @@
+package images
+
+import "io"
+
+// Interface that provides the necessary methods to list and export the local images.
+type dockerLocal interface {
+	ListImages() ([]string, error)
+	SaveImage(imageKey string) (io.ReadCloser, error)
+}
@@ -0,0 +1,14 @@
+package images
+
+import (
+	"context"
+	"github.com/strabox/caravela/api/types"
+	"io"
+)
+
+// Interface that provides the necessary methods to talk with other nodes.
+type imagesRemoteClient interface {
+	AdvertiseImage(ctx context.Context, fromNode, toNode *types.Node, imageKey string) error
+	GetImageHolders(ctx context.Context, fromNode, toNode *types.Node, imageKey string) ([]types.Node, error)
+	DownloadImage(ctx context.Context, toHolder *types.Node, imageKey string) (io.ReadCloser, error)
+}
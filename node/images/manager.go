@@ -0,0 +1,261 @@
+package images
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	log "github.com/Sirupsen/logrus"
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/configuration"
+	"github.com/strabox/caravela/node/common"
+	"github.com/strabox/caravela/node/common/guid"
+	"github.com/strabox/caravela/overlay"
+	"github.com/strabox/caravela/util"
+	"github.com/strabox/caravela/util/bloom"
+	"io"
+	"sync"
+	"time"
+)
+
+// Size (in bytes) of the bloom filter that summarizes the images cached in the node.
+const imagesFilterSizeBytes = 128
+
+// Images manager is responsible for sharing the container's images between the CARAVELA's nodes.
+// The nodes that hold an image advertise it to the node responsible (in the overlay) for the image's key,
+// which keeps the image's holders. It implements the storage.ImagesPeers interface.
+type Manager struct {
+	common.NodeComponent // Base component.
+
+	config       *configuration.Configuration // System's configurations.
+	overlay      overlay.Overlay              // Overlay component.
+	remoteClient imagesRemoteClient           // Client to collaborate with other CARAVELA's nodes.
+	docker       dockerLocal                  // Local Docker's client used to export the images.
+
+	holdersMutex sync.Mutex                      // Mutex to control access to the holders and local images.
+	holders      map[string]map[string]time.Time // Images holders, that this node is responsible for (imageKey->(holderIP->lastAdvertise)).
+	localImages  map[string]bool                 // Images keys that the local node advertised holding.
+
+	quitChan        chan bool        // Channel to alert that the node is stopping.
+	advertiseTicker <-chan time.Time // Timer to re-advertise the local images.
+}
+
+// NewManager creates a new images manager component.
+func NewManager(config *configuration.Configuration, overlay overlay.Overlay, remoteClient imagesRemoteClient,
+	docker dockerLocal) *Manager {
+	return &Manager{
+		config:       config,
+		overlay:      overlay,
+		remoteClient: remoteClient,
+		docker:       docker,
+
+		holdersMutex: sync.Mutex{},
+		holders:      make(map[string]map[string]time.Time),
+		localImages:  make(map[string]bool),
+
+		quitChan:        make(chan bool),
+		advertiseTicker: time.NewTicker(config.ImagesAdvertiseInterval()).C,
+	}
+}
+
+// start controls the time dependant actions like re-advertising the local images.
+func (m *Manager) start() {
+	for {
+		select {
+		case <-m.advertiseTicker: // Re-advertise the local images, keeping this node as a holder.
+			m.holdersMutex.Lock()
+			imageKeys := make([]string, 0, len(m.localImages))
+			for imageKey := range m.localImages {
+				imageKeys = append(imageKeys, imageKey)
+			}
+			m.holdersMutex.Unlock()
+
+			for _, imageKey := range imageKeys {
+				m.advertise(context.Background(), imageKey)
+			}
+		case quit := <-m.quitChan: // Stopping the images management
+			if quit {
+				log.Info(util.LogTag("IMAGES") + "STOPPED")
+				return
+			}
+		}
+	}
+}
+
+// ===============================================================================
+// =							  ImagesPeers Interface                          =
+// ===============================================================================
+
+// FindImageHolders returns the IP addresses of the nodes (excluding the local one) that hold the image.
+func (m *Manager) FindImageHolders(ctx context.Context, imageKey string) []string {
+	for _, responsibleNode := range common.ResponsibleNodes(ctx, m.overlay, imageKeyGUID(imageKey)) {
+		var holders []types.Node
+		if responsibleNode.IP == m.config.HostIP() {
+			holders = m.ImageHolders(imageKey)
+		} else {
+			var err error
+			holders, err = m.remoteClient.GetImageHolders(ctx, m.localNode(), responsibleNode, imageKey)
+			if err != nil {
+				continue
+			}
+		}
+
+		res := make([]string, 0, len(holders))
+		for _, holder := range holders {
+			if holder.IP != m.config.HostIP() {
+				res = append(res, holder.IP)
+			}
+		}
+		if len(res) > 0 {
+			return res
+		}
+	}
+	return nil
+}
+
+// DownloadImage obtains the image from the given holder node, the download is bounded by the configured timeout.
+func (m *Manager) DownloadImage(ctx context.Context, holderIP string, imageKey string) (io.ReadCloser, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.config.ImagesDownloadTimeout())
+
+	imageArchive, err := m.remoteClient.DownloadImage(ctx, &types.Node{IP: holderIP}, imageKey)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &cancelReadCloser{ReadCloser: imageArchive, cancel: cancel}, nil
+}
+
+// AdvertiseImage advertises into the system that the local node holds an image, known by the given keys.
+func (m *Manager) AdvertiseImage(ctx context.Context, imageKeys ...string) {
+	m.holdersMutex.Lock()
+	for _, imageKey := range imageKeys {
+		m.localImages[imageKey] = true
+	}
+	m.holdersMutex.Unlock()
+
+	for _, imageKey := range imageKeys {
+		m.advertise(ctx, imageKey)
+	}
+}
+
+// advertise sends the advertisement of a local image to the node responsible for the image's key.
+func (m *Manager) advertise(ctx context.Context, imageKey string) {
+	for _, responsibleNode := range common.ResponsibleNodes(ctx, m.overlay, imageKeyGUID(imageKey)) {
+		if responsibleNode.IP == m.config.HostIP() {
+			m.AddHolder(m.config.HostIP(), imageKey)
+			return
+		}
+		err := m.remoteClient.AdvertiseImage(ctx, m.localNode(), responsibleNode, imageKey)
+		if err == nil {
+			return
+		}
+	}
+	log.Warnf(util.LogTag("IMAGES")+"Impossible to advertise image %s", imageKey)
+}
+
+// ===============================================================================
+// =							   Remote Requests                               =
+// ===============================================================================
+
+// AddHolder registers a node as a holder of an image.
+func (m *Manager) AddHolder(holderIP string, imageKey string) {
+	m.holdersMutex.Lock()
+	defer m.holdersMutex.Unlock()
+
+	imageHolders, exist := m.holders[imageKey]
+	if !exist {
+		imageHolders = make(map[string]time.Time)
+		m.holders[imageKey] = imageHolders
+	}
+	imageHolders[holderIP] = time.Now()
+}
+
+// ImageHolders returns the nodes that hold an image, discarding the ones that stopped advertising it.
+func (m *Manager) ImageHolders(imageKey string) []types.Node {
+	m.holdersMutex.Lock()
+	defer m.holdersMutex.Unlock()
+
+	res := make([]types.Node, 0)
+	for holderIP, lastAdvertise := range m.holders[imageKey] {
+		if time.Since(lastAdvertise) > m.config.ImagesHolderTimeout() {
+			delete(m.holders[imageKey], holderIP)
+			continue
+		}
+		res = append(res, types.Node{IP: holderIP})
+	}
+	if len(m.holders[imageKey]) == 0 {
+		delete(m.holders, imageKey)
+	}
+	return res
+}
+
+// ExportImage exports an image that the local node holds.
+func (m *Manager) ExportImage(imageKey string) (io.ReadCloser, error) {
+	m.holdersMutex.Lock()
+	advertised := m.localImages[imageKey]
+	m.holdersMutex.Unlock()
+
+	if !advertised {
+		return nil, errors.New(fmt.Sprintf("image %s is not shared by this node", imageKey))
+	}
+	return m.docker.SaveImage(imageKey)
+}
+
+// CachedImagesFilter returns a bloom filter that summarizes the images present in the local Docker engine.
+func (m *Manager) CachedImagesFilter() []byte {
+	imageKeys, err := m.docker.ListImages()
+	if err != nil {
+		log.Warnf(util.LogTag("IMAGES")+"Impossible to list the local images: %s", err)
+		return nil
+	}
+
+	filter := bloom.NewFilter(imagesFilterSizeBytes)
+	for _, imageKey := range imageKeys {
+		filter.Add(util.NormalizeImageKey(imageKey))
+	}
+	return filter.Bytes()
+}
+
+// localNode returns the representation of the local node used in the remote messages.
+func (m *Manager) localNode() *types.Node {
+	return &types.Node{IP: m.config.HostIP()}
+}
+
+// imageKeyGUID maps an image key (e.g. a digest) into the overlay's key space.
+func imageKeyGUID(imageKey string) *guid.GUID {
+	return guid.NewGUIDHash("", imageKey)
+}
+
+// cancelReadCloser releases the context of a download when the image archive is closed.
+type cancelReadCloser struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelReadCloser) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
+// ===============================================================================
+// =							SubComponent Interface                           =
+// ===============================================================================
+
+func (m *Manager) Start() {
+	m.Started(m.config.Simulation(), func() {
+		if !m.config.Simulation() {
+			go m.start()
+		}
+	})
+}
+
+func (m *Manager) Stop() {
+	m.Stopped(func() {
+		if !m.config.Simulation() {
+			m.quitChan <- true
+		}
+	})
+}
+
+func (m *Manager) IsWorking() bool {
+	return m.Working()
+}
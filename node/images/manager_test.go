@@ -0,0 +1,52 @@
+package images
+
+import (
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/configuration"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+const imageKeyTest = "redis@sha256:0123456789abcdef"
+
+func TestManager_ImageHolders(t *testing.T) {
+	manager := NewManager(configuration.Default("127.0.0.1"), nil, nil, nil)
+
+	manager.AddHolder("10.0.0.1", imageKeyTest)
+	manager.AddHolder("10.0.0.2", imageKeyTest)
+	manager.AddHolder("10.0.0.1", imageKeyTest)
+
+	holders := manager.ImageHolders(imageKeyTest)
+	assert.Len(t, holders, 2, "Number of holders is incorrect!")
+	assert.Contains(t, holders, types.Node{IP: "10.0.0.1"}, "Holder is missing!")
+	assert.Contains(t, holders, types.Node{IP: "10.0.0.2"}, "Holder is missing!")
+	assert.Empty(t, manager.ImageHolders("redis:alpine"), "Image should not have holders!")
+}
+
+func TestManager_ExportImage_NotAdvertised(t *testing.T) {
+	manager := NewManager(configuration.Default("127.0.0.1"), nil, nil, nil)
+
+	_, err := manager.ExportImage(imageKeyTest)
+
+	assert.NotNil(t, err, "Export a non advertised image should fail!")
+}
+
+func TestManager_Stop_Simulation(t *testing.T) {
+	config := configuration.Default("127.0.0.1")
+	config.Caravela.Simulation = true
+	manager := NewManager(config, nil, nil, nil)
+	manager.Start()
+
+	stopped := make(chan bool)
+	go func() {
+		manager.Stop()
+		stopped <- true
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Stopping the manager blocked in simulation")
+	}
+}
@@ -14,17 +14,23 @@ import (
 	"github.com/strabox/caravela/api/types"
 	"github.com/strabox/caravela/configuration"
 	"github.com/strabox/caravela/node/common/guid"
+	"github.com/strabox/caravela/node/common/reputation"
 	"github.com/strabox/caravela/node/common/resources"
 	"github.com/strabox/caravela/node/containers"
+	"github.com/strabox/caravela/node/credits"
 	"github.com/strabox/caravela/node/discovery"
 	"github.com/strabox/caravela/node/discovery/backend"
 	"github.com/strabox/caravela/node/discovery/offering/partitions"
 	"github.com/strabox/caravela/node/external"
+	"github.com/strabox/caravela/node/images"
+	"github.com/strabox/caravela/node/quota"
 	"github.com/strabox/caravela/node/scheduler"
 	"github.com/strabox/caravela/node/user"
 	"github.com/strabox/caravela/overlay"
 	"github.com/strabox/caravela/util"
+	"io"
 	"math/rand"
+	"sync"
 	"time"
 	"unsafe"
 )
@@ -36,12 +42,19 @@ type Node struct {
 	schedulerComp         *scheduler.Scheduler // Scheduler component.
 	containersManagerComp *containers.Manager  // Container's Manager component.
 	userManagerComp       *user.Manager        // User's Manager component.
+	imagesManagerComp     *images.Manager      // Images's Manager component.
+	quotaManagerComp      *quota.Manager       // Quota's Manager component.
+	creditsManagerComp    *credits.Manager     // Credits's Manager component.
 	overlayComp           overlay.Overlay      // Overlay component.
+	faultyClient          *remote.FaultyClient // Injects faults in the requests sent to other nodes (nil if disabled).
 
 	config   *configuration.Configuration // System's configurations.
 	stopChan chan bool                    // Channel to stop the node functions.
 
 	systemPartitionsState *partitions.SystemResourcePartitions
+	supplierReputations   *reputation.Reputations
+	draining              bool       // True if the node is in maintenance.
+	drainMutex            sync.Mutex // Mutex to drain/undrain the node.
 }
 
 // NewNode creates a Node object that contains all the functionality of a CARAVELA's node.
@@ -59,14 +72,31 @@ func NewNode(config *configuration.Configuration, overlayCli overlay.Overlay, ca
 
 	// Create all the internal components
 	node := &Node{}
-
+	supplierReputations := reputation.NewReputations(config.ReputationHalfLife())
+
+	var faultyCli *remote.FaultyClient = nil // Only used if the fault injection is enabled.
+	if config.FaultInjection() {
+		faultyCli = remote.NewFaultyClient(caravelaCli, config.HostIP())
+		if config.FaultsFile() != "" {
+			if err := faultyCli.LoadFile(config.FaultsFile()); err != nil {
+				log.Errorf(util.LogTag("Node")+"Loading faults file error: %s", err)
+			}
+		}
+		caravelaCli = faultyCli
+	}
 	caravelaCli = remote.NewClient(caravelaCli, node)
 	overlayCli = overlay.NewOverlayClient(overlayCli, node)
 
 	discoveryComp := discovery.CreateDiscoveryBackend(node, config, overlayCli, caravelaCli, resourcesMap, *maxAvailableResources)
-	containersManagerComp := containers.NewManager(config, dockerClient, discoveryComp)
-	schedulerComp := scheduler.NewScheduler(config, discoveryComp, containersManagerComp, caravelaCli)
-	userManagerComp := user.NewManager(config, schedulerComp, caravelaCli, *resourcesMap.LowestResources())
+	containersManagerComp := containers.NewManager(config, dockerClient, discoveryComp, caravelaCli)
+	creditsManagerComp := credits.NewManager(config, overlayCli, caravelaCli, containersManagerComp)
+	schedulerComp := scheduler.NewScheduler(config, discoveryComp, containersManagerComp, creditsManagerComp,
+		supplierReputations, caravelaCli)
+	quotaManagerComp := quota.NewManager(config, overlayCli, caravelaCli)
+	userManagerComp := user.NewManager(config, schedulerComp, quotaManagerComp, creditsManagerComp,
+		supplierReputations, caravelaCli, *resourcesMap.LowestResources())
+	imagesManagerComp := images.NewManager(config, overlayCli, caravelaCli, dockerClient)
+	dockerClient.SetImagesPeers(imagesManagerComp)
 
 	// Initialize the node's fields.
 	node.apiServerComp = apiServer
@@ -74,10 +104,15 @@ func NewNode(config *configuration.Configuration, overlayCli overlay.Overlay, ca
 	node.schedulerComp = schedulerComp
 	node.containersManagerComp = containersManagerComp
 	node.userManagerComp = userManagerComp
+	node.imagesManagerComp = imagesManagerComp
+	node.quotaManagerComp = quotaManagerComp
+	node.creditsManagerComp = creditsManagerComp
 	node.overlayComp = overlayCli
+	node.faultyClient = faultyCli
 	node.config = config
 	node.stopChan = make(chan bool)
 	node.systemPartitionsState = partitions.NewSystemResourcePartitions(config.PartitionsStateBufferSize(), rand.New(util.NewSourceSafe(rand.NewSource(time.Now().Unix()))))
+	node.supplierReputations = supplierReputations
 	return node
 }
 
@@ -102,6 +137,9 @@ func (n *Node) Start(join bool, joinIP string) error {
 	n.discoveryComp.Start()
 	n.containersManagerComp.Start()
 	n.schedulerComp.Start()
+	n.imagesManagerComp.Start()
+	n.quotaManagerComp.Start()
+	n.creditsManagerComp.Start()
 
 	err = n.apiServerComp.Start(n) // Start CARAVELA's REST API web server
 	if err != nil {
@@ -124,16 +162,23 @@ func (n *Node) Start(join bool, joinIP string) error {
 // Stop the node's functions.
 func (n *Node) Stop(ctx context.Context) {
 	log.Debug(util.LogTag("Node") + "STOPPING...")
+	// Leave the overlay first, the traders hand over their offers while the other components are still working.
+	n.overlayComp.Leave(context.Background())
+	log.Debug(util.LogTag("Node") + "-> OVERLAY STOPPED")
 	n.apiServerComp.Stop()
 	log.Debug(util.LogTag("Node") + "-> API SERVER STOPPED")
+	n.imagesManagerComp.Stop()
+	log.Debug(util.LogTag("Node") + "-> IMAGES MANAGER STOPPED")
+	n.quotaManagerComp.Stop()
+	log.Debug(util.LogTag("Node") + "-> QUOTA MANAGER STOPPED")
+	n.creditsManagerComp.Stop()
+	log.Debug(util.LogTag("Node") + "-> CREDITS MANAGER STOPPED")
 	n.schedulerComp.Stop()
 	log.Debug(util.LogTag("Node") + "-> SCHEDULER STOPPED")
 	n.containersManagerComp.Stop()
 	log.Debug(util.LogTag("Node") + "-> CONTAINERS MANAGER STOPPED")
 	n.discoveryComp.Stop()
 	log.Debug(util.LogTag("Node") + "-> DISCOVERY STOPPED")
-	n.overlayComp.Leave(context.Background())
-	log.Debug(util.LogTag("Node") + "-> OVERLAY STOPPED")
 	// Used to make the main goroutine quit and exit the process
 	n.stopChan <- true
 	log.Debug(util.LogTag("Node") + "-> STOPPED")
@@ -152,6 +197,14 @@ func (n *Node) GUID() string {
 	return n.discoveryComp.GUID()
 }
 
+func (n *Node) ImagesFilter() []byte {
+	return n.imagesManagerComp.CachedImagesFilter()
+}
+
+func (n *Node) SupplierReputations() *reputation.Reputations {
+	return n.supplierReputations
+}
+
 // ##############################################################################################
 // #									     CLIENT API											#
 // ##############################################################################################
@@ -166,8 +219,87 @@ func (n *Node) StopContainers(ctx context.Context, containersIDs []string) error
 	return n.userManagerComp.StopContainers(ctx, containersIDs)
 }
 
-func (n *Node) ListContainers(_ context.Context) []types.ContainerStatus {
-	return n.userManagerComp.ListContainers()
+func (n *Node) ListContainers(ctx context.Context) []types.ContainerStatus {
+	return n.userManagerComp.ListContainers(ctx)
+}
+
+func (n *Node) Quota(ctx context.Context) (*types.UserQuota, error) {
+	return n.quotaManagerComp.Quota(ctx, types.UserID(ctx))
+}
+
+func (n *Node) Accounting(_ context.Context, since time.Time) ([]types.UsageRecord, error) {
+	return n.containersManagerComp.UsageRecords(since)
+}
+
+// Drain puts the node in maintenance: it stops offering its resources, asks the buyers of its containers to reschedule
+// them elsewhere and waits, until the timeout, for them to move. Optionally, the node leaves the system afterwards.
+func (n *Node) Drain(ctx context.Context, leave bool, timeout time.Duration) (*types.DrainStatus, error) {
+	n.drainMutex.Lock()
+	defer n.drainMutex.Unlock()
+
+	log.Debug(util.LogTag("Node") + "DRAINING...")
+	n.draining = true
+	n.discoveryComp.Drain()
+	moved, remaining := n.containersManagerComp.Drain(ctx, timeout)
+	log.Debugf(util.LogTag("Node")+"DRAINED, Moved: %d, Remaining: %d", moved, remaining)
+
+	if leave { // Stopped in background, the API server only stops after answering the drain's request.
+		go n.Stop(context.Background())
+	}
+
+	return &types.DrainStatus{
+		Draining:            true,
+		ContainersMoved:     moved,
+		ContainersRemaining: remaining,
+		Leaving:             leave,
+	}, nil
+}
+
+// Undrain takes the node out of maintenance, offering its resources again.
+func (n *Node) Undrain(_ context.Context) error {
+	n.drainMutex.Lock()
+	defer n.drainMutex.Unlock()
+
+	if !n.draining {
+		return errors.New("node is not draining")
+	}
+	n.draining = false
+	n.discoveryComp.Undrain()
+	log.Debug(util.LogTag("Node") + "UNDRAINED")
+	return nil
+}
+
+// Faults returns the faults being injected in the requests sent to other nodes.
+func (n *Node) Faults(_ context.Context) (*types.Faults, error) {
+	if n.faultyClient == nil {
+		return nil, errors.New("fault injection is disabled in the node")
+	}
+	return n.faultyClient.Faults(), nil
+}
+
+// SetFaults replaces the faults injected in the requests sent to other nodes, e.g. to start a chaos experiment.
+func (n *Node) SetFaults(_ context.Context, faults *types.Faults) error {
+	if n.faultyClient == nil {
+		return errors.New("fault injection is disabled in the node")
+	}
+	return n.faultyClient.SetFaults(faults)
+}
+
+// SearchStats returns the statistics (e.g. latency) of the offers' searches made by the node.
+func (n *Node) SearchStats(_ context.Context) types.SearchStats {
+	return n.discoveryComp.SearchStats()
+}
+
+func (n *Node) Reputations(_ context.Context) []types.SupplierReputation {
+	return n.supplierReputations.List()
+}
+
+func (n *Node) Authenticate(_ context.Context, token string) (string, bool) {
+	return n.userManagerComp.Authenticate(token)
+}
+
+func (n *Node) IsAdmin(_ context.Context, userID string) bool {
+	return n.userManagerComp.IsAdmin(userID)
 }
 
 // ##############################################################################################
@@ -176,9 +308,21 @@ func (n *Node) ListContainers(_ context.Context) []types.ContainerStatus {
 
 // =============================== Overlay Membership Interface =================================
 
-func (n *Node) AddTrader(guidBytes []byte) {
-	guidRes := guid.NewGUIDBytes(guidBytes)
-	n.discoveryComp.AddTrader(*guidRes)
+func (n *Node) MembershipChanged(event *overlay.MembershipEvent) {
+	log.Debugf(util.LogTag("Node")+"Overlay membership event: %s", event.Type.String())
+
+	switch event.Type {
+	case overlay.VirtualNodeJoined:
+		n.discoveryComp.AddTrader(*guid.NewGUIDBytes(event.LocalNodeID))
+	case overlay.VirtualNodeRemoved:
+		var successorNode *types.Node = nil
+		if event.Node != nil {
+			successorNode = &types.Node{IP: event.Node.IP(), GUID: guid.NewGUIDBytes(event.Node.GUID()).String()}
+		}
+		n.discoveryComp.RemoveTrader(*guid.NewGUIDBytes(event.LocalNodeID), successorNode)
+	default:
+		n.discoveryComp.MembershipChanged(event)
+	}
 }
 
 // =============================== Discovery Component Interface =================================
@@ -225,6 +369,18 @@ func (n *Node) AdvertiseOffersNeighbor(ctx context.Context, fromTrader, toNeighb
 	n.discoveryComp.AdvertiseNeighborOffers(fromTrader, toNeighborTrader, traderOffering)
 }
 
+func (n *Node) ReplicateOffers(_ context.Context, fromTrader, toTrader *types.Node, offers []types.ReplicatedOffer) {
+	n.discoveryComp.ReplicateOffers(fromTrader, toTrader, offers)
+}
+
+func (n *Node) HandOverOffers(_ context.Context, fromTrader, toTrader *types.Node, offers []types.ReplicatedOffer) {
+	n.discoveryComp.HandOverOffers(fromTrader, toTrader, offers)
+}
+
+func (n *Node) ChangeOfferTrader(_ context.Context, fromTrader, newTrader *types.Node, offer *types.Offer) {
+	n.discoveryComp.ChangeOfferTrader(fromTrader, newTrader, offer)
+}
+
 // ================================ Scheduling Component Interface ==============================
 
 func (n *Node) LaunchContainers(ctx context.Context, fromBuyer *types.Node, offer *types.Offer,
@@ -235,13 +391,63 @@ func (n *Node) LaunchContainers(ctx context.Context, fromBuyer *types.Node, offe
 	return n.schedulerComp.Launch(ctx, fromBuyer, offer, containersConfigs)
 }
 
+func (n *Node) AdoptContainer(ctx context.Context, fromSupplier *types.Node, containerStatus *types.ContainerStatus) error {
+	return n.userManagerComp.AdoptContainer(ctx, fromSupplier.IP, containerStatus)
+}
+
+func (n *Node) RescheduleContainer(ctx context.Context, fromSupplier *types.Node, containerID string) error {
+	return n.userManagerComp.RescheduleContainer(ctx, fromSupplier.IP, containerID)
+}
+
 // ============================== Containers Component Interface ================================
 
-func (n *Node) StopLocalContainer(ctx context.Context, containerID string) error {
+func (n *Node) StopLocalContainer(ctx context.Context, fromBuyer *types.Node, containerID string) error {
 	if partitionsState := types.SysPartitionsState(ctx); partitionsState != nil && n.config.SpreadPartitionsState() {
 		n.systemPartitionsState.MergePartitionsState(partitionsState)
 	}
-	return n.containersManagerComp.StopContainer(containerID)
+	return n.containersManagerComp.StopUserContainer(fromBuyer.IP, types.UserID(ctx), containerID)
+}
+
+// ================================ Images Component Interface ==================================
+
+func (n *Node) AdvertiseImage(_ context.Context, fromNode *types.Node, imageKey string) {
+	n.imagesManagerComp.AddHolder(fromNode.IP, imageKey)
+}
+
+func (n *Node) ImageHolders(_ context.Context, _ *types.Node, imageKey string) []types.Node {
+	return n.imagesManagerComp.ImageHolders(imageKey)
+}
+
+func (n *Node) ExportImage(_ context.Context, imageKey string) (io.ReadCloser, error) {
+	return n.imagesManagerComp.ExportImage(imageKey)
+}
+
+// ================================ Quota Component Interface ===================================
+
+func (n *Node) ReserveQuota(_ context.Context, fromNode *types.Node, userID string, resources types.QuotaUsage) (*types.UserQuota, error) {
+	return n.quotaManagerComp.ReserveQuota(fromNode, userID, resources)
+}
+
+func (n *Node) ReplicateQuota(_ context.Context, fromNode *types.Node, userID string, resources types.QuotaUsage) {
+	n.quotaManagerComp.ReplicateQuota(fromNode, userID, resources)
+}
+
+func (n *Node) UserQuota(_ context.Context, _ *types.Node, userID string) *types.UserQuota {
+	return n.quotaManagerComp.LocalQuota(userID)
+}
+
+// =============================== Credits Component Interface ==================================
+
+func (n *Node) ExchangeReceipt(ctx context.Context, fromBuyer *types.Node, receipt *types.CreditReceipt) (*types.CreditReceipt, error) {
+	return n.creditsManagerComp.AcceptReceipt(ctx, fromBuyer, receipt)
+}
+
+func (n *Node) PostReceipt(_ context.Context, fromNode *types.Node, receipt *types.CreditReceipt) error {
+	return n.creditsManagerComp.PostReceipt(fromNode, receipt)
+}
+
+func (n *Node) Balance(_ context.Context, _ *types.Node, nodeIP string) float64 {
+	return n.creditsManagerComp.LocalBalance(nodeIP)
 }
 
 // ##############################################################################################
@@ -0,0 +1,106 @@
+package node
+
+import (
+	"context"
+	"github.com/strabox/caravela/api"
+	"github.com/strabox/caravela/api/remote"
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/configuration"
+	"github.com/strabox/caravela/docker/fake"
+	"github.com/strabox/caravela/node/common/guid"
+	"github.com/strabox/caravela/overlay/memory"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// apiServerStub does not serve the API, the nodes are contacted through the in-memory network.
+type apiServerStub struct{}
+
+func (a *apiServerStub) Start(api.LocalNode) error {
+	return nil
+}
+
+func (a *apiServerStub) Stop() {}
+
+func TestNode_SubmitContainers_MemoryNetwork(t *testing.T) {
+	ring := memory.NewRing(1)
+	network := remote.NewMemoryNetworkOver(ring)
+	ips := []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}
+	nodes := make([]*Node, len(ips))
+	dockerClients := make([]*fake.Client, len(ips))
+	for i, ip := range ips {
+		config := configuration.Default(ip)
+		config.Caravela.Simulation = true
+		config.Caravela.Resources = configuration.ResourcesPartitions{CPUClasses: []configuration.CPUClassPartition{{
+			ResourcesPartition: configuration.ResourcesPartition{Value: 0, Percentage: 100},
+			CPUCores: []configuration.CPUCoresPartition{{
+				ResourcesPartition: configuration.ResourcesPartition{Value: 1, Percentage: 100},
+				Memory: []configuration.MemoryPartition{{
+					ResourcesPartition: configuration.ResourcesPartition{Value: 256, Percentage: 100}}},
+			}},
+		}}}
+		config.Overlay.Chord.VirtualNodes = 1 // Every trader is a neighbor of the others, so it relays their offers.
+		guid.Init(config.ChordHashSizeBits(), int64(config.GUIDEstimatedNetworkSize()), int64(config.GUIDScaleFactor()))
+		dockerClients[i] = fake.NewClient(0, 4, 4096)
+		if i == 0 { // The buyer has no resources, its containers run in other node.
+			dockerClients[i] = fake.NewClient(0, 0, 0)
+		}
+		nodes[i] = NewNode(config, memory.NewMemory(ring, config), network.Client(ip), dockerClients[i],
+			&apiServerStub{})
+		network.Register(ip, nodes[i])
+		if err := nodes[i].Start(i > 0, ips[0]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, node := range nodes {
+		node.SpreadOffersSim()
+	}
+
+	// The buyer finds a supplier through the overlay and launches the container there.
+	statuses, err := nodes[0].SubmitContainers(context.Background(), []types.ContainerConfig{{ImageKey: "redis",
+		Resources: types.Resources{CPUs: 1, Memory: 256}}})
+
+	assert.NoError(t, err)
+	if !assert.Len(t, statuses, 1) {
+		return
+	}
+	supplierIP := statuses[0].SupplierIP
+	assert.NotEqual(t, "10.0.0.1", supplierIP, "Container launched in the buyer without resources")
+	running := 0
+	for i, ip := range ips {
+		if ip == supplierIP {
+			running = dockerClients[i].NumContainersRunning()
+		}
+	}
+	assert.Equal(t, 1, running, "Container not launched in the supplier through the network")
+	assert.True(t, network.Messages()["LAUNCH_CONTAINER"] > 0, "Launch not routed through the network")
+
+	// A node that fails in the overlay is also unreachable through the network.
+	ring.Fail(supplierIP)
+	assert.Error(t, nodes[0].StopContainers(context.Background(), []string{statuses[0].ContainerID}),
+		"Failed supplier reached through the network")
+}
+
+func TestNode_SetFaults_Disabled(t *testing.T) {
+	config := configuration.Default("10.0.0.1")
+	config.Caravela.Simulation = true
+	guid.Init(config.ChordHashSizeBits(), int64(config.GUIDEstimatedNetworkSize()), int64(config.GUIDScaleFactor()))
+	node := NewNode(config, memory.NewMemory(memory.NewRing(1), config), remote.NewMemoryNetwork().Client("10.0.0.1"),
+		fake.NewClient(0, 4, 4096), &apiServerStub{})
+
+	_, err := node.Faults(context.Background())
+	assert.Error(t, err, "Faults injected without the fault injection enabled")
+	assert.Error(t, node.SetFaults(context.Background(), &types.Faults{Enabled: true}),
+		"Faults injected without the fault injection enabled")
+
+	config = configuration.Default("10.0.0.2")
+	config.Caravela.Simulation = true
+	config.Host.FaultInjection = true
+	node = NewNode(config, memory.NewMemory(memory.NewRing(1), config), remote.NewMemoryNetwork().Client("10.0.0.2"),
+		fake.NewClient(0, 4, 4096), &apiServerStub{})
+
+	assert.NoError(t, node.SetFaults(context.Background(), &types.Faults{Enabled: true}))
+	faults, err := node.Faults(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, faults.Enabled, "Faults not injected")
+}
@@ -0,0 +1,227 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	log "github.com/Sirupsen/logrus"
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/configuration"
+	"github.com/strabox/caravela/node/common"
+	"github.com/strabox/caravela/node/common/guid"
+	"github.com/strabox/caravela/overlay"
+	"github.com/strabox/caravela/util"
+	"sync"
+)
+
+// Quota manager is responsible for enforcing the users' resources quotas in the whole system.
+// The usage of each user is kept by the node responsible (in the overlay) for the user's key, which checks the
+// reservations against the quota, and it is replicated into the following nodes of the key's lookup, so a user
+// can't escape its quota submitting the containers through different nodes.
+// Each node keeps, per user, the usage reserved through each (origin) node and only the changes are replicated,
+// so concurrent reservations made through different nodes do not overwrite each other and a node can only release
+// the usage it reserved itself.
+type Manager struct {
+	common.NodeComponent // Base component.
+
+	config       *configuration.Configuration // System's configurations.
+	overlay      overlay.Overlay              // Overlay component.
+	remoteClient quotaRemoteClient            // Client to collaborate with other CARAVELA's nodes.
+
+	usageMutex sync.Mutex                             // Mutex to control access to the users' usage.
+	usage      map[string]map[string]types.QuotaUsage // User->Origin node's IP->Usage reserved through the node.
+}
+
+// NewManager creates a new quota manager component.
+func NewManager(config *configuration.Configuration, overlay overlay.Overlay, remoteClient quotaRemoteClient) *Manager {
+	return &Manager{
+		config:       config,
+		overlay:      overlay,
+		remoteClient: remoteClient,
+
+		usageMutex: sync.Mutex{},
+		usage:      make(map[string]map[string]types.QuotaUsage),
+	}
+}
+
+// Reserve reserves the resources in the user's quota, it fails if the quota does not allow them.
+// The anonymous user (empty ID) has no quota.
+func (m *Manager) Reserve(ctx context.Context, userID string, resources types.QuotaUsage) error {
+	if userID == "" {
+		return nil
+	}
+	_, err := m.update(ctx, userID, resources)
+	return err
+}
+
+// Release gives back the resources to the user's quota.
+func (m *Manager) Release(ctx context.Context, userID string, resources types.QuotaUsage) {
+	if userID == "" {
+		return
+	}
+	if _, err := m.update(ctx, userID, resources.Negate()); err != nil {
+		log.Errorf(util.LogTag("QUOTA")+"Release for user %s failed, error: %s", userID, err)
+	}
+}
+
+// Quota returns the user's quota, with its usage in the whole system.
+func (m *Manager) Quota(ctx context.Context, userID string) (*types.UserQuota, error) {
+	for _, node := range m.responsibleNodes(ctx, userID) {
+		if node.IP == m.config.HostIP() {
+			return m.LocalQuota(userID), nil
+		}
+		if userQuota, err := m.remoteClient.GetQuota(ctx, m.localNode(), node, userID); err == nil {
+			return userQuota, nil
+		}
+	}
+	return nil, errors.New("impossible reach the nodes responsible for the quota")
+}
+
+// update applies the change in the node responsible for the user's quota, the first reachable one,
+// and replicates the change into the remaining ones.
+func (m *Manager) update(ctx context.Context, userID string, resources types.QuotaUsage) (*types.UserQuota, error) {
+	nodes := m.responsibleNodes(ctx, userID)
+	for i, node := range nodes {
+		var userQuota *types.UserQuota
+		var err error
+		if node.IP == m.config.HostIP() {
+			userQuota, err = m.ReserveQuota(m.localNode(), userID, resources)
+		} else {
+			userQuota, err = m.remoteClient.ReserveQuota(ctx, m.localNode(), node, userID, resources)
+		}
+
+		if _, exceeded := err.(*types.QuotaExceededError); exceeded {
+			return nil, err
+		} else if err != nil {
+			continue // Unreachable, the next node holds a replica of the usage.
+		}
+
+		for _, replica := range nodes[i+1:] {
+			if replica.IP == m.config.HostIP() {
+				m.ReplicateQuota(m.localNode(), userID, resources)
+			} else if err := m.remoteClient.ReplicateQuota(ctx, m.localNode(), replica, userID, resources); err != nil {
+				log.Debugf(util.LogTag("QUOTA")+"Replication to %s failed, error: %s", replica.IP, err)
+			}
+		}
+		return userQuota, nil
+	}
+	return nil, errors.New("impossible reach the nodes responsible for the quota")
+}
+
+// responsibleNodes returns the nodes (without repetitions) that keep the user's usage, the first is the responsible.
+func (m *Manager) responsibleNodes(ctx context.Context, userID string) []*types.Node {
+	return common.ResponsibleNodes(ctx, m.overlay, guid.NewGUIDHash("user:", userID))
+}
+
+// ReserveQuota applies the change, requested by the given node, to the user's usage kept in this node. The increments
+// are checked against the user's quota. It returns the resulting user's quota.
+func (m *Manager) ReserveQuota(fromNode *types.Node, userID string, resources types.QuotaUsage) (*types.UserQuota, error) {
+	if fromNode.IP == "" {
+		return nil, errors.New("unknown origin node")
+	}
+
+	m.usageMutex.Lock()
+	defer m.usageMutex.Unlock()
+
+	limits := m.limits(userID)
+	newUsage := m.totalUsage(userID).Add(resources)
+
+	exceeds := func(limit, delta, used int) bool {
+		return limit > 0 && delta > 0 && used > limit
+	}
+	if exceeds(limits.Containers, resources.Containers, newUsage.Containers) {
+		return nil, types.NewQuotaExceededError(userID, fmt.Sprintf("containers limit is %d", limits.Containers))
+	} else if exceeds(limits.CPUs, resources.CPUs, newUsage.CPUs) {
+		return nil, types.NewQuotaExceededError(userID, fmt.Sprintf("CPUs limit is %d", limits.CPUs))
+	} else if exceeds(limits.Memory, resources.Memory, newUsage.Memory) {
+		return nil, types.NewQuotaExceededError(userID, fmt.Sprintf("memory limit is %dMB", limits.Memory))
+	}
+
+	m.apply(fromNode.IP, userID, resources)
+	return types.NewUserQuota(userID, limits, m.totalUsage(userID)), nil
+}
+
+// ReplicateQuota applies, to the replica of the user's usage, the change made by the given node in the node
+// responsible for the user's quota.
+func (m *Manager) ReplicateQuota(fromNode *types.Node, userID string, resources types.QuotaUsage) {
+	if fromNode.IP == "" {
+		return
+	}
+
+	m.usageMutex.Lock()
+	defer m.usageMutex.Unlock()
+
+	m.apply(fromNode.IP, userID, resources)
+}
+
+// LocalQuota returns the user's quota with the usage kept in this node.
+func (m *Manager) LocalQuota(userID string) *types.UserQuota {
+	m.usageMutex.Lock()
+	defer m.usageMutex.Unlock()
+
+	return types.NewUserQuota(userID, m.limits(userID), m.totalUsage(userID))
+}
+
+// apply changes the usage reserved through the origin node. A node can only release the usage it reserved, and
+// releases that this node does not know (e.g. replica lost) can't make it negative.
+func (m *Manager) apply(originIP string, userID string, resources types.QuotaUsage) {
+	usage := m.usage[userID][originIP].Add(resources)
+	if usage.Containers < 0 {
+		usage.Containers = 0
+	}
+	if usage.CPUs < 0 {
+		usage.CPUs = 0
+	}
+	if usage.Memory < 0 {
+		usage.Memory = 0
+	}
+
+	if _, exist := m.usage[userID]; !exist {
+		m.usage[userID] = make(map[string]types.QuotaUsage)
+	}
+	if usage == (types.QuotaUsage{}) {
+		delete(m.usage[userID], originIP)
+	} else {
+		m.usage[userID][originIP] = usage
+	}
+}
+
+// totalUsage returns the user's usage reserved through all the nodes.
+func (m *Manager) totalUsage(userID string) types.QuotaUsage {
+	total := types.QuotaUsage{}
+	for _, usage := range m.usage[userID] {
+		total = total.Add(usage)
+	}
+	return total
+}
+
+// limits returns the user's configured quota.
+func (m *Manager) limits(userID string) types.QuotaUsage {
+	userQuota := m.config.Quota(userID)
+	return types.QuotaUsage{
+		Containers: userQuota.MaxContainers,
+		CPUs:       userQuota.MaxCPUs,
+		Memory:     userQuota.MaxMemory,
+	}
+}
+
+// localNode returns the representation of the local node used in the remote messages.
+func (m *Manager) localNode() *types.Node {
+	return &types.Node{IP: m.config.HostIP()}
+}
+
+// ===============================================================================
+// =							SubComponent Interface                           =
+// ===============================================================================
+
+func (m *Manager) Start() {
+	m.Started(m.config.Simulation(), func() { /* Do Nothing */ })
+}
+
+func (m *Manager) Stop() {
+	m.Stopped(func() { /* Do Nothing */ })
+}
+
+func (m *Manager) IsWorking() bool {
+	return m.Working()
+}
@@ -0,0 +1,156 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/configuration"
+	"github.com/strabox/caravela/overlay"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// overlayStub returns always the same nodes for any key, the first is the responsible for the key.
+type overlayStub struct {
+	overlay.Overlay
+	nodes []*overlay.OverlayNode
+}
+
+func (o *overlayStub) Lookup(context.Context, []byte) ([]*overlay.OverlayNode, error) {
+	return o.nodes, nil
+}
+
+// remoteClientStub delivers the quota messages directly to the managers of the other nodes.
+type remoteClientStub struct {
+	managers map[string]*Manager
+	down     map[string]bool
+}
+
+func (r *remoteClientStub) ReserveQuota(_ context.Context, fromNode, toNode *types.Node, userID string,
+	resources types.QuotaUsage) (*types.UserQuota, error) {
+	if r.down[toNode.IP] {
+		return nil, errors.New("node unreachable")
+	}
+	return r.managers[toNode.IP].ReserveQuota(fromNode, userID, resources)
+}
+
+func (r *remoteClientStub) ReplicateQuota(_ context.Context, fromNode, toNode *types.Node, userID string,
+	resources types.QuotaUsage) error {
+	if r.down[toNode.IP] {
+		return errors.New("node unreachable")
+	}
+	r.managers[toNode.IP].ReplicateQuota(fromNode, userID, resources)
+	return nil
+}
+
+func (r *remoteClientStub) GetQuota(_ context.Context, _, toNode *types.Node, userID string) (*types.UserQuota, error) {
+	if r.down[toNode.IP] {
+		return nil, errors.New("node unreachable")
+	}
+	return r.managers[toNode.IP].LocalQuota(userID), nil
+}
+
+func TestManager_ReserveQuota_WithinLimits(t *testing.T) {
+	config := configuration.Default("10.0.0.1")
+	config.Caravela.Quotas.MaxContainers = 2
+	config.Caravela.Quotas.MaxCPUs = 4
+	manager := NewManager(config, nil, nil)
+
+	userQuota, err := manager.ReserveQuota(&types.Node{IP: "10.0.0.2"}, "alice",
+		types.QuotaUsage{Containers: 2, CPUs: 4, Memory: 2048})
+
+	assert.Nil(t, err, "Reservation within the quota should succeed")
+	assert.Equal(t, types.QuotaUsage{Containers: 2, CPUs: 4, Memory: 2048}, userQuota.Usage)
+	assert.Equal(t, types.QuotaUsage{Containers: 0, CPUs: 0, Memory: -1}, userQuota.Remaining)
+}
+
+func TestManager_ReserveQuota_Exceeded(t *testing.T) {
+	config := configuration.Default("10.0.0.1")
+	config.Caravela.Quotas.MaxContainers = 2
+	config.Caravela.Quotas.MaxCPUs = 4
+	manager := NewManager(config, nil, nil)
+	manager.ReserveQuota(&types.Node{IP: "10.0.0.2"}, "alice", types.QuotaUsage{Containers: 1, CPUs: 3})
+
+	_, err := manager.ReserveQuota(&types.Node{IP: "10.0.0.3"}, "alice", types.QuotaUsage{Containers: 1, CPUs: 2})
+
+	assert.IsType(t, &types.QuotaExceededError{}, err, "CPUs quota should be enforced across the origin nodes")
+	assert.Equal(t, types.QuotaUsage{Containers: 1, CPUs: 3}, manager.LocalQuota("alice").Usage,
+		"Rejected reservation should not change the usage")
+}
+
+func TestManager_ReserveQuota_Release(t *testing.T) {
+	config := configuration.Default("10.0.0.1")
+	config.Caravela.Quotas.MaxContainers = 2
+	config.Caravela.Quotas.MaxCPUs = 4
+	manager := NewManager(config, nil, nil)
+	manager.ReserveQuota(&types.Node{IP: "10.0.0.2"}, "alice", types.QuotaUsage{Containers: 2, CPUs: 2})
+
+	_, err := manager.ReserveQuota(&types.Node{IP: "10.0.0.2"}, "alice",
+		types.QuotaUsage{Containers: 3, CPUs: 3}.Negate())
+
+	assert.Nil(t, err, "Releases should never be rejected")
+	assert.Equal(t, types.QuotaUsage{}, manager.LocalQuota("alice").Usage, "Usage can't be negative")
+}
+
+func TestManager_ReserveQuota_OtherOrigin(t *testing.T) {
+	config := configuration.Default("10.0.0.1")
+	config.Caravela.Quotas.MaxContainers = 2
+	manager := NewManager(config, nil, nil)
+	manager.ReserveQuota(&types.Node{IP: "10.0.0.2"}, "alice", types.QuotaUsage{Containers: 2, CPUs: 2})
+
+	manager.ReserveQuota(&types.Node{IP: "10.0.0.3"}, "alice", types.QuotaUsage{Containers: 2, CPUs: 2}.Negate())
+	_, err := manager.ReserveQuota(&types.Node{}, "alice", types.QuotaUsage{Containers: 1})
+
+	assert.Equal(t, types.QuotaUsage{Containers: 2, CPUs: 2}, manager.LocalQuota("alice").Usage,
+		"Node released the usage reserved through another node")
+	assert.Error(t, err, "Reservation from an unknown node accepted")
+}
+
+func TestManager_ReserveQuota_UserOverride(t *testing.T) {
+	config := configuration.Default("10.0.0.1")
+	config.Caravela.Quotas.MaxContainers = 2
+	config.Caravela.Quotas.MaxCPUs = 4
+	config.Caravela.Quotas.Users = []configuration.UserQuota{{Name: "admin"}}
+	manager := NewManager(config, nil, nil)
+
+	_, err := manager.ReserveQuota(&types.Node{IP: "10.0.0.2"}, "admin", types.QuotaUsage{Containers: 10, CPUs: 40})
+
+	assert.Nil(t, err, "User's specific quota should override the defaults")
+}
+
+func TestManager_Reserve_Replication(t *testing.T) {
+	nodesIPs := []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}
+	overlayNodes := make([]*overlay.OverlayNode, 0)
+	for i, nodeIP := range nodesIPs {
+		overlayNodes = append(overlayNodes, overlay.NewOverlayNode(nodeIP, 8000, []byte{byte(i)}))
+	}
+	remoteClient := &remoteClientStub{managers: make(map[string]*Manager), down: make(map[string]bool)}
+	for _, nodeIP := range nodesIPs {
+		config := configuration.Default(nodeIP)
+		config.Caravela.Quotas.MaxContainers = 3
+		remoteClient.managers[nodeIP] = NewManager(config, &overlayStub{nodes: overlayNodes}, remoteClient)
+	}
+	ctx := context.Background()
+
+	// Reservations made through different nodes must add up in every replica.
+	err1 := remoteClient.managers["10.0.0.2"].Reserve(ctx, "alice", types.QuotaUsage{Containers: 1, CPUs: 1})
+	err2 := remoteClient.managers["10.0.0.3"].Reserve(ctx, "alice", types.QuotaUsage{Containers: 1, CPUs: 2})
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	for _, nodeIP := range nodesIPs {
+		assert.Equal(t, types.QuotaUsage{Containers: 2, CPUs: 3}, remoteClient.managers[nodeIP].LocalQuota("alice").Usage,
+			"Replica of %s diverged", nodeIP)
+	}
+
+	// The replica takes over when the responsible node is unreachable.
+	remoteClient.down["10.0.0.1"] = true
+	remoteClient.managers["10.0.0.3"].Release(ctx, "alice", types.QuotaUsage{Containers: 1, CPUs: 2})
+	err := remoteClient.managers["10.0.0.2"].Reserve(ctx, "alice", types.QuotaUsage{Containers: 3})
+	userQuota, quotaErr := remoteClient.managers["10.0.0.3"].Quota(ctx, "alice")
+
+	assert.IsType(t, &types.QuotaExceededError{}, err, "Replica should enforce the quota")
+	assert.NoError(t, quotaErr)
+	assert.Equal(t, types.QuotaUsage{Containers: 1, CPUs: 1}, userQuota.Usage)
+	assert.Equal(t, types.QuotaUsage{Containers: 1, CPUs: 1}, remoteClient.managers["10.0.0.3"].LocalQuota("alice").Usage)
+}
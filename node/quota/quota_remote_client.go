@@ -0,0 +1,13 @@
+package quota
+
+import (
+	"context"
+	"github.com/strabox/caravela/api/types"
+)
+
+// Interface that provides the necessary methods to talk with other nodes.
+type quotaRemoteClient interface {
+	ReserveQuota(ctx context.Context, fromNode, toNode *types.Node, userID string, resources types.QuotaUsage) (*types.UserQuota, error)
+	ReplicateQuota(ctx context.Context, fromNode, toNode *types.Node, userID string, resources types.QuotaUsage) error
+	GetQuota(ctx context.Context, fromNode, toNode *types.Node, userID string) (*types.UserQuota, error)
+}
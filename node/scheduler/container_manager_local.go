@@ -6,6 +6,6 @@ import (
 )
 
 type containerManagerLocal interface {
-	StartContainer(fromBuyer *types.Node, offer *types.Offer, containersConfigs []types.ContainerConfig,
+	StartContainer(fromBuyer *types.Node, userID string, offer *types.Offer, containersConfigs []types.ContainerConfig,
 		totalResourcesNecessary resources.Resources) ([]types.ContainerStatus, error)
 }
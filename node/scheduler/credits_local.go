@@ -0,0 +1,11 @@
+package scheduler
+
+import (
+	"context"
+	"github.com/strabox/caravela/api/types"
+)
+
+type creditsLocal interface {
+	Admit(ctx context.Context, buyerIP string) error
+	Pay(ctx context.Context, supplierIP string, userID string, containersStatus []types.ContainerStatus)
+}
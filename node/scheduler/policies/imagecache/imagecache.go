@@ -0,0 +1,40 @@
+package imagecache
+
+import (
+	"github.com/strabox/caravela/node/common/resources"
+	"github.com/strabox/caravela/node/scheduler/policies"
+	"github.com/strabox/caravela/util"
+	"github.com/strabox/caravela/util/bloom"
+	"sort"
+)
+
+// Weight added to an offer for each requested image that its supplier already holds.
+// It is bigger than any resources weight, so suppliers holding more images are always preferred.
+const imageHeldWeight = 1000
+
+// SchedulePolicy implements the SchedulePolicy interface.
+// This policy prefers the suppliers that already hold the requested images (avoiding pulling them),
+// between suppliers holding the same number of images it behaves like the binpack policy.
+type SchedulePolicy struct {
+	policies.BaseSchedulePolicy
+}
+
+// NewImageCacheSchedulePolicy creates a new image cache aware schedule policy.
+func NewImageCacheSchedulePolicy() (policies.SchedulingPolicy, error) {
+	return &SchedulePolicy{}, nil
+}
+
+func (s *SchedulePolicy) Rank(availableOffers policies.WeightedOffers, necessaryResources resources.Resources,
+	imageKeys []string) policies.WeightedOffers {
+	suitableOffers := s.WeightOffers(availableOffers, necessaryResources)
+	for i := range suitableOffers {
+		imagesFilter := bloom.NewFilterBytes(suitableOffers[i].ImagesFilter)
+		for _, imageKey := range imageKeys {
+			if imagesFilter.Contains(util.NormalizeImageKey(imageKey)) {
+				suitableOffers[i].Weight += imageHeldWeight
+			}
+		}
+	}
+	sort.Sort(sort.Reverse(suitableOffers))
+	return suitableOffers
+}
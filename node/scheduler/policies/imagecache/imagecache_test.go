@@ -0,0 +1,62 @@
+package imagecache
+
+import (
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/node/common/resources"
+	"github.com/strabox/caravela/node/scheduler/policies"
+	"github.com/strabox/caravela/util/bloom"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSchedulePolicy_Rank(t *testing.T) {
+	policy, _ := NewImageCacheSchedulePolicy()
+	redisFilter := bloom.NewFilter(128)
+	redisFilter.Add("redis:alpine")
+	offers := policies.WeightedOffers{
+		{SupplierIP: "10.0.0.1", Offer: types.Offer{
+			FreeResources: types.Resources{CPUs: 2, Memory: 1024},
+			UsedResources: types.Resources{CPUs: 2, Memory: 0},
+			ImagesFilter:  bloom.NewFilter(128).Bytes(),
+		}},
+		{SupplierIP: "10.0.0.2", Offer: types.Offer{
+			FreeResources: types.Resources{CPUs: 4, Memory: 1024},
+			UsedResources: types.Resources{CPUs: 0, Memory: 0},
+			ImagesFilter:  redisFilter.Bytes(),
+		}},
+		{SupplierIP: "10.0.0.3", Offer: types.Offer{
+			FreeResources: types.Resources{CPUs: 3, Memory: 1024},
+			UsedResources: types.Resources{CPUs: 1, Memory: 0},
+			ImagesFilter:  bloom.NewFilter(128).Bytes(),
+		}},
+	}
+
+	ranked := policy.Rank(offers, *resources.NewResourcesCPUClass(0, 1, 256), []string{"redis:alpine"})
+
+	assert.Len(t, ranked, 3, "Number of ranked offers is incorrect!")
+	assert.Equal(t, "10.0.0.2", ranked[0].SupplierIP, "Supplier holding the image should be the first!")
+	assert.Equal(t, "10.0.0.1", ranked[1].SupplierIP, "Remaining offers should be ranked as binpack!")
+	assert.Equal(t, "10.0.0.3", ranked[2].SupplierIP, "Remaining offers should be ranked as binpack!")
+}
+
+func TestSchedulePolicy_Rank_NormalizedKey(t *testing.T) {
+	policy, _ := NewImageCacheSchedulePolicy()
+	nginxFilter := bloom.NewFilter(128)
+	nginxFilter.Add("nginx:latest")
+	offers := policies.WeightedOffers{
+		{SupplierIP: "10.0.0.1", Offer: types.Offer{
+			FreeResources: types.Resources{CPUs: 2, Memory: 1024},
+			UsedResources: types.Resources{CPUs: 2, Memory: 0},
+			ImagesFilter:  bloom.NewFilter(128).Bytes(),
+		}},
+		{SupplierIP: "10.0.0.2", Offer: types.Offer{
+			FreeResources: types.Resources{CPUs: 4, Memory: 1024},
+			UsedResources: types.Resources{CPUs: 0, Memory: 0},
+			ImagesFilter:  nginxFilter.Bytes(),
+		}},
+	}
+
+	ranked := policy.Rank(offers, *resources.NewResourcesCPUClass(0, 1, 256), []string{"nginx"})
+
+	assert.Equal(t, "10.0.0.2", ranked[0].SupplierIP, "Image key without tag should match the latest tag!")
+}
@@ -7,6 +7,6 @@ import (
 // SchedulingPolicy is an interface that can be implemented in order to provide different criteria to rank a given set
 // of offers in a different way.
 type SchedulingPolicy interface {
-	// Sort the given availableOffers knowing the necessary resources for the deployment.
-	Rank(availableOffers WeightedOffers, necessaryResources resources.Resources) WeightedOffers
+	// Sort the given availableOffers knowing the necessary resources and the images keys of the deployment.
+	Rank(availableOffers WeightedOffers, necessaryResources resources.Resources, imageKeys []string) WeightedOffers
 }
@@ -17,7 +17,7 @@ func NewSpreadSchedulePolicy() (policies.SchedulingPolicy, error) {
 	return &SchedulePolicy{}, nil
 }
 
-func (s *SchedulePolicy) Rank(availableOffers policies.WeightedOffers, necessaryResources resources.Resources) policies.WeightedOffers {
+func (s *SchedulePolicy) Rank(availableOffers policies.WeightedOffers, necessaryResources resources.Resources, _ []string) policies.WeightedOffers {
 	suitableOffers := s.WeightOffers(availableOffers, necessaryResources)
 	sort.Sort(suitableOffers)
 	return suitableOffers
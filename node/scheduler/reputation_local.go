@@ -0,0 +1,13 @@
+package scheduler
+
+type reputationLocal interface {
+	LaunchSucceeded(supplierIP string)
+	LaunchFailed(supplierIP string)
+	LaunchTimedOut(supplierIP string)
+	Score(supplierIP string) float64
+}
+
+// timeoutError is implemented by the remote client's errors that know if they were caused by a timeout.
+type timeoutError interface {
+	Timeout() bool
+}
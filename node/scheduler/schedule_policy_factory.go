@@ -6,6 +6,7 @@ import (
 	"github.com/strabox/caravela/configuration"
 	"github.com/strabox/caravela/node/scheduler/policies"
 	"github.com/strabox/caravela/node/scheduler/policies/binpack"
+	"github.com/strabox/caravela/node/scheduler/policies/imagecache"
 	"github.com/strabox/caravela/node/scheduler/policies/spread"
 	"strings"
 )
@@ -20,6 +21,7 @@ var schedulePolicies = make(map[string]SchedulePolicyFactory)
 func init() {
 	RegisterSchedulePolicy("binpack", binpack.NewBinPackSchedulePolicy)
 	RegisterSchedulePolicy("spread", spread.NewSpreadSchedulePolicy)
+	RegisterSchedulePolicy("image-cache", imagecache.NewImageCacheSchedulePolicy)
 
 }
 
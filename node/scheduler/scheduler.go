@@ -24,17 +24,22 @@ type Scheduler struct {
 
 	discovery         discoveryLocal        // Local Discovery component.
 	containersManager containerManagerLocal // Local Containers Manager component.
+	credits           creditsLocal          // Local Credits component.
+	reputations       reputationLocal       // Reputation of the suppliers.
 }
 
 // NewScheduler creates a new local scheduler component.
 func NewScheduler(config *configuration.Configuration, internalDisc discoveryLocal,
-	containersManager containerManagerLocal, client userRemoteClient) *Scheduler {
+	containersManager containerManagerLocal, credits creditsLocal, reputations reputationLocal,
+	client userRemoteClient) *Scheduler {
 
 	return &Scheduler{
 		config:            config,
 		client:            client,
 		discovery:         internalDisc,
 		containersManager: containersManager,
+		credits:           credits,
+		reputations:       reputations,
 	}
 }
 
@@ -50,6 +55,11 @@ func (s *Scheduler) Launch(ctx context.Context, fromBuyer *types.Node, offer *ty
 		return make([]types.ContainerStatus, 0), errors.New("no container configurations")
 	}
 
+	if err := s.credits.Admit(ctx, fromBuyer.IP); err != nil {
+		log.Debugf(util.LogTag("SCHEDULE")+"Launch REFUSED, Buyer: %s, error: %s", fromBuyer.IP, err)
+		return nil, err
+	}
+
 	totalResourcesNecessary := resources.NewResources(0, 0)
 	for i, contConfig := range containersConfigs {
 		log.Debugf(util.LogTag("SCHEDULE")+"Launching... [%d] Img: %s, Res: <%d,%d>", i, contConfig.ImageKey,
@@ -57,7 +67,8 @@ func (s *Scheduler) Launch(ctx context.Context, fromBuyer *types.Node, offer *ty
 		totalResourcesNecessary.Add(*resources.NewResources(contConfig.Resources.CPUs, contConfig.Resources.Memory))
 	}
 
-	containerStatus, err := s.containersManager.StartContainer(fromBuyer, offer, containersConfigs, *totalResourcesNecessary)
+	containerStatus, err := s.containersManager.StartContainer(fromBuyer, types.UserID(ctx), offer, containersConfigs,
+		*totalResourcesNecessary)
 	return containerStatus, err
 }
 
@@ -104,7 +115,8 @@ func (s *Scheduler) SubmitContainers(ctx context.Context, contConfigs []types.Co
 		containersStatus, err := s.launchContainers(ctx, []types.ContainerConfig{contConfig}, *resourcesNecessary)
 		if err != nil {
 			for i := range resContainersStatus { // Stop all the previous launched containers
-				s.client.StopLocalContainer(ctx, &types.Node{IP: resContainersStatus[i].SupplierIP}, resContainersStatus[i].ContainerID)
+				s.client.StopLocalContainer(ctx, &types.Node{IP: s.config.HostIP()},
+					&types.Node{IP: resContainersStatus[i].SupplierIP}, resContainersStatus[i].ContainerID)
 			}
 			return nil, err
 		}
@@ -125,14 +137,21 @@ func (s *Scheduler) launchContainers(ctx context.Context, containersConfigs []ty
 		return resContainersStatus, nil
 	}
 
+	imageKeys := make([]string, len(containersConfigs))
+	for i, contConfig := range containersConfigs {
+		imageKeys[i] = contConfig.ImageKey
+	}
+
 	offers := s.discovery.FindOffers(ctx, resourcesNecessary)
-	offers = CreateSchedulePolicy(s.config).Rank(offers, resourcesNecessary) // Rank the offers according with the scheduling policy.
+	offers = CreateSchedulePolicy(s.config).Rank(offers, resourcesNecessary, imageKeys) // Rank the offers according with the scheduling policy.
+	offers = s.prioritizeReputable(offers)
 
 	if len(offers) == 0 {
 		log.Debugf(util.LogTag("SCHEDULE") + "Deploy FAILED. No offers found.")
 		return resContainersStatus, errors.New("no offers found to deploy")
 	}
 
+	var imageRejectedErr *types.ImageRejectedError // Set if a supplier's image policy rejected the image.
 	for offerIndex, offer := range offers {
 		log.Debugf(util.LogTag("SCHEDULE")+"Trying OFFER [#%d]... SuppIP: %s, Offer: %d, Amount %d, Res: <%d;%d>",
 			offerIndex, offer.SupplierIP, offer.ID, offer.Amount, offer.FreeResources.CPUs, offer.FreeResources.Memory)
@@ -145,8 +164,18 @@ func (s *Scheduler) launchContainers(ctx context.Context, containersConfigs []ty
 			containersConfigs)
 		if err != nil {
 			log.Debugf(util.LogTag("SCHEDULE")+"Deploy FAILED [#%d] Offer: %d error: %s", offerIndex, offer.ID, err)
+			if rejectedErr, ok := err.(*types.ImageRejectedError); ok {
+				imageRejectedErr = rejectedErr // Supplier's policy, it does not affect its reputation.
+			} else if timeoutErr, ok := err.(timeoutError); ok && timeoutErr.Timeout() {
+				s.reputations.LaunchTimedOut(offer.SupplierIP)
+			} else {
+				s.reputations.LaunchFailed(offer.SupplierIP)
+			}
 			if offerIndex == (len(offers) - 1) {
 				log.Debugf(util.LogTag("SCHEDULE") + "Deploy FAILED. No offers found.")
+				if imageRejectedErr != nil { // Give the user the reason why the image was not accepted.
+					return resContainersStatus, imageRejectedErr
+				}
 				return resContainersStatus, errors.New("all offers were reject to deploy")
 			}
 			continue
@@ -154,12 +183,35 @@ func (s *Scheduler) launchContainers(ctx context.Context, containersConfigs []ty
 
 		resContainersStatus = append(resContainersStatus, containersStatus...)
 		log.Debugf(util.LogTag("SCHEDULE") + "Deploy SUCCESS")
+		s.reputations.LaunchSucceeded(offer.SupplierIP)
+		s.credits.Pay(ctx, offer.SupplierIP, types.UserID(ctx), containersStatus)
 		break
 	}
 
 	return resContainersStatus, nil
 }
 
+// prioritizeReputable moves the offers of the suppliers with bad reputation to the end of the ranked offers, so
+// they are only tried when all the others fail. The ranking of the scheduling policy is kept otherwise.
+func (s *Scheduler) prioritizeReputable(offers []types.AvailableOffer) []types.AvailableOffer {
+	if !s.config.ReputationEnabled() {
+		return offers
+	}
+
+	reputable := make([]types.AvailableOffer, 0, len(offers))
+	unreputable := make([]types.AvailableOffer, 0)
+	for _, offer := range offers {
+		if s.reputations.Score(offer.SupplierIP) >= s.config.ReputationMinScore() {
+			reputable = append(reputable, offer)
+		} else {
+			log.Debugf(util.LogTag("SCHEDULE")+"Offer DEPRIORITIZED, bad reputation SuppIP: %s, Score: %.2f",
+				offer.SupplierIP, s.reputations.Score(offer.SupplierIP))
+			unreputable = append(unreputable, offer)
+		}
+	}
+	return append(reputable, unreputable...)
+}
+
 // ===============================================================================
 // =							SubComponent Interface                           =
 // ===============================================================================
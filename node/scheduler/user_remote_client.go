@@ -8,5 +8,5 @@ import (
 // Interface that provides the necessary methods to talk with other nodes.
 type userRemoteClient interface {
 	LaunchContainer(ctx context.Context, fromBuyer, toSupplier *types.Node, offer *types.Offer, containerConfig []types.ContainerConfig) ([]types.ContainerStatus, error)
-	StopLocalContainer(ctx context.Context, toSupplier *types.Node, containerID string) error
+	StopLocalContainer(ctx context.Context, fromBuyer, toSupplier *types.Node, containerID string) error
 }
@@ -7,19 +7,35 @@ import (
 )
 
 type deployedContainer struct {
-	*common.Container        // Base container
-	suppIP            string // IP of the supplier node
+	*common.Container                       // Base container
+	config            types.ContainerConfig // Configuration submitted, used to launch the container in other supplier
+	suppIP            string                // IP of the supplier node
+	userID            string                // User that owns the container
 }
 
-func newContainer(name, imageKey string, args []string, portMaps []types.PortMapping,
-	resources resources.Resources, id string, supplierIP string) *deployedContainer {
+// newContainer creates a container deployed with the given configuration. The pull credentials are only kept in
+// memory, because the container can't be rescheduled without them.
+func newContainer(contConfig types.ContainerConfig, id string, supplierIP string, userID string) *deployedContainer {
+	contResources := resources.NewResourcesCPUClass(int(contConfig.Resources.CPUClass), contConfig.Resources.CPUs,
+		contConfig.Resources.Memory)
 
 	return &deployedContainer{
-		Container: common.NewContainer(name, imageKey, args, portMaps, resources, id),
-		suppIP:    supplierIP,
+		Container: common.NewContainer(contConfig.Name, contConfig.ImageKey, contConfig.Args, contConfig.PortMappings,
+			*contResources, id),
+		config: contConfig,
+		suppIP: supplierIP,
+		userID: userID,
 	}
 }
 
+func (d *deployedContainer) submittedConfig() types.ContainerConfig {
+	return d.config
+}
+
 func (d *deployedContainer) supplierIP() string {
 	return d.suppIP
 }
+
+func (d *deployedContainer) owner() string {
+	return d.userID
+}
@@ -0,0 +1,7 @@
+package user
+
+import "context"
+
+type creditsLocal interface {
+	Settle(ctx context.Context, containerID string)
+}
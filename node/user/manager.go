@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"crypto/subtle"
 	log "github.com/Sirupsen/logrus"
 	"github.com/pkg/errors"
 	"github.com/strabox/caravela/api/types"
@@ -20,17 +21,24 @@ type Manager struct {
 	containers          sync.Map // Map ID<->Container submitted by the user
 	minRequestResources resources.Resources
 	localScheduler      localScheduler   // Container's scheduler component
+	quota               quotaLocal       // Users' quotas component
+	credits             creditsLocal     // Credits component
+	reputations         reputationLocal  // Reputation of the suppliers
 	userRemoteCli       userRemoteClient //
 
 	config *configuration.Configuration // System's configurations.
 }
 
-func NewManager(config *configuration.Configuration, localScheduler localScheduler, userRemoteCli userRemoteClient,
+func NewManager(config *configuration.Configuration, localScheduler localScheduler, quota quotaLocal,
+	credits creditsLocal, reputations reputationLocal, userRemoteCli userRemoteClient,
 	minRequestResources resources.Resources) *Manager {
 	return &Manager{
 		minRequestResources: minRequestResources,
 		config:              config,
 		localScheduler:      localScheduler,
+		quota:               quota,
+		credits:             credits,
+		reputations:         reputations,
 		userRemoteCli:       userRemoteCli,
 
 		containers: sync.Map{},
@@ -59,17 +67,29 @@ func (m *Manager) SubmitContainers(ctx context.Context, containerConfigs []types
 		}
 	}
 
+	// Reserve the containers' resources in the user's quota (in the whole system).
+	userID := types.UserID(ctx)
+	requested := types.QuotaUsage{Containers: len(containerConfigs)}
+	for _, contConfig := range containerConfigs {
+		requested.CPUs += contConfig.Resources.CPUs
+		requested.Memory += contConfig.Resources.Memory
+	}
+	if err := m.quota.Reserve(ctx, userID, requested); err != nil {
+		return nil, err
+	}
+
 	// Submit the request into the local scheduler.
 	containersStatus, err := m.localScheduler.SubmitContainers(ctx, containerConfigs)
 	if err != nil {
+		m.quota.Release(ctx, userID, requested)
 		return nil, err
 	}
 
 	// Update internals.
 	for _, contStatus := range containersStatus {
-		container := newContainer(contStatus.Name, contStatus.ImageKey, contStatus.Args, contStatus.PortMappings,
-			*resources.NewResourcesCPUClass(int(contStatus.Resources.CPUClass), contStatus.Resources.CPUs, contStatus.Resources.Memory), contStatus.ContainerID,
-			contStatus.SupplierIP)
+		contConfig := contStatus.ContainerConfig // The statuses never return the pull credentials.
+		contConfig.RegistryAuth = registryAuth(containerConfigs, contConfig.ImageKey)
+		container := newContainer(contConfig, contStatus.ContainerID, contStatus.SupplierIP, userID)
 
 		m.containers.Store(container.ShortID(), container)
 	}
@@ -80,13 +100,31 @@ func (m *Manager) SubmitContainers(ctx context.Context, containerConfigs []types
 func (m *Manager) StopContainers(ctx context.Context, containerIDs []string) error {
 	errMsg := "Failed to stop:"
 	fail := false
+	userID := types.UserID(ctx)
 	for _, contID := range containerIDs {
 		contTmp, contExist := m.containers.Load(contID[:common.ContainerShortIDSize])
 		container, ok := contTmp.(*deployedContainer)
-		if contExist && ok {
-			if err := m.userRemoteCli.StopLocalContainer(ctx, &types.Node{IP: container.supplierIP()}, container.ID()); err == nil {
+		if contExist && ok && container.owner() != userID { // Users can only stop their own containers.
+			fail = true
+			errMsg += " " + contID
+		} else if contExist && ok {
+			m.credits.Settle(ctx, container.ID())
+			err := m.userRemoteCli.StopLocalContainer(ctx, &types.Node{IP: m.config.HostIP()},
+				&types.Node{IP: container.supplierIP()}, container.ID())
+			notFoundErr, notFound := err.(*types.ContainerNotFoundError)
+			if err == nil || notFound {
+				// When the supplier does not have the container anymore (it exited) its resources are free too.
 				m.containers.Delete(contID[:common.ContainerShortIDSize])
+				contResources := container.Resources()
+				m.quota.Release(ctx, userID, types.QuotaUsage{Containers: 1, CPUs: contResources.CPUs(),
+					Memory: contResources.Memory()})
+				if notFound && !notFoundErr.Exited {
+					m.reputations.ContainerLost(container.supplierIP()) // Supplier lost the container.
+				}
 			} else {
+				if unavailableErr, ok := err.(unavailableError); ok && unavailableErr.Unavailable() {
+					m.reputations.ContainerLost(container.supplierIP()) // Supplier is unreachable.
+				}
 				fail = true
 				errMsg += " " + contID
 			}
@@ -102,11 +140,107 @@ func (m *Manager) StopContainers(ctx context.Context, containerIDs []string) err
 	return nil
 }
 
-func (m *Manager) ListContainers() []types.ContainerStatus {
+// RescheduleContainer moves a user's container, running in a supplier that is draining, to another supplier. The
+// container is launched in the new supplier before being stopped in the old one, in the background because it can
+// take longer than the supplier's request. The new container is reserved in the user's quota before being launched
+// and the old one is released after being stopped.
+func (m *Manager) RescheduleContainer(_ context.Context, fromSupplierIP string, containerID string) error {
+	if len(containerID) < common.ContainerShortIDSize {
+		return errors.New("invalid container ID")
+	}
+
+	contTmp, contExist := m.containers.Load(containerID[:common.ContainerShortIDSize])
+	container, ok := contTmp.(*deployedContainer)
+	if !contExist || !ok || container.supplierIP() != fromSupplierIP {
+		return errors.New("container does not exist in the supplier")
+	}
+
+	contResources := container.Resources()
+	contConfig := container.submittedConfig()
+	contUsage := types.QuotaUsage{Containers: 1, CPUs: contResources.CPUs(), Memory: contResources.Memory()}
+
+	move := func() {
+		ctx := context.WithValue(context.Background(), types.UserIDKey, container.owner())
+		if err := m.quota.Reserve(ctx, container.owner(), contUsage); err != nil {
+			log.Debugf(util.LogTag("USRMNG")+"RESCHEDULE container %s FAILED, error: %s", container.ShortID(), err)
+			return
+		}
+
+		containersStatus, err := m.localScheduler.SubmitContainers(ctx, []types.ContainerConfig{contConfig})
+		if err != nil {
+			m.quota.Release(ctx, container.owner(), contUsage)
+			log.Debugf(util.LogTag("USRMNG")+"RESCHEDULE container %s FAILED, error: %s", container.ShortID(), err)
+			return
+		}
+
+		for _, contStatus := range containersStatus {
+			newContainer := newContainer(contConfig, contStatus.ContainerID, contStatus.SupplierIP, container.owner())
+			m.containers.Store(newContainer.ShortID(), newContainer)
+			log.Debugf(util.LogTag("USRMNG")+"RESCHEDULED container %s, Supplier: %s -> %s (%s)",
+				container.ShortID(), fromSupplierIP, contStatus.SupplierIP, newContainer.ShortID())
+		}
+
+		// The old container is not tracked anymore, so its quota is released even if the supplier can't stop it.
+		m.containers.Delete(container.ShortID())
+		m.credits.Settle(ctx, container.ID())
+		err = m.userRemoteCli.StopLocalContainer(ctx, &types.Node{IP: m.config.HostIP()},
+			&types.Node{IP: fromSupplierIP}, container.ID())
+		if err != nil {
+			log.Debugf(util.LogTag("USRMNG")+"STOPPING rescheduled container %s, error: %s", container.ShortID(), err)
+		}
+		m.quota.Release(ctx, container.owner(), contUsage)
+	}
+
+	if m.config.Simulation() {
+		move()
+	} else {
+		go move()
+	}
+	return nil
+}
+
+// AdoptContainer re-establishes the ownership of a container that kept running while its supplier restarted. If the
+// node does not know the container anymore (e.g. it also restarted) it registers it again for the user, only if
+// the container fits in the user's quota.
+func (m *Manager) AdoptContainer(ctx context.Context, fromSupplierIP string, contStatus *types.ContainerStatus) error {
+	if len(contStatus.ContainerID) < common.ContainerShortIDSize {
+		return errors.New("invalid container ID")
+	}
+
+	contTmp, contExist := m.containers.Load(contStatus.ContainerID[:common.ContainerShortIDSize])
+	if container, ok := contTmp.(*deployedContainer); contExist && ok {
+		if container.supplierIP() != fromSupplierIP {
+			return errors.New("container is running in another supplier")
+		}
+		return nil
+	}
+
+	userID := types.UserID(ctx)
+	if len(m.config.Users()) > 0 && !m.isUser(userID) {
+		return errors.New("unknown user")
+	}
+	contUsage := types.QuotaUsage{Containers: 1, CPUs: contStatus.Resources.CPUs, Memory: contStatus.Resources.Memory}
+	if err := m.quota.Reserve(ctx, userID, contUsage); err != nil {
+		return err
+	}
+
+	container := newContainer(contStatus.ContainerConfig, contStatus.ContainerID, fromSupplierIP, userID)
+	if _, adopted := m.containers.LoadOrStore(container.ShortID(), container); adopted { // Adopted concurrently.
+		m.quota.Release(ctx, userID, contUsage)
+		return nil
+	}
+	log.Debugf(util.LogTag("USRMNG")+"ADOPTED container %s, Supplier: %s, User: %s", container.ShortID(),
+		fromSupplierIP, container.owner())
+	return nil
+}
+
+// ListContainers lists the containers of the user that made the request.
+func (m *Manager) ListContainers(ctx context.Context) []types.ContainerStatus {
 	res := make([]types.ContainerStatus, 0)
+	userID := types.UserID(ctx)
 
 	m.containers.Range(func(_, value interface{}) bool {
-		if container, ok := value.(*deployedContainer); ok {
+		if container, ok := value.(*deployedContainer); ok && container.owner() == userID {
 			res = append(res,
 				types.ContainerStatus{
 					ContainerConfig: types.ContainerConfig{
@@ -124,6 +258,55 @@ func (m *Manager) ListContainers() []types.ContainerStatus {
 	return res
 }
 
+// Authenticate returns the user identified by the API token. When the node has no users configured, every
+// request is made by the anonymous user (empty ID).
+func (m *Manager) Authenticate(token string) (string, bool) {
+	if len(m.config.Users()) == 0 {
+		return "", true
+	}
+
+	for _, user := range m.config.Users() {
+		if subtle.ConstantTimeCompare([]byte(user.Token), []byte(token)) == 1 {
+			return user.Name, true
+		}
+	}
+	return "", false
+}
+
+// isUser returns true if the user is one of the node's users.
+func (m *Manager) isUser(userID string) bool {
+	for _, user := range m.config.Users() {
+		if user.Name == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAdmin returns true if the user can administrate the node e.g. shut it down.
+func (m *Manager) IsAdmin(userID string) bool {
+	if len(m.config.Users()) == 0 {
+		return true
+	}
+
+	for _, user := range m.config.Users() {
+		if user.Name == userID {
+			return user.Admin
+		}
+	}
+	return false
+}
+
+// registryAuth returns the pull credentials submitted for the image, nil if the image is public.
+func registryAuth(containerConfigs []types.ContainerConfig, imageKey string) *types.RegistryAuth {
+	for _, contConfig := range containerConfigs {
+		if contConfig.ImageKey == imageKey && contConfig.RegistryAuth != nil {
+			return contConfig.RegistryAuth
+		}
+	}
+	return nil
+}
+
 // ===============================================================================
 // =							SubComponent Interface                           =
 // ===============================================================================
@@ -148,6 +331,7 @@ func (m *Manager) DebugSizeBytes() int {
 	deployedContainerSize := func(container *deployedContainer) uintptr {
 		contSizeBytes := unsafe.Sizeof(*container)
 		contSizeBytes += debug.SizeofString(container.suppIP)
+		contSizeBytes += debug.SizeofString(container.userID)
 		// common.Container
 		contSizeBytes += unsafe.Sizeof(*container.Container)
 		contSizeBytes += debug.SizeofString(container.Name())
@@ -0,0 +1,274 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"github.com/strabox/caravela/api/remote"
+	"github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/configuration"
+	"github.com/strabox/caravela/node/common/resources"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const aliceContainerIDTest = "aaaaaaaaaaaa0123456789abcdef0123456789abcdef0123456789abcdef0123"
+const bobContainerIDTest = "bbbbbbbbbbbb0123456789abcdef0123456789abcdef0123456789abcdef0123"
+
+// remoteClientStub records the stop container messages sent to the suppliers.
+type remoteClientStub struct {
+	stopped []string
+	stopErr error
+}
+
+func (r *remoteClientStub) StopLocalContainer(_ context.Context, _, _ *types.Node, containerID string) error {
+	if r.stopErr != nil {
+		return r.stopErr
+	}
+	r.stopped = append(r.stopped, containerID)
+	return nil
+}
+
+// quotaStub keeps the resources reserved by each user, refusing more containers than the maximum (if set).
+type quotaStub struct {
+	reserved      map[string]types.QuotaUsage
+	maxContainers int
+}
+
+func (q *quotaStub) Reserve(_ context.Context, userID string, usage types.QuotaUsage) error {
+	reserved := q.reserved[userID]
+	if q.maxContainers > 0 && reserved.Containers+usage.Containers > q.maxContainers {
+		return types.NewQuotaExceededError(userID, "containers limit reached")
+	}
+	reserved.Containers += usage.Containers
+	reserved.CPUs += usage.CPUs
+	reserved.Memory += usage.Memory
+	q.reserved[userID] = reserved
+	return nil
+}
+
+func (q *quotaStub) Release(_ context.Context, userID string, usage types.QuotaUsage) {
+	reserved := q.reserved[userID]
+	reserved.Containers -= usage.Containers
+	reserved.CPUs -= usage.CPUs
+	reserved.Memory -= usage.Memory
+	q.reserved[userID] = reserved
+}
+
+// schedulerStub launches the containers in the same supplier, recording the configurations submitted.
+type schedulerStub struct {
+	supplierIP  string
+	containerID string
+	submitted   []types.ContainerConfig
+}
+
+func (s *schedulerStub) SubmitContainers(_ context.Context, containersConfigs []types.ContainerConfig) ([]types.ContainerStatus, error) {
+	res := make([]types.ContainerStatus, 0)
+	for _, contConfig := range containersConfigs {
+		s.submitted = append(s.submitted, contConfig)
+		contConfig.RegistryAuth = nil // The suppliers never return the pull credentials.
+		res = append(res, types.ContainerStatus{ContainerConfig: contConfig, SupplierIP: s.supplierIP,
+			ContainerID: s.containerID})
+	}
+	return res, nil
+}
+
+// creditsStub records the containers settled.
+type creditsStub struct {
+	settled []string
+}
+
+func (c *creditsStub) Settle(_ context.Context, containerID string) {
+	c.settled = append(c.settled, containerID)
+}
+
+// reputationStub counts the containers lost by each supplier.
+type reputationStub struct {
+	lost map[string]int
+}
+
+func (r *reputationStub) ContainerLost(supplierIP string) {
+	r.lost[supplierIP]++
+}
+
+func TestManager_ListContainers_UserScope(t *testing.T) {
+	manager := NewManager(configuration.Default("10.0.0.1"), nil, &quotaStub{reserved: map[string]types.QuotaUsage{}},
+		&creditsStub{}, &reputationStub{lost: map[string]int{}}, &remoteClientStub{}, *resources.NewResourcesCPUClass(0, 1, 256))
+	manager.containers.Store(aliceContainerIDTest[:12], newContainer(types.ContainerConfig{Name: "alice-redis",
+		ImageKey: "redis", Resources: types.Resources{CPUs: 1, Memory: 256}}, aliceContainerIDTest, "10.0.0.2", "alice"))
+	manager.containers.Store(bobContainerIDTest[:12], newContainer(types.ContainerConfig{Name: "bob-redis",
+		ImageKey: "redis", Resources: types.Resources{CPUs: 1, Memory: 256}}, bobContainerIDTest, "10.0.0.3", "bob"))
+
+	aliceContainers := manager.ListContainers(context.WithValue(context.Background(), types.UserIDKey, "alice"))
+	anonymousContainers := manager.ListContainers(context.Background())
+
+	assert.Len(t, aliceContainers, 1, "User must only see its own containers")
+	assert.Equal(t, "alice-redis", aliceContainers[0].Name)
+	assert.Equal(t, "10.0.0.2", aliceContainers[0].SupplierIP)
+	assert.Empty(t, anonymousContainers, "Anonymous user must not see the users' containers")
+}
+
+func TestManager_StopContainers_OtherUser(t *testing.T) {
+	remoteClient := &remoteClientStub{}
+	manager := NewManager(configuration.Default("10.0.0.1"), nil, &quotaStub{reserved: map[string]types.QuotaUsage{}},
+		&creditsStub{}, &reputationStub{lost: map[string]int{}}, remoteClient, *resources.NewResourcesCPUClass(0, 1, 256))
+	manager.containers.Store(aliceContainerIDTest[:12], newContainer(types.ContainerConfig{Name: "alice-redis",
+		ImageKey: "redis", Resources: types.Resources{CPUs: 1, Memory: 256}}, aliceContainerIDTest, "10.0.0.2", "alice"))
+
+	err := manager.StopContainers(context.WithValue(context.Background(), types.UserIDKey, "bob"),
+		[]string{aliceContainerIDTest})
+
+	assert.Error(t, err, "User stopped other user's container")
+	assert.Empty(t, remoteClient.stopped, "Stop message sent for other user's container")
+
+	err = manager.StopContainers(context.WithValue(context.Background(), types.UserIDKey, "alice"),
+		[]string{aliceContainerIDTest})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{aliceContainerIDTest}, remoteClient.stopped)
+	assert.Empty(t, manager.ListContainers(context.WithValue(context.Background(), types.UserIDKey, "alice")))
+}
+
+func TestManager_StopContainers_Exited(t *testing.T) {
+	quota := &quotaStub{reserved: map[string]types.QuotaUsage{"alice": {Containers: 1, CPUs: 1, Memory: 256}}}
+	reputations := &reputationStub{lost: map[string]int{}}
+	manager := NewManager(configuration.Default("10.0.0.1"), nil, quota, &creditsStub{}, reputations,
+		&remoteClientStub{stopErr: types.NewContainerNotFoundError(aliceContainerIDTest, true)},
+		*resources.NewResourcesCPUClass(0, 1, 256))
+	manager.containers.Store(aliceContainerIDTest[:12], newContainer(types.ContainerConfig{Name: "alice-redis",
+		ImageKey: "redis", Resources: types.Resources{CPUs: 1, Memory: 256}}, aliceContainerIDTest, "10.0.0.2", "alice"))
+
+	err := manager.StopContainers(context.WithValue(context.Background(), types.UserIDKey, "alice"),
+		[]string{aliceContainerIDTest})
+
+	assert.NoError(t, err, "Container that exited should be stopped")
+	assert.Equal(t, types.QuotaUsage{}, quota.reserved["alice"], "Quota of the exited container not released")
+	assert.Empty(t, reputations.lost, "Supplier penalized for a container that exited")
+	assert.Empty(t, manager.ListContainers(context.WithValue(context.Background(), types.UserIDKey, "alice")))
+}
+
+func TestManager_StopContainers_Lost(t *testing.T) {
+	reputations := &reputationStub{lost: map[string]int{}}
+	remoteClient := &remoteClientStub{stopErr: errors.New("impossible stop container")}
+	manager := NewManager(configuration.Default("10.0.0.1"), nil, &quotaStub{reserved: map[string]types.QuotaUsage{}},
+		&creditsStub{}, reputations, remoteClient, *resources.NewResourcesCPUClass(0, 1, 256))
+	manager.containers.Store(aliceContainerIDTest[:12], newContainer(types.ContainerConfig{Name: "alice-redis",
+		ImageKey: "redis", Resources: types.Resources{CPUs: 1, Memory: 256}}, aliceContainerIDTest, "10.0.0.2", "alice"))
+	ctx := context.WithValue(context.Background(), types.UserIDKey, "alice")
+
+	manager.StopContainers(ctx, []string{aliceContainerIDTest})
+	assert.Empty(t, reputations.lost, "Supplier penalized for an error that does not show the container is lost")
+
+	remoteClient.stopErr = remote.NewRemoteClientError(errors.New("No connection to 10.0.0.2"))
+	manager.StopContainers(ctx, []string{aliceContainerIDTest})
+	assert.Equal(t, 1, reputations.lost["10.0.0.2"], "Unreachable supplier not penalized")
+
+	remoteClient.stopErr = types.NewContainerNotFoundError(aliceContainerIDTest, false)
+	manager.StopContainers(ctx, []string{aliceContainerIDTest})
+	assert.Equal(t, 2, reputations.lost["10.0.0.2"], "Supplier that lost the container not penalized")
+}
+
+func TestManager_RescheduleContainer(t *testing.T) {
+	config := configuration.Default("10.0.0.1")
+	config.Caravela.Simulation = true
+	quota := &quotaStub{reserved: map[string]types.QuotaUsage{"alice": {Containers: 1, CPUs: 1, Memory: 256}}}
+	credits := &creditsStub{}
+	remoteClient := &remoteClientStub{}
+	manager := NewManager(config, &schedulerStub{supplierIP: "10.0.0.3", containerID: bobContainerIDTest}, quota,
+		credits, &reputationStub{lost: map[string]int{}}, remoteClient, *resources.NewResourcesCPUClass(0, 1, 256))
+	manager.containers.Store(aliceContainerIDTest[:12], newContainer(types.ContainerConfig{Name: "alice-redis",
+		ImageKey: "redis", Resources: types.Resources{CPUs: 1, Memory: 256}}, aliceContainerIDTest, "10.0.0.2", "alice"))
+
+	assert.Error(t, manager.RescheduleContainer(context.Background(), "10.0.0.2", "aaaa"), "Short ID accepted")
+	assert.Error(t, manager.RescheduleContainer(context.Background(), "10.0.0.4", aliceContainerIDTest),
+		"Container rescheduled by other supplier")
+	assert.NoError(t, manager.RescheduleContainer(context.Background(), "10.0.0.2", aliceContainerIDTest))
+
+	aliceContainers := manager.ListContainers(context.WithValue(context.Background(), types.UserIDKey, "alice"))
+	assert.Len(t, aliceContainers, 1)
+	assert.Equal(t, "10.0.0.3", aliceContainers[0].SupplierIP, "Container not moved to the new supplier")
+	assert.Equal(t, []string{aliceContainerIDTest}, remoteClient.stopped, "Old container not stopped")
+	assert.Equal(t, []string{aliceContainerIDTest}, credits.settled, "Old container not paid")
+	assert.Equal(t, types.QuotaUsage{Containers: 1, CPUs: 1, Memory: 256}, quota.reserved["alice"],
+		"Quota should account only the new container")
+}
+
+func TestManager_RescheduleContainer_SubmittedConfig(t *testing.T) {
+	config := configuration.Default("10.0.0.1")
+	config.Caravela.Simulation = true
+	scheduler := &schedulerStub{supplierIP: "10.0.0.2", containerID: aliceContainerIDTest}
+	manager := NewManager(config, scheduler, &quotaStub{reserved: map[string]types.QuotaUsage{}}, &creditsStub{},
+		&reputationStub{lost: map[string]int{}}, &remoteClientStub{}, *resources.NewResourcesCPUClass(0, 1, 256))
+	contConfig := types.ContainerConfig{Name: "alice-redis", ImageKey: "registry.example.com/redis@sha256:0123",
+		Resources: types.Resources{CPUs: 1, Memory: 256}, GroupPolicy: types.CoLocationGroupPolicy,
+		RegistryAuth: &types.RegistryAuth{Username: "alice", Password: "secret"}, ImageSignature: "c2lnbmF0dXJl"}
+	ctx := context.WithValue(context.Background(), types.UserIDKey, "alice")
+	if _, err := manager.SubmitContainers(ctx, []types.ContainerConfig{contConfig}); err != nil {
+		t.Fatal(err)
+	}
+	scheduler.supplierIP, scheduler.containerID = "10.0.0.3", bobContainerIDTest
+
+	assert.NoError(t, manager.RescheduleContainer(context.Background(), "10.0.0.2", aliceContainerIDTest))
+
+	if assert.Len(t, scheduler.submitted, 2) {
+		assert.Equal(t, contConfig, scheduler.submitted[1],
+			"Container rescheduled without the credentials, signature or group policy submitted")
+	}
+}
+
+func TestManager_RescheduleContainer_QuotaExceeded(t *testing.T) {
+	config := configuration.Default("10.0.0.1")
+	config.Caravela.Simulation = true
+	quota := &quotaStub{reserved: map[string]types.QuotaUsage{"alice": {Containers: 1, CPUs: 1, Memory: 256}},
+		maxContainers: 1}
+	remoteClient := &remoteClientStub{}
+	manager := NewManager(config, &schedulerStub{supplierIP: "10.0.0.3", containerID: bobContainerIDTest}, quota,
+		&creditsStub{}, &reputationStub{lost: map[string]int{}}, remoteClient, *resources.NewResourcesCPUClass(0, 1, 256))
+	manager.containers.Store(aliceContainerIDTest[:12], newContainer(types.ContainerConfig{Name: "alice-redis",
+		ImageKey: "redis", Resources: types.Resources{CPUs: 1, Memory: 256}}, aliceContainerIDTest, "10.0.0.2", "alice"))
+
+	manager.RescheduleContainer(context.Background(), "10.0.0.2", aliceContainerIDTest)
+
+	aliceContainers := manager.ListContainers(context.WithValue(context.Background(), types.UserIDKey, "alice"))
+	assert.Len(t, aliceContainers, 1)
+	assert.Equal(t, "10.0.0.2", aliceContainers[0].SupplierIP, "Container moved beyond the user's quota")
+	assert.Empty(t, remoteClient.stopped)
+	assert.Equal(t, types.QuotaUsage{Containers: 1, CPUs: 1, Memory: 256}, quota.reserved["alice"])
+}
+
+func TestManager_AdoptContainer(t *testing.T) {
+	quota := &quotaStub{reserved: map[string]types.QuotaUsage{}, maxContainers: 1}
+	manager := NewManager(configuration.Default("10.0.0.1"), nil, quota, &creditsStub{},
+		&reputationStub{lost: map[string]int{}}, &remoteClientStub{}, *resources.NewResourcesCPUClass(0, 1, 256))
+	ctx := context.WithValue(context.Background(), types.UserIDKey, "alice")
+	aliceContainer := &types.ContainerStatus{ContainerConfig: types.ContainerConfig{Name: "alice-redis",
+		ImageKey: "redis", Resources: types.Resources{CPUs: 1, Memory: 256}}, ContainerID: aliceContainerIDTest}
+	bobContainer := &types.ContainerStatus{ContainerConfig: types.ContainerConfig{Name: "alice-nginx",
+		ImageKey: "nginx", Resources: types.Resources{CPUs: 1, Memory: 256}}, ContainerID: bobContainerIDTest}
+
+	assert.NoError(t, manager.AdoptContainer(ctx, "10.0.0.2", aliceContainer))
+	assert.NoError(t, manager.AdoptContainer(ctx, "10.0.0.2", aliceContainer), "Adopted container adopted again")
+	assert.Error(t, manager.AdoptContainer(ctx, "10.0.0.3", aliceContainer), "Container adopted from other supplier")
+	assert.Equal(t, types.QuotaUsage{Containers: 1, CPUs: 1, Memory: 256}, quota.reserved["alice"],
+		"Quota not reserved for the adopted container")
+
+	err := manager.AdoptContainer(ctx, "10.0.0.2", bobContainer)
+
+	assert.IsType(t, &types.QuotaExceededError{}, err, "Container adopted beyond the user's quota")
+	assert.Len(t, manager.ListContainers(ctx), 1)
+}
+
+func TestManager_AdoptContainer_UnknownUser(t *testing.T) {
+	config := configuration.Default("10.0.0.1")
+	config.Host.Users = []configuration.UserAccount{{Name: "alice", Token: "alice-token"}}
+	quota := &quotaStub{reserved: map[string]types.QuotaUsage{}}
+	manager := NewManager(config, nil, quota, &creditsStub{}, &reputationStub{lost: map[string]int{}},
+		&remoteClientStub{}, *resources.NewResourcesCPUClass(0, 1, 256))
+	ctx := context.WithValue(context.Background(), types.UserIDKey, "mallory")
+
+	err := manager.AdoptContainer(ctx, "10.0.0.2", &types.ContainerStatus{ContainerConfig: types.ContainerConfig{
+		Name: "redis", ImageKey: "redis", Resources: types.Resources{CPUs: 1, Memory: 256}},
+		ContainerID: aliceContainerIDTest})
+
+	assert.Error(t, err, "Container adopted for a user the node does not know")
+	assert.Empty(t, quota.reserved)
+}
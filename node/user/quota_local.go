@@ -0,0 +1,11 @@
+package user
+
+import (
+	"context"
+	"github.com/strabox/caravela/api/types"
+)
+
+type quotaLocal interface {
+	Reserve(ctx context.Context, userID string, resources types.QuotaUsage) error
+	Release(ctx context.Context, userID string, resources types.QuotaUsage)
+}
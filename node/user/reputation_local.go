@@ -0,0 +1,10 @@
+package user
+
+type reputationLocal interface {
+	ContainerLost(supplierIP string)
+}
+
+// unavailableError is implemented by the remote client's errors that know if the remote node was unreachable.
+type unavailableError interface {
+	Unavailable() bool
+}
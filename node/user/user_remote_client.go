@@ -7,5 +7,5 @@ import (
 
 // Interface that provides the necessary methods to talk with other nodes.
 type userRemoteClient interface {
-	StopLocalContainer(ctx context.Context, toSupplier *types.Node, containerID string) error
+	StopLocalContainer(ctx context.Context, fromBuyer, toSupplier *types.Node, containerID string) error
 }
@@ -21,6 +21,10 @@ type Chord struct {
 	appNode overlay.LocalNode
 	// Map of local virtual nodes IDs to the respective predecessors IDs
 	predecessors sync.Map
+	// Map of local virtual nodes IDs to the respective successors known in the last successors check
+	successors sync.Map
+	// Successors (IDs) that left the ring gracefully
+	leftSuccessors sync.Map
 	// Number of virtual nodes up and running
 	virtualNodesRunning int
 	// Physical node ID (Higher ID of all the virtual nodes)
@@ -41,6 +45,12 @@ type Chord struct {
 	timeout time.Duration
 	// Chord ring structure from the library used (github.com/strabox/go-chord).
 	chordRing *chord.Ring
+	// Transport used to ping the successors.
+	transport chord.Transport
+	// Interval between the checks for crashed successors.
+	checkSuccessorsInterval time.Duration
+	// Channel to stop checking the successors.
+	quitChan chan bool
 }
 
 // new create a new chord overlay structure.
@@ -58,6 +68,7 @@ func New(config *configuration.Configuration) (overlay.Overlay, error) {
 		numSuccessors:   config.ChordNumSuccessors(),
 		timeout:         config.ChordTimeout(),
 		chordRing:       nil,
+		quitChan:        make(chan bool),
 	}, nil
 }
 
@@ -78,18 +89,91 @@ func (c *Chord) initialize(appNode overlay.LocalNode) (*chord.Config, chord.Tran
 	if err != nil {
 		return nil, nil, err
 	}
+	c.transport = transport
+	c.checkSuccessorsInterval = config.StabilizeMax
 
 	return config, transport, nil
 }
 
+// start runs a endless loop that checks periodically the successors of the local virtual nodes.
+func (c *Chord) start() {
+	ticker := time.NewTicker(c.checkSuccessorsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.successors.Range(func(key, _ interface{}) bool {
+				localVirtualNodeID := big.NewInt(0)
+				localVirtualNodeID.SetString(key.(string), 10)
+				successors, err := c.chordRing.Lookup(c.numSuccessors, c.nextID(localVirtualNodeID))
+				if err == nil {
+					c.checkSuccessors(localVirtualNodeID.Bytes(), successors)
+				}
+				return true
+			})
+		case <-c.quitChan:
+			return
+		}
+	}
+}
+
+// checkSuccessors compares the current successors of a local virtual node with the ones known before. The
+// successors that were dropped by go-chord's stabilization, and do not answer to a ping, crashed (go-chord only
+// alerts about the successors that leave gracefully).
+func (c *Chord) checkSuccessors(localVirtualNodeID []byte, successors []*chord.Vnode) {
+	vNodeID := big.NewInt(0)
+	vNodeID.SetBytes(localVirtualNodeID)
+
+	previous, exist := c.successors.Load(vNodeID.String())
+	if !exist {
+		return
+	}
+	c.successors.Store(vNodeID.String(), successors)
+
+	current := make(map[string]bool)
+	for _, successor := range successors {
+		current[successor.String()] = true
+	}
+	for _, successor := range previous.([]*chord.Vnode) {
+		if current[successor.String()] {
+			continue
+		}
+		if _, left := c.leftSuccessors.Load(successor.String()); left {
+			continue
+		}
+		if alive, err := c.transport.Ping(successor); err == nil && alive {
+			continue // Successor was pushed out of the list by the nodes that joined before it.
+		}
+		c.successorNodeFailed(localVirtualNodeID, toOverlayNode(successor))
+	}
+}
+
+// nextID returns the ID that follows the given one in the ring.
+func (c *Chord) nextID(id *big.Int) []byte {
+	nextID := big.NewInt(0).Add(id, big.NewInt(1))
+	res := make([]byte, c.hashSizeBytes)
+	if nextIDBytes := nextID.Bytes(); len(nextIDBytes) <= c.hashSizeBytes { // Otherwise it wraps around into 0.
+		copy(res[c.hashSizeBytes-len(nextIDBytes):], nextIDBytes)
+	}
+	return res
+}
+
 // Called when a new virtual node of this physical node has joined the chord ring.
 func (c *Chord) newLocalVirtualNode(localVirtualNodeID []byte, predecessorNode *overlay.OverlayNode) {
+	newLocalVirtualNodeID := big.NewInt(0)
+	newLocalVirtualNodeID.SetBytes(localVirtualNodeID)
 	if c.virtualNodesRunning == c.numVirtualNodes {
+		// Existing virtual node that obtained a predecessor after the previous one left or crashed. go-chord forgets
+		// the crashed predecessor (when it stops answering the pings) without alerting, but we still know it.
+		var previousNode *overlay.OverlayNode = nil
+		if previous, exist := c.predecessors.Load(newLocalVirtualNodeID.String()); exist {
+			previousNode = previous.(*overlay.OverlayNode)
+		}
+		c.predecessorNodeChanged(localVirtualNodeID, predecessorNode, previousNode)
 		return
 	}
 
-	newLocalVirtualNodeID := big.NewInt(0)
-	newLocalVirtualNodeID.SetBytes(localVirtualNodeID)
 	if c.localID == nil {
 		c.localID = localVirtualNodeID
 	} else {
@@ -101,15 +185,60 @@ func (c *Chord) newLocalVirtualNode(localVirtualNodeID []byte, predecessorNode *
 	}
 	c.virtualNodesRunning++
 	c.predecessors.Store(newLocalVirtualNodeID.String(), predecessorNode)
-	c.appNode.AddTrader(localVirtualNodeID) // Alert the node for the new virtual node/trader
+	c.successors.Store(newLocalVirtualNodeID.String(), make([]*chord.Vnode, 0))
+	// Alert the node for the new virtual node/trader
+	c.appNode.MembershipChanged(&overlay.MembershipEvent{
+		Type:        overlay.VirtualNodeJoined,
+		LocalNodeID: localVirtualNodeID,
+		Node:        predecessorNode,
+	})
 }
 
 // Called when the predecessor of a virtual node of the physical node changes.
 // e.g. Due to a crash in the previous predecessor or because he left the chord ring.
-func (c *Chord) predecessorNodeChanged(localVirtualNodeID []byte, predecessorNode *overlay.OverlayNode) {
+func (c *Chord) predecessorNodeChanged(localVirtualNodeID []byte, predecessorNode, previousNode *overlay.OverlayNode) {
+	vNodeID := big.NewInt(0)
+	vNodeID.SetBytes(localVirtualNodeID)
+	if predecessorNode == nil {
+		c.predecessors.Delete(vNodeID.String())
+	} else {
+		c.predecessors.Store(vNodeID.String(), predecessorNode)
+	}
+	c.appNode.MembershipChanged(&overlay.MembershipEvent{
+		Type:         overlay.PredecessorChanged,
+		LocalNodeID:  localVirtualNodeID,
+		Node:         predecessorNode,
+		PreviousNode: previousNode,
+	})
+}
+
+// Called when a successor of a virtual node of the physical node left the chord ring gracefully.
+func (c *Chord) successorNodeLeaving(successorNode *chord.Vnode) {
+	c.leftSuccessors.Store(successorNode.String(), true)
+}
+
+// Called when a successor of a virtual node of the physical node crashed.
+func (c *Chord) successorNodeFailed(localVirtualNodeID []byte, successorNode *overlay.OverlayNode) {
+	c.appNode.MembershipChanged(&overlay.MembershipEvent{
+		Type:        overlay.SuccessorFailed,
+		LocalNodeID: localVirtualNodeID,
+		Node:        successorNode,
+	})
+}
+
+// Called when a virtual node of the physical node is leaving the chord ring.
+// The successor will be responsible for the virtual node's keys.
+func (c *Chord) localVirtualNodeLeaving(localVirtualNodeID []byte, successorNode *overlay.OverlayNode) {
 	vNodeID := big.NewInt(0)
 	vNodeID.SetBytes(localVirtualNodeID)
-	c.predecessors.Store(vNodeID.String(), predecessorNode)
+	c.predecessors.Delete(vNodeID.String())
+	c.successors.Delete(vNodeID.String())
+	// Alert the node, it must hand over its state
+	c.appNode.MembershipChanged(&overlay.MembershipEvent{
+		Type:        overlay.VirtualNodeRemoved,
+		LocalNodeID: localVirtualNodeID,
+		Node:        successorNode,
+	})
 }
 
 /* ============================ Overlay Interface ============================ */
@@ -126,6 +255,7 @@ func (c *Chord) Create(ctx context.Context, appNode overlay.LocalNode) error {
 	}
 
 	c.chordRing = ring
+	go c.start()
 	return nil
 }
 
@@ -141,6 +271,7 @@ func (c *Chord) Join(ctx context.Context, overlayNodeIP string, overlayNodePort
 		return fmt.Errorf("join chord error: %s", err)
 	}
 	c.chordRing = ring
+	go c.start()
 	return nil
 }
 
@@ -197,6 +328,7 @@ func (c *Chord) NodeID(ctx context.Context) ([]byte, error) {
 }
 
 func (c *Chord) Leave(ctx context.Context) error {
+	close(c.quitChan)
 	err := c.chordRing.Leave()
 	if err != nil {
 		return fmt.Errorf("leave chord error: %s", err)
@@ -0,0 +1,78 @@
+package chord
+
+import (
+	"github.com/strabox/caravela/configuration"
+	"github.com/strabox/caravela/overlay"
+	"github.com/strabox/go-chord"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// appNodeStub records the membership events delivered to the node.
+type appNodeStub struct {
+	events []*overlay.MembershipEvent
+}
+
+func (a *appNodeStub) MembershipChanged(event *overlay.MembershipEvent) {
+	a.events = append(a.events, event)
+}
+
+func (a *appNodeStub) GUID() string {
+	return ""
+}
+
+// transportStub answers the pings of the virtual nodes that are alive.
+type transportStub struct {
+	chord.Transport
+	alive map[string]bool
+}
+
+func (t *transportStub) Ping(vnode *chord.Vnode) (bool, error) {
+	return t.alive[vnode.String()], nil
+}
+
+func TestChord_NewLocalVirtualNode_PredecessorCrashed(t *testing.T) {
+	config := configuration.Default("10.0.0.1")
+	config.Overlay.Chord.VirtualNodes = 1
+	chordOverlay, _ := New(config)
+	c := chordOverlay.(*Chord)
+	appNode := &appNodeStub{}
+	c.appNode = appNode
+	listener := &Listener{chordOverlay: c}
+	local := &chord.Vnode{Id: []byte{10}, Host: "10.0.0.1:8001"}
+	crashed := &chord.Vnode{Id: []byte{5}, Host: "10.0.0.2:8001"}
+
+	listener.NewPredecessor(local, crashed, nil)
+	// go-chord forgets the crashed predecessor and accepts the next one as if it was the first.
+	listener.NewPredecessor(local, &chord.Vnode{Id: []byte{2}, Host: "10.0.0.3:8001"}, nil)
+
+	assert.Len(t, appNode.events, 2)
+	assert.Equal(t, overlay.VirtualNodeJoined, appNode.events[0].Type)
+	assert.Equal(t, overlay.PredecessorChanged, appNode.events[1].Type)
+	assert.Equal(t, "10.0.0.2", appNode.events[1].PreviousNode.IP(), "Crashed predecessor not alerted")
+}
+
+func TestChord_CheckSuccessors(t *testing.T) {
+	config := configuration.Default("10.0.0.1")
+	config.Overlay.Chord.VirtualNodes = 1
+	chordOverlay, _ := New(config)
+	c := chordOverlay.(*Chord)
+	appNode := &appNodeStub{}
+	c.appNode = appNode
+	listener := &Listener{chordOverlay: c}
+	local := &chord.Vnode{Id: []byte{10}, Host: "10.0.0.1:8001"}
+	crashed := &chord.Vnode{Id: []byte{20}, Host: "10.0.0.2:8001"}
+	leaving := &chord.Vnode{Id: []byte{30}, Host: "10.0.0.3:8001"}
+	pushed := &chord.Vnode{Id: []byte{40}, Host: "10.0.0.4:8001"}
+	joined := &chord.Vnode{Id: []byte{15}, Host: "10.0.0.5:8001"}
+	c.transport = &transportStub{alive: map[string]bool{pushed.String(): true, joined.String(): true}}
+	listener.NewPredecessor(local, &chord.Vnode{Id: []byte{5}, Host: "10.0.0.6:8001"}, nil)
+	c.checkSuccessors(local.Id, []*chord.Vnode{crashed, leaving, pushed})
+
+	listener.SuccessorLeaving(local, leaving)
+	c.checkSuccessors(local.Id, []*chord.Vnode{joined})
+
+	assert.Len(t, appNode.events, 2, "Only the crashed successor should be alerted")
+	assert.Equal(t, overlay.SuccessorFailed, appNode.events[1].Type)
+	assert.Equal(t, "10.0.0.2", appNode.events[1].Node.IP())
+}
@@ -25,28 +25,37 @@ func (l *Listener) NewPredecessor(local, newPredecessor, previousPredecessor *ch
 		l.chordOverlay.newLocalVirtualNode(local.Id, predecessorNode)
 	} else if local != nil && newPredecessor != nil && previousPredecessor != nil {
 		// New predecessor for a existing node
-		nodeIP, nodePort := util.ObtainIpPort(newPredecessor.Host)
-		predecessorNode := overlay.NewOverlayNode(nodeIP, nodePort, newPredecessor.Id)
-		l.chordOverlay.predecessorNodeChanged(local.Id, predecessorNode)
+		l.chordOverlay.predecessorNodeChanged(local.Id, toOverlayNode(newPredecessor), toOverlayNode(previousPredecessor))
 	}
 }
 
 // Fired when the local node is leaving the chord overlay.
+// The successor takes over the local node's keys, so the application hands over its state to it.
 func (l *Listener) Leaving(local, predecessor, successor *chord.Vnode) {
-	// DO NOTHING FOR NOW
 	log.Debug(util.LogTag("Chord") + "I am leaving!!")
+	if local == nil {
+		return
+	}
+
+	l.chordOverlay.localVirtualNodeLeaving(local.Id, toOverlayNode(successor))
 }
 
 // Fired when the current predecessor of the local node is leaving the chord overlay.
 func (l *Listener) PredecessorLeaving(local, remote *chord.Vnode) {
-	// DO NOTHING FOR NOW
 	log.Debug(util.LogTag("Chord") + "Current predecessor is leaving!!")
+	if local != nil {
+		l.chordOverlay.predecessorNodeChanged(local.Id, nil, toOverlayNode(remote))
+	}
 }
 
 // Fired when a current successor of the local node is leaving the chord overlay.
+// The successor hands over its state when leaving, so it is not alerted as failed. Note: go-chord only fires it for
+// successors that leave, the crashed ones are detected by the overlay's successors check.
 func (l *Listener) SuccessorLeaving(local, remote *chord.Vnode) {
-	// DO NOTHING FOR NOW
 	log.Debug(util.LogTag("Chord") + "A successor is leaving!!")
+	if local != nil && remote != nil {
+		l.chordOverlay.successorNodeLeaving(remote)
+	}
 }
 
 // Fired when when one node decided to shutdown the chord ring system.
@@ -55,3 +64,12 @@ func (l *Listener) Shutdown() {
 	// DO NOTHING FOR NOW
 	log.Debug(util.LogTag("Chord") + "Shutting Down??")
 }
+
+// toOverlayNode converts a chord virtual node into an overlay node (nil if the virtual node is nil).
+func toOverlayNode(vnode *chord.Vnode) *overlay.OverlayNode {
+	if vnode == nil {
+		return nil
+	}
+	nodeIP, nodePort := util.ObtainIpPort(vnode.Host)
+	return overlay.NewOverlayNode(nodeIP, nodePort, vnode.Id)
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/strabox/caravela/configuration"
 	"github.com/strabox/caravela/overlay"
 	"github.com/strabox/caravela/overlay/chord"
+	"github.com/strabox/caravela/overlay/kademlia"
+	"github.com/strabox/caravela/overlay/memory"
 	"strings"
 )
 
@@ -19,6 +21,8 @@ var overlayFactories = make(map[string]Factory)
 // init initializes our predefined overlays.
 func init() {
 	Register("chord", chord.New)
+	Register("kademlia", kademlia.New)
+	Register("memory", memory.New)
 }
 
 // Register can be used to register a new overlay in order to be available.
@@ -0,0 +1,409 @@
+package kademlia
+
+import (
+	"context"
+	"fmt"
+	log "github.com/Sirupsen/logrus"
+	"github.com/strabox/caravela/configuration"
+	"github.com/strabox/caravela/node/common/guid"
+	"github.com/strabox/caravela/overlay"
+	"github.com/strabox/caravela/util"
+	"net"
+	"net/rpc"
+	"sync"
+	"time"
+)
+
+// Kademlia represents a Kademlia overlay local (for each node) structure.
+// The GUIDs are used directly as the kademlia's node IDs and the routing is done using the XOR distance.
+type Kademlia struct {
+	// Used to communicate interesting events to the application.
+	appNode overlay.LocalNode
+	// Local virtual nodes.
+	vnodes []*contact
+	// Routing table of each local virtual node (same order as the virtual nodes).
+	tables []*routingTable
+	// Physical node ID (Higher ID of all the virtual nodes).
+	localID []byte
+	// Mutex to access the physical node ID.
+	mutex sync.RWMutex
+
+	// ============== KADEMLIA Related Fields  ==================
+	// IP address of the local node.
+	hostIP string
+	// Port where local node is running the kademlia daemon.
+	hostPort int
+	// Number of virtual nodes in the local "physical node".
+	numVirtualNodes int
+	// Maximum number of contacts in each k-bucket (k).
+	bucketSize int
+	// Number of concurrent messages in a lookup (alpha).
+	parallelism int
+	// Number of nodes returned by a lookup.
+	lookupSize int
+	// Timeout for kademlia messages.
+	timeout time.Duration
+	// Interval to verify the contacts and refresh the k-buckets.
+	refreshInterval time.Duration
+	// Listener of the kademlia daemon.
+	listener net.Listener
+	// Channel to stop the maintenance of the routing tables.
+	quitChan chan bool
+}
+
+// New creates a new kademlia overlay structure.
+func New(config *configuration.Configuration) (overlay.Overlay, error) {
+	return &Kademlia{
+		appNode: nil,
+		vnodes:  make([]*contact, 0),
+		tables:  make([]*routingTable, 0),
+		localID: nil,
+		mutex:   sync.RWMutex{},
+
+		hostIP:          config.HostIP(),
+		hostPort:        config.OverlayPort(),
+		numVirtualNodes: config.KademliaVirtualNodes(),
+		bucketSize:      config.KademliaBucketSize(),
+		parallelism:     config.KademliaParallelism(),
+		lookupSize:      config.KademliaLookupSize(),
+		timeout:         config.KademliaTimeout(),
+		refreshInterval: config.KademliaRefreshInterval(),
+		listener:        nil,
+		quitChan:        make(chan bool),
+	}, nil
+}
+
+// initialize the virtual nodes and starts the kademlia daemon.
+func (k *Kademlia) initialize(appNode overlay.LocalNode) error {
+	k.appNode = appNode
+	for i := 0; i < k.numVirtualNodes; i++ {
+		vnodeID := guid.NewGUIDRandom()
+		k.vnodes = append(k.vnodes, newContact(vnodeID, k.hostIP, k.hostPort))
+		k.tables = append(k.tables, newRoutingTable(vnodeID, k.bucketSize))
+	}
+
+	server := rpc.NewServer()
+	if err := server.RegisterName(serviceName, &Service{kademlia: k}); err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", k.hostPort))
+	if err != nil {
+		return err
+	}
+	k.listener = listener
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return // Listener closed, the node left the overlay.
+			}
+			go server.ServeConn(conn)
+		}
+	}()
+	return nil
+}
+
+// start alerts the node for its virtual nodes and starts maintaining the routing tables.
+func (k *Kademlia) start() {
+	localID := k.vnodes[0].id
+	for _, vnode := range k.vnodes {
+		if vnode.id.Higher(*localID) {
+			localID = vnode.id
+		}
+	}
+	k.mutex.Lock()
+	k.localID = localID.Bytes()
+	k.mutex.Unlock()
+
+	for _, vnode := range k.vnodes {
+		k.appNode.MembershipChanged(&overlay.MembershipEvent{
+			Type:        overlay.VirtualNodeJoined,
+			LocalNodeID: vnode.id.Bytes(),
+		})
+	}
+
+	go k.maintain()
+}
+
+// maintain runs a endless loop that periodically verifies the contacts and refreshes the routing tables.
+func (k *Kademlia) maintain() {
+	ticker := time.NewTicker(k.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			k.refresh()
+		case <-k.quitChan:
+			return
+		}
+	}
+}
+
+// refresh pings all the known nodes, removing the ones that don't answer, and looks up the virtual nodes' IDs
+// in order to find new contacts near them.
+func (k *Kademlia) refresh() {
+	addresses := make(map[string]bool)
+	for _, table := range k.tables {
+		for _, c := range table.Contacts() {
+			addresses[c.address()] = true
+		}
+	}
+
+	wg := sync.WaitGroup{}
+	for address := range addresses {
+		wg.Add(1)
+		go func(address string) {
+			defer wg.Done()
+			reply := &PingReply{}
+			if err := call(address, k.timeout, "Ping", &PingArgs{Sender: toWire(k.vnodes)}, reply); err != nil {
+				k.removeNode(address)
+				return
+			}
+			k.seen(fromWire(reply.Sender))
+		}(address)
+	}
+	wg.Wait()
+
+	for _, vnode := range k.vnodes {
+		k.lookup(vnode.id)
+	}
+}
+
+// address of the local physical node.
+func (k *Kademlia) address() string {
+	return fmt.Sprintf("%s:%d", k.hostIP, k.hostPort)
+}
+
+// seen updates the routing tables with contacts that showed to be alive.
+func (k *Kademlia) seen(contacts []*contact) {
+	for _, c := range contacts {
+		if c.address() == k.address() {
+			continue
+		}
+		for _, table := range k.tables {
+			table.Update(c)
+		}
+	}
+}
+
+// removeNode removes the virtual nodes of a physical node that failed from the routing tables, alerting the node.
+func (k *Kademlia) removeNode(address string) {
+	failed := make(map[string]bool)
+	events := make([]*overlay.MembershipEvent, 0)
+	for i, table := range k.tables {
+		for _, removed := range table.Remove(address) {
+			if !failed[removed.id.String()] {
+				failed[removed.id.String()] = true
+				events = append(events, &overlay.MembershipEvent{
+					Type:        overlay.SuccessorFailed,
+					LocalNodeID: k.vnodes[i].id.Bytes(),
+					Node:        removed.overlayNode(),
+				})
+			}
+		}
+	}
+
+	if len(events) > 0 {
+		log.Debugf(util.LogTag("Kademlia")+"Node %s FAILED", address)
+	}
+	for _, event := range events {
+		k.appNode.MembershipChanged(event)
+	}
+}
+
+// closestKnown returns the (at most) n nodes, known locally (including the local virtual nodes), that are closest
+// to the target.
+func (k *Kademlia) closestKnown(target *guid.GUID, n int) []*contact {
+	known := make(map[string]*contact)
+	for _, vnode := range k.vnodes {
+		known[vnode.id.String()] = vnode
+	}
+	for _, table := range k.tables {
+		for _, c := range table.Closest(target, n) {
+			known[c.id.String()] = c
+		}
+	}
+
+	res := make([]*contact, 0, len(known))
+	for _, c := range known {
+		res = append(res, c)
+	}
+	sortByDistance(res, target)
+	if len(res) > n {
+		res = res[:n]
+	}
+	return res
+}
+
+// lookup finds the closest nodes to the target in the overlay, asking iteratively (and in parallel) the closest
+// nodes found so far for nodes even closer.
+func (k *Kademlia) lookup(target *guid.GUID) []*contact {
+	shortlist := k.closestKnown(target, k.bucketSize)
+	queried := map[string]bool{k.address(): true}
+
+	for {
+		toQuery := make([]*contact, 0, k.parallelism)
+		for _, c := range shortlist {
+			if len(toQuery) == k.parallelism {
+				break
+			}
+			if !queried[c.address()] {
+				queried[c.address()] = true
+				toQuery = append(toQuery, c)
+			}
+		}
+		if len(toQuery) == 0 {
+			return shortlist
+		}
+
+		replies := make([]*FindNodeReply, len(toQuery))
+		errs := make([]error, len(toQuery))
+		wg := sync.WaitGroup{}
+		for i, c := range toQuery {
+			wg.Add(1)
+			go func(i int, c *contact) {
+				defer wg.Done()
+				replies[i] = &FindNodeReply{}
+				errs[i] = call(c.address(), k.timeout, "FindNode",
+					&FindNodeArgs{Sender: toWire(k.vnodes), Target: target.Bytes()}, replies[i])
+			}(i, c)
+		}
+		wg.Wait()
+
+		found := make(map[string]*contact)
+		for _, c := range shortlist {
+			found[c.id.String()] = c
+		}
+		for i, c := range toQuery {
+			if errs[i] != nil {
+				k.removeNode(c.address())
+				for id, foundContact := range found {
+					if foundContact.address() == c.address() {
+						delete(found, id)
+					}
+				}
+				continue
+			}
+			k.seen(fromWire(replies[i].Sender))
+			for _, replied := range fromWire(replies[i].Contacts) {
+				found[replied.id.String()] = replied
+			}
+		}
+
+		shortlist = make([]*contact, 0, len(found))
+		for _, c := range found {
+			shortlist = append(shortlist, c)
+		}
+		sortByDistance(shortlist, target)
+		if len(shortlist) > k.bucketSize {
+			shortlist = shortlist[:k.bucketSize]
+		}
+	}
+}
+
+/* ============================ Overlay Interface ============================ */
+
+func (k *Kademlia) Create(ctx context.Context, appNode overlay.LocalNode) error {
+	if err := k.initialize(appNode); err != nil {
+		return fmt.Errorf("create kademlia error: %s", err)
+	}
+
+	k.start()
+	return nil
+}
+
+func (k *Kademlia) Join(ctx context.Context, overlayNodeIP string, overlayNodePort int, appNode overlay.LocalNode) error {
+	if err := k.initialize(appNode); err != nil {
+		return fmt.Errorf("join kademlia error: %s", err)
+	}
+
+	reply := &PingReply{}
+	err := call(fmt.Sprintf("%s:%d", overlayNodeIP, overlayNodePort), k.timeout, "Ping",
+		&PingArgs{Sender: toWire(k.vnodes)}, reply)
+	if err != nil {
+		k.listener.Close()
+		return fmt.Errorf("join kademlia error: %s", err)
+	}
+	k.seen(fromWire(reply.Sender))
+
+	for _, vnode := range k.vnodes { // Fill the k-buckets near each virtual node.
+		k.lookup(vnode.id)
+	}
+
+	k.start()
+	return nil
+}
+
+func (k *Kademlia) Lookup(ctx context.Context, key []byte) ([]*overlay.OverlayNode, error) {
+	closest := k.lookup(guid.NewGUIDBytes(key))
+	if len(closest) > k.lookupSize {
+		closest = closest[:k.lookupSize]
+	}
+
+	res := make([]*overlay.OverlayNode, len(closest))
+	for index := range closest {
+		res[index] = closest[index].overlayNode()
+	}
+	return res, nil
+}
+
+// Neighbors returns the known nodes with the next higher and the next lower IDs of the given node, found among the
+// closest nodes (XOR distance) of the node.
+func (k *Kademlia) Neighbors(ctx context.Context, nodeID []byte) ([]*overlay.OverlayNode, error) {
+	id := guid.NewGUIDBytes(nodeID)
+
+	var successor, predecessor *contact = nil, nil
+	for _, c := range k.lookup(id) {
+		if c.id.Higher(*id) && (successor == nil || c.id.Lower(*successor.id)) {
+			successor = c
+		}
+		if c.id.Lower(*id) && (predecessor == nil || c.id.Higher(*predecessor.id)) {
+			predecessor = c
+		}
+	}
+
+	res := make([]*overlay.OverlayNode, 0)
+	if successor != nil {
+		res = append(res, successor.overlayNode()) // The successor of the given node
+	}
+	if predecessor != nil {
+		res = append(res, predecessor.overlayNode()) // The predecessor of the given node
+	}
+	return res, nil
+}
+
+func (k *Kademlia) NodeID(ctx context.Context) ([]byte, error) {
+	k.mutex.RLock()
+	defer k.mutex.RUnlock()
+
+	if k.localID != nil {
+		return k.localID, nil
+	}
+	return nil, fmt.Errorf("node ID not known yet")
+}
+
+// Leave alerts the node for each virtual node that is leaving, together with the closest remote node (that will
+// receive the lookups for the virtual node's IDs), and stops the kademlia daemon.
+func (k *Kademlia) Leave(ctx context.Context) error {
+	if k.listener == nil {
+		return fmt.Errorf("leave kademlia error: overlay not created/joined")
+	}
+	close(k.quitChan)
+
+	for i, vnode := range k.vnodes {
+		var successor *overlay.OverlayNode = nil
+		if closest := k.tables[i].Closest(vnode.id, 1); len(closest) > 0 {
+			successor = closest[0].overlayNode()
+		}
+		k.appNode.MembershipChanged(&overlay.MembershipEvent{
+			Type:        overlay.VirtualNodeRemoved,
+			LocalNodeID: vnode.id.Bytes(),
+			Node:        successor,
+		})
+	}
+
+	return k.listener.Close()
+}
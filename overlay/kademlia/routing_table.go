@@ -0,0 +1,143 @@
+package kademlia
+
+import (
+	"fmt"
+	"github.com/strabox/caravela/node/common/guid"
+	"github.com/strabox/caravela/overlay"
+	"math/big"
+	"sort"
+	"sync"
+)
+
+// contact is a (virtual) node of the overlay known by the local node.
+type contact struct {
+	id   *guid.GUID // Node identifier (the GUID maps directly into the kademlia's ID space)
+	ip   string     // IP address of the node
+	port int        // Port where the node runs the kademlia daemon
+}
+
+func newContact(id *guid.GUID, ip string, port int) *contact {
+	return &contact{
+		id:   id,
+		ip:   ip,
+		port: port,
+	}
+}
+
+// Address of the physical node where the contact runs.
+func (c *contact) address() string {
+	return fmt.Sprintf("%s:%d", c.ip, c.port)
+}
+
+func (c *contact) overlayNode() *overlay.OverlayNode {
+	return overlay.NewOverlayNode(c.ip, c.port, c.id.Bytes())
+}
+
+// distance returns the XOR distance between two identifiers.
+func distance(id1, id2 *guid.GUID) *big.Int {
+	return big.NewInt(0).Xor(id1.BigInt(), id2.BigInt())
+}
+
+// sortByDistance sorts the contacts by their distance to the target (closest first).
+func sortByDistance(contacts []*contact, target *guid.GUID) {
+	sort.Slice(contacts, func(i, j int) bool {
+		return distance(contacts[i].id, target).Cmp(distance(contacts[j].id, target)) < 0
+	})
+}
+
+// routingTable keeps the contacts of a local virtual node in k-buckets. The i-th bucket holds the contacts whose
+// distance to the local node is in [2^i, 2^(i+1)). The contacts of each bucket are ordered from the least
+// recently seen to the most recently seen.
+type routingTable struct {
+	localID    *guid.GUID   // Identifier of the local virtual node
+	bucketSize int          // Maximum number of contacts in each bucket (k)
+	buckets    [][]*contact // The k-buckets
+	mutex      sync.Mutex   // Mutex to manage the buckets
+}
+
+func newRoutingTable(localID *guid.GUID, bucketSize int) *routingTable {
+	return &routingTable{
+		localID:    localID,
+		bucketSize: bucketSize,
+		buckets:    make([][]*contact, guid.SizeBits()),
+		mutex:      sync.Mutex{},
+	}
+}
+
+// bucketIndex returns the index of the bucket where the identifier belongs (-1 for the local identifier).
+func (r *routingTable) bucketIndex(id *guid.GUID) int {
+	index := distance(r.localID, id).BitLen() - 1
+	if index >= len(r.buckets) {
+		return len(r.buckets) - 1
+	}
+	return index
+}
+
+// Update marks the contact as the most recently seen of its bucket. When the bucket is full the new contact is
+// discarded (the old contacts are preferred, they are more likely to stay alive) and false is returned.
+func (r *routingTable) Update(newContact *contact) bool {
+	index := r.bucketIndex(newContact.id)
+	if index < 0 {
+		return false
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	bucket := r.buckets[index]
+	for i, existing := range bucket {
+		if existing.id.Equals(*newContact.id) {
+			bucket = append(bucket[:i], bucket[i+1:]...)
+			r.buckets[index] = append(bucket, newContact)
+			return true
+		}
+	}
+
+	if len(bucket) >= r.bucketSize {
+		return false
+	}
+	r.buckets[index] = append(bucket, newContact)
+	return true
+}
+
+// Remove removes the contacts of the given physical node, returning the ones removed.
+func (r *routingTable) Remove(address string) []*contact {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	removed := make([]*contact, 0)
+	for index, bucket := range r.buckets {
+		kept := bucket[:0]
+		for _, existing := range bucket {
+			if existing.address() == address {
+				removed = append(removed, existing)
+			} else {
+				kept = append(kept, existing)
+			}
+		}
+		r.buckets[index] = kept
+	}
+	return removed
+}
+
+// Contacts returns all the contacts of the table.
+func (r *routingTable) Contacts() []*contact {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	res := make([]*contact, 0)
+	for _, bucket := range r.buckets {
+		res = append(res, bucket...)
+	}
+	return res
+}
+
+// Closest returns the (at most) n contacts of the table that are closest to the target.
+func (r *routingTable) Closest(target *guid.GUID, n int) []*contact {
+	res := r.Contacts()
+	sortByDistance(res, target)
+	if len(res) > n {
+		res = res[:n]
+	}
+	return res
+}
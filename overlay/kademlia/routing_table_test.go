@@ -0,0 +1,51 @@
+package kademlia
+
+import (
+	"github.com/strabox/caravela/node/common/guid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRoutingTable_Update_BucketFull(t *testing.T) {
+	guid.Init(16, 1, 1) // Use 16-bit GUID to be easily tested
+	table := newRoutingTable(guid.NewGUIDInteger(0), 2)
+
+	// IDs 4, 5, 6 and 7 are all in the bucket of the distances [4, 8).
+	assert.True(t, table.Update(newContact(guid.NewGUIDInteger(4), "10.0.0.4", 8000)))
+	assert.True(t, table.Update(newContact(guid.NewGUIDInteger(5), "10.0.0.5", 8000)))
+	assert.False(t, table.Update(newContact(guid.NewGUIDInteger(6), "10.0.0.6", 8000)), "Full bucket keeps old contacts")
+	assert.True(t, table.Update(newContact(guid.NewGUIDInteger(4), "10.0.0.4", 8000)), "Known contact is refreshed")
+	assert.False(t, table.Update(newContact(guid.NewGUIDInteger(0), "10.0.0.1", 8000)), "Local ID is not a contact")
+
+	assert.Equal(t, 2, len(table.Contacts()))
+	assert.Equal(t, int64(4), table.buckets[2][1].id.Int64(), "Refreshed contact is the most recently seen")
+}
+
+func TestRoutingTable_Closest(t *testing.T) {
+	guid.Init(16, 1, 1) // Use 16-bit GUID to be easily tested
+	table := newRoutingTable(guid.NewGUIDInteger(0), 4)
+	for _, id := range []int64{1, 2, 9, 12, 300} {
+		table.Update(newContact(guid.NewGUIDInteger(id), "10.0.0.1", 8000))
+	}
+
+	closest := table.Closest(guid.NewGUIDInteger(13), 3)
+
+	assert.Equal(t, 3, len(closest))
+	assert.Equal(t, int64(12), closest[0].id.Int64()) // 13 XOR 12 = 1
+	assert.Equal(t, int64(9), closest[1].id.Int64())  // 13 XOR 9 = 4
+	assert.Equal(t, int64(1), closest[2].id.Int64())  // 13 XOR 1 = 12
+}
+
+func TestRoutingTable_Remove(t *testing.T) {
+	guid.Init(16, 1, 1) // Use 16-bit GUID to be easily tested
+	table := newRoutingTable(guid.NewGUIDInteger(0), 4)
+	table.Update(newContact(guid.NewGUIDInteger(1), "10.0.0.1", 8000))
+	table.Update(newContact(guid.NewGUIDInteger(7), "10.0.0.1", 8000))
+	table.Update(newContact(guid.NewGUIDInteger(9), "10.0.0.2", 8000))
+
+	removed := table.Remove("10.0.0.1:8000")
+
+	assert.Equal(t, 2, len(removed))
+	assert.Equal(t, 1, len(table.Contacts()))
+	assert.Equal(t, int64(9), table.Contacts()[0].id.Int64())
+}
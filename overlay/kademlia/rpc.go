@@ -0,0 +1,95 @@
+package kademlia
+
+import (
+	"fmt"
+	"github.com/strabox/caravela/node/common/guid"
+	"net"
+	"net/rpc"
+	"time"
+)
+
+// Name of the RPC service exposed by each node.
+const serviceName = "Kademlia"
+
+// WireContact is the representation of a contact in the kademlia messages.
+type WireContact struct {
+	ID   []byte
+	IP   string
+	Port int
+}
+
+// PingArgs are the arguments of the PING message.
+type PingArgs struct {
+	Sender []WireContact // Virtual nodes of the sender
+}
+
+// PingReply is the reply of the PING message.
+type PingReply struct {
+	Sender []WireContact // Virtual nodes of the receiver
+}
+
+// FindNodeArgs are the arguments of the FIND_NODE message.
+type FindNodeArgs struct {
+	Sender []WireContact // Virtual nodes of the sender
+	Target []byte        // Identifier being searched
+}
+
+// FindNodeReply is the reply of the FIND_NODE message.
+type FindNodeReply struct {
+	Sender   []WireContact // Virtual nodes of the receiver
+	Contacts []WireContact // Closest contacts to the target known by the receiver
+}
+
+// Service handles the kademlia messages received by the local node.
+type Service struct {
+	kademlia *Kademlia
+}
+
+// Ping answers with the local virtual nodes, learning the sender's virtual nodes.
+func (s *Service) Ping(args *PingArgs, reply *PingReply) error {
+	s.kademlia.seen(fromWire(args.Sender))
+	reply.Sender = toWire(s.kademlia.vnodes)
+	return nil
+}
+
+// FindNode answers with the closest known contacts to the target, learning the sender's virtual nodes.
+func (s *Service) FindNode(args *FindNodeArgs, reply *FindNodeReply) error {
+	s.kademlia.seen(fromWire(args.Sender))
+	reply.Sender = toWire(s.kademlia.vnodes)
+	reply.Contacts = toWire(s.kademlia.closestKnown(guid.NewGUIDBytes(args.Target), s.kademlia.bucketSize))
+	return nil
+}
+
+func toWire(contacts []*contact) []WireContact {
+	res := make([]WireContact, len(contacts))
+	for i, c := range contacts {
+		res[i] = WireContact{ID: c.id.Bytes(), IP: c.ip, Port: c.port}
+	}
+	return res
+}
+
+func fromWire(wireContacts []WireContact) []*contact {
+	res := make([]*contact, len(wireContacts))
+	for i, c := range wireContacts {
+		res[i] = newContact(guid.NewGUIDBytes(c.ID), c.IP, c.Port)
+	}
+	return res
+}
+
+// call sends a message to the physical node with the given address and waits (until the timeout) for the reply.
+func call(address string, timeout time.Duration, method string, args, reply interface{}) error {
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return err
+	}
+	client := rpc.NewClient(conn)
+	defer client.Close()
+
+	rpcCall := client.Go(serviceName+"."+method, args, reply, make(chan *rpc.Call, 1))
+	select {
+	case <-rpcCall.Done:
+		return rpcCall.Error
+	case <-time.After(timeout):
+		return fmt.Errorf("%s to %s timed out", method, address)
+	}
+}
@@ -2,8 +2,9 @@ package overlay
 
 // LocalNode exposes the overlay to the application node.
 type LocalNode interface {
-	// AddTrader is called when a new local virtual node joins the overlay.
-	AddTrader(guid []byte)
+	// MembershipChanged is called when the overlay's membership changes around the local virtual nodes,
+	// e.g. a new local virtual node joins the overlay.
+	MembershipChanged(event *MembershipEvent)
 	// GUID returns the node's GUID.
 	GUID() string
 }
@@ -0,0 +1,44 @@
+package overlay
+
+// MembershipEventType identifies the kind of change in the overlay's membership.
+type MembershipEventType int
+
+const (
+	// A local virtual node joined the overlay.
+	VirtualNodeJoined MembershipEventType = iota
+	// The predecessor of a local virtual node changed (e.g. a node joined before it or the predecessor left).
+	PredecessorChanged
+	// A successor of a local virtual node crashed (the successors that leave gracefully hand over their state).
+	SuccessorFailed
+	// A local virtual node left the overlay.
+	VirtualNodeRemoved
+)
+
+// MembershipEvent describes a change in the overlay's membership as seen by one of the local virtual nodes.
+type MembershipEvent struct {
+	// Kind of membership change
+	Type MembershipEventType
+	// Identifier of the local virtual node where the change was noticed
+	LocalNodeID []byte
+	// Remote node involved: predecessor of a joined node, new predecessor, failed successor or the successor that
+	// takes over a removed node's keys. It can be nil when it is not known.
+	Node *OverlayNode
+	// Previous predecessor (only for predecessor changes)
+	PreviousNode *OverlayNode
+}
+
+// String returns the human readable name of the event type.
+func (t MembershipEventType) String() string {
+	switch t {
+	case VirtualNodeJoined:
+		return "VirtualNodeJoined"
+	case PredecessorChanged:
+		return "PredecessorChanged"
+	case SuccessorFailed:
+		return "SuccessorFailed"
+	case VirtualNodeRemoved:
+		return "VirtualNodeRemoved"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"github.com/strabox/caravela/configuration"
+	"github.com/strabox/caravela/node/common/guid"
+	"github.com/strabox/caravela/overlay"
+	"sync"
+)
+
+// Memory represents an in-memory overlay local (for each node) structure.
+// All the nodes of the process that use the same ring see the same (chord-like) overlay, without any network
+// communication, which allows testing the discovery protocols with many nodes inside a single process.
+type Memory struct {
+	// Ring shared by all the nodes.
+	ring *Ring
+	// Physical node ID (Higher ID of all the virtual nodes).
+	localID []byte
+	// Mutex to access the physical node ID.
+	mutex sync.RWMutex
+
+	// IP address of the local node.
+	hostIP string
+	// Port of the local node (only used to fill the overlay nodes).
+	hostPort int
+	// Number of virtual nodes in the local "physical node".
+	numVirtualNodes int
+	// Number of nodes returned by a lookup.
+	numSuccessors int
+}
+
+// New creates a new in-memory overlay structure that uses the process' shared ring.
+func New(config *configuration.Configuration) (overlay.Overlay, error) {
+	return NewMemory(SharedRing(), config), nil
+}
+
+// NewMemory creates a new in-memory overlay structure that uses the given ring.
+// It mimics the chord overlay, so it uses the chord's number of virtual nodes and successors.
+func NewMemory(ring *Ring, config *configuration.Configuration) *Memory {
+	return &Memory{
+		ring:    ring,
+		localID: nil,
+		mutex:   sync.RWMutex{},
+
+		hostIP:          config.HostIP(),
+		hostPort:        config.OverlayPort(),
+		numVirtualNodes: config.ChordVirtualNodes(),
+		numSuccessors:   config.ChordNumSuccessors(),
+	}
+}
+
+// join adds the local virtual nodes into the ring.
+func (m *Memory) join(appNode overlay.LocalNode) error {
+	ids, err := m.ring.join(m.hostIP, m.hostPort, appNode, m.numVirtualNodes)
+	if err != nil {
+		return err
+	}
+
+	localID := ids[0]
+	for _, id := range ids {
+		if id.Higher(*localID) {
+			localID = id
+		}
+	}
+	m.mutex.Lock()
+	m.localID = localID.Bytes()
+	m.mutex.Unlock()
+	return nil
+}
+
+/* ============================ Overlay Interface ============================ */
+
+func (m *Memory) Create(ctx context.Context, appNode overlay.LocalNode) error {
+	if err := m.join(appNode); err != nil {
+		return fmt.Errorf("create memory overlay error: %s", err)
+	}
+	return nil
+}
+
+func (m *Memory) Join(ctx context.Context, overlayNodeIP string, overlayNodePort int, appNode overlay.LocalNode) error {
+	if !m.ring.joinable(m.hostIP, overlayNodeIP) {
+		return fmt.Errorf("join memory overlay error: node %s unreachable", overlayNodeIP)
+	}
+
+	if err := m.join(appNode); err != nil {
+		return fmt.Errorf("join memory overlay error: %s", err)
+	}
+	return nil
+}
+
+func (m *Memory) Lookup(ctx context.Context, key []byte) ([]*overlay.OverlayNode, error) {
+	vnodes := m.ring.lookup(m.hostIP, guid.NewGUIDBytes(key), m.numSuccessors)
+
+	res := make([]*overlay.OverlayNode, len(vnodes))
+	for index := range vnodes {
+		res[index] = vnodes[index].overlayNode()
+	}
+	return res, nil
+}
+
+func (m *Memory) Neighbors(ctx context.Context, nodeID []byte) ([]*overlay.OverlayNode, error) {
+	successor, predecessor := m.ring.neighbors(m.hostIP, guid.NewGUIDBytes(nodeID))
+
+	res := make([]*overlay.OverlayNode, 0)
+	if successor != nil {
+		res = append(res, successor.overlayNode()) // The successor of the given node
+	}
+	if predecessor != nil {
+		res = append(res, predecessor.overlayNode()) // The predecessor of the given node
+	}
+	return res, nil
+}
+
+func (m *Memory) NodeID(ctx context.Context) ([]byte, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	if m.localID != nil {
+		return m.localID, nil
+	}
+	return nil, fmt.Errorf("node ID not known yet")
+}
+
+func (m *Memory) Leave(ctx context.Context) error {
+	m.mutex.RLock()
+	joined := m.localID != nil
+	m.mutex.RUnlock()
+	if !joined {
+		return fmt.Errorf("leave memory overlay error: overlay not created/joined")
+	}
+
+	m.ring.leave(m.hostIP)
+	return nil
+}
@@ -0,0 +1,178 @@
+package memory
+
+import (
+	"context"
+	"github.com/strabox/caravela/configuration"
+	"github.com/strabox/caravela/node/common/guid"
+	"github.com/strabox/caravela/overlay"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+// recorderNode is a overlay.LocalNode that records the membership events received.
+type recorderNode struct {
+	events []*overlay.MembershipEvent
+	mutex  sync.Mutex
+}
+
+func (r *recorderNode) MembershipChanged(event *overlay.MembershipEvent) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recorderNode) GUID() string {
+	return ""
+}
+
+func (r *recorderNode) count(eventType overlay.MembershipEventType) int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	res := 0
+	for _, event := range r.events {
+		if event.Type == eventType {
+			res++
+		}
+	}
+	return res
+}
+
+func TestMemory_Lookup_Successors(t *testing.T) {
+	ring := NewRing(1)
+	ips := []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}
+	overlays := make([]*Memory, len(ips))
+	nodes := make([]*recorderNode, len(ips))
+	for i, ip := range ips {
+		overlays[i] = NewMemory(ring, configuration.Default(ip))
+		nodes[i] = &recorderNode{}
+		var err error
+		if i == 0 {
+			err = overlays[i].Create(context.Background(), nodes[i])
+		} else {
+			err = overlays[i].Join(context.Background(), ips[0], 8000, nodes[i])
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	virtualNodes := configuration.Default("10.0.0.1").ChordVirtualNodes()
+	assert.Equal(t, 3*virtualNodes, ring.Size(), "Wrong number of virtual nodes")
+	assert.Equal(t, virtualNodes, nodes[1].count(overlay.VirtualNodeJoined), "Wrong number of joined events")
+
+	// The lookup of a virtual node's ID must return the virtual node itself first.
+	nodeID, err := overlays[2].NodeID(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := overlays[0].Lookup(context.Background(), nodeID)
+	assert.NoError(t, err)
+	assert.Len(t, res, configuration.Default("10.0.0.1").ChordNumSuccessors(), "Wrong number of successors")
+	assert.Equal(t, "10.0.0.3", res[0].IP(), "Wrong responsible node")
+	assert.Equal(t, nodeID, res[0].GUID(), "Wrong responsible virtual node")
+}
+
+func TestMemory_Lookup_AfterFail(t *testing.T) {
+	ring := NewRing(2)
+	ips := []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}
+	overlays := make([]*Memory, len(ips))
+	nodes := make([]*recorderNode, len(ips))
+	for i, ip := range ips {
+		overlays[i] = NewMemory(ring, configuration.Default(ip))
+		nodes[i] = &recorderNode{}
+		var err error
+		if i == 0 {
+			err = overlays[i].Create(context.Background(), nodes[i])
+		} else {
+			err = overlays[i].Join(context.Background(), ips[0], 8000, nodes[i])
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ring.Fail("10.0.0.2")
+
+	res, err := overlays[0].Lookup(context.Background(), guid.NewZero().Bytes())
+	assert.NoError(t, err)
+	for _, node := range res {
+		assert.NotEqual(t, "10.0.0.2", node.IP(), "Failed node returned in lookup")
+	}
+	assert.Equal(t, 0, nodes[1].count(overlay.VirtualNodeRemoved), "Failed node was alerted")
+	assert.True(t, nodes[0].count(overlay.SuccessorFailed)+nodes[2].count(overlay.SuccessorFailed) > 0,
+		"Failure not detected")
+	assert.False(t, ring.Reachable("10.0.0.1", "10.0.0.2"), "Failed node reachable")
+}
+
+func TestMemory_Lookup_Partition(t *testing.T) {
+	ring := NewRing(3)
+	ips := []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4"}
+	overlays := make([]*Memory, len(ips))
+	nodes := make([]*recorderNode, len(ips))
+	for i, ip := range ips {
+		overlays[i] = NewMemory(ring, configuration.Default(ip))
+		nodes[i] = &recorderNode{}
+		var err error
+		if i == 0 {
+			err = overlays[i].Create(context.Background(), nodes[i])
+		} else {
+			err = overlays[i].Join(context.Background(), ips[0], 8000, nodes[i])
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ring.Partition([]string{"10.0.0.1", "10.0.0.2"}, []string{"10.0.0.3", "10.0.0.4"})
+
+	for i := 0; i < 10; i++ {
+		res, err := overlays[2].Lookup(context.Background(), guid.NewGUIDInteger(int64(i*1000)).Bytes())
+		assert.NoError(t, err)
+		for _, node := range res {
+			assert.Contains(t, []string{"10.0.0.3", "10.0.0.4"}, node.IP(), "Node outside partition returned")
+		}
+	}
+
+	ring.Heal()
+	assert.True(t, ring.Reachable("10.0.0.1", "10.0.0.4"), "Partition not healed")
+}
+
+func TestMemory_Leave(t *testing.T) {
+	ring := NewRing(4)
+	ips := []string{"10.0.0.1", "10.0.0.2"}
+	overlays := make([]*Memory, len(ips))
+	nodes := make([]*recorderNode, len(ips))
+	for i, ip := range ips {
+		overlays[i] = NewMemory(ring, configuration.Default(ip))
+		nodes[i] = &recorderNode{}
+		var err error
+		if i == 0 {
+			err = overlays[i].Create(context.Background(), nodes[i])
+		} else {
+			err = overlays[i].Join(context.Background(), ips[0], 8000, nodes[i])
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	joinEvents := len(nodes[0].events)
+	assert.NoError(t, overlays[1].Leave(context.Background()))
+
+	virtualNodes := configuration.Default("10.0.0.1").ChordVirtualNodes()
+	assert.Equal(t, virtualNodes, ring.Size(), "Leaving node still in the ring")
+	assert.Equal(t, virtualNodes, nodes[1].count(overlay.VirtualNodeRemoved), "Wrong number of removed events")
+	for _, event := range nodes[1].events {
+		if event.Type == overlay.VirtualNodeRemoved {
+			assert.Equal(t, "10.0.0.1", event.Node.IP(), "Wrong successor of leaving virtual node")
+		}
+	}
+	assert.Equal(t, 0, nodes[0].count(overlay.SuccessorFailed), "Leaving node alerted as failed")
+	for _, event := range nodes[0].events[joinEvents:] {
+		if event.Type == overlay.PredecessorChanged {
+			assert.Nil(t, event.Node, "Leaving predecessor alerted as failed")
+		}
+	}
+}
@@ -0,0 +1,330 @@
+package memory
+
+import (
+	"fmt"
+	"github.com/strabox/caravela/node/common/guid"
+	"github.com/strabox/caravela/overlay"
+	"math/big"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// sharedRing is the ring used by the overlays created through the overlay factory.
+var sharedRing = NewRing(time.Now().UnixNano())
+
+// SharedRing returns the ring used by the overlays created through the overlay factory, allowing the tests to
+// inject failures and partitions.
+func SharedRing() *Ring {
+	return sharedRing
+}
+
+// vnode is a virtual node of the ring.
+type vnode struct {
+	id      *guid.GUID        // Virtual node's GUID
+	ip      string            // IP of the node where the virtual node runs
+	port    int               // Overlay port of the node where the virtual node runs
+	appNode overlay.LocalNode // Node where the virtual node runs
+}
+
+func (v *vnode) overlayNode() *overlay.OverlayNode {
+	return overlay.NewOverlayNode(v.ip, v.port, v.id.Bytes())
+}
+
+// pendingEvent is a membership event to be delivered into a node (after the ring is unlocked).
+type pendingEvent struct {
+	appNode overlay.LocalNode
+	event   *overlay.MembershipEvent
+}
+
+// Ring is an in-memory chord-like ring of virtual nodes shared by many overlays (nodes) in the same process.
+// It supports the injection of node failures and network partitions.
+type Ring struct {
+	vnodes     []*vnode        // Virtual nodes sorted by GUID
+	failed     map[string]bool // Nodes (IPs) that failed
+	partitions map[string]int  // Network partition of each node (IP), the nodes without one are in the partition 0
+	random     *rand.Rand      // Source of the virtual nodes' GUIDs
+	mutex      sync.Mutex      // Mutex to manage the ring
+}
+
+// NewRing creates a new empty ring. The seed makes the virtual nodes' GUIDs (and so the tests) deterministic.
+func NewRing(seed int64) *Ring {
+	return &Ring{
+		vnodes:     make([]*vnode, 0),
+		failed:     make(map[string]bool),
+		partitions: make(map[string]int),
+		random:     rand.New(rand.NewSource(seed)),
+		mutex:      sync.Mutex{},
+	}
+}
+
+// Fail crashes a node: its virtual nodes disappear from the ring without handing over anything and the
+// neighbors of its virtual nodes are alerted.
+func (r *Ring) Fail(ip string) {
+	r.mutex.Lock()
+	r.failed[ip] = true
+	events := r.remove(ip, true)
+	r.mutex.Unlock()
+
+	deliver(events)
+}
+
+// Partition splits the nodes into groups that can only communicate inside the group. The nodes that are not in
+// any group can only communicate with each other.
+func (r *Ring) Partition(groups ...[]string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.partitions = make(map[string]int)
+	for i, group := range groups {
+		for _, ip := range group {
+			r.partitions[ip] = i + 1
+		}
+	}
+}
+
+// Heal removes all the network partitions.
+func (r *Ring) Heal() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.partitions = make(map[string]int)
+}
+
+// Reachable returns true if a node can communicate with other node, i.e. both are alive and in the same partition.
+func (r *Ring) Reachable(fromIP, toIP string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.reachable(fromIP, toIP)
+}
+
+// Size returns the number of virtual nodes in the ring.
+func (r *Ring) Size() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return len(r.vnodes)
+}
+
+func (r *Ring) reachable(fromIP, toIP string) bool {
+	return !r.failed[fromIP] && !r.failed[toIP] && r.partitions[fromIP] == r.partitions[toIP]
+}
+
+// contains returns true if the ring has virtual nodes of the given node.
+func (r *Ring) contains(ip string) bool {
+	for _, v := range r.vnodes {
+		if v.ip == ip {
+			return true
+		}
+	}
+	return false
+}
+
+// newGUID generates a random GUID that is not used by any virtual node.
+func (r *Ring) newGUID() *guid.GUID {
+	for {
+		id := big.NewInt(0).Rand(r.random, guid.MaximumGUID().BigInt())
+		newID := guid.NewGUIDBigInt(id)
+		if r.search(newID) == len(r.vnodes) || !r.vnodes[r.search(newID)].id.Equals(*newID) {
+			return newID
+		}
+	}
+}
+
+// search returns the index of the first virtual node with a GUID higher or equal than the given one.
+func (r *Ring) search(id *guid.GUID) int {
+	return sort.Search(len(r.vnodes), func(i int) bool {
+		return !r.vnodes[i].id.Lower(*id)
+	})
+}
+
+// joinable returns true if a node can join the ring through the given (bootstrap) node.
+func (r *Ring) joinable(fromIP, bootstrapIP string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.contains(bootstrapIP) && r.reachable(fromIP, bootstrapIP)
+}
+
+// join adds the virtual nodes of a node into the ring.
+func (r *Ring) join(ip string, port int, appNode overlay.LocalNode, numVirtualNodes int) ([]*guid.GUID, error) {
+	r.mutex.Lock()
+	if r.contains(ip) {
+		r.mutex.Unlock()
+		return nil, fmt.Errorf("node %s is already in the ring", ip)
+	}
+	delete(r.failed, ip)
+
+	ids := make([]*guid.GUID, 0, numVirtualNodes)
+	events := make([]pendingEvent, 0)
+	for i := 0; i < numVirtualNodes; i++ {
+		newVnode := &vnode{id: r.newGUID(), ip: ip, port: port, appNode: appNode}
+		index := r.search(newVnode.id)
+		r.vnodes = append(r.vnodes, nil)
+		copy(r.vnodes[index+1:], r.vnodes[index:])
+		r.vnodes[index] = newVnode
+		ids = append(ids, newVnode.id)
+
+		predecessor, successor := r.predecessorOf(index), r.successorOf(index)
+		events = append(events, pendingEvent{appNode: appNode, event: &overlay.MembershipEvent{
+			Type:        overlay.VirtualNodeJoined,
+			LocalNodeID: newVnode.id.Bytes(),
+			Node:        overlayNodeOf(predecessor),
+		}})
+		if successor != nil && successor.ip != ip {
+			events = append(events, pendingEvent{appNode: successor.appNode, event: &overlay.MembershipEvent{
+				Type:         overlay.PredecessorChanged,
+				LocalNodeID:  successor.id.Bytes(),
+				Node:         newVnode.overlayNode(),
+				PreviousNode: overlayNodeOf(predecessor),
+			}})
+		}
+	}
+	r.mutex.Unlock()
+
+	deliver(events)
+	return ids, nil
+}
+
+// leave removes the virtual nodes of a node that leaves gracefully from the ring. Each virtual node is alerted,
+// with the node that takes over its GUIDs, before the neighbors.
+func (r *Ring) leave(ip string) {
+	r.mutex.Lock()
+	removing := make([]pendingEvent, 0)
+	for index, v := range r.vnodes {
+		if v.ip == ip {
+			var successor *vnode = nil
+			for i := 1; i < len(r.vnodes); i++ { // First virtual node of other node that follows it.
+				if candidate := r.vnodes[(index+i)%len(r.vnodes)]; candidate.ip != ip {
+					successor = candidate
+					break
+				}
+			}
+			removing = append(removing, pendingEvent{appNode: v.appNode, event: &overlay.MembershipEvent{
+				Type:        overlay.VirtualNodeRemoved,
+				LocalNodeID: v.id.Bytes(),
+				Node:        overlayNodeOf(successor),
+			}})
+		}
+	}
+	r.mutex.Unlock()
+
+	deliver(removing) // Virtual nodes hand over their state while they are still in the ring.
+
+	r.mutex.Lock()
+	events := r.remove(ip, false)
+	r.mutex.Unlock()
+
+	deliver(events)
+}
+
+// remove removes the virtual nodes of a node from the ring, returning the events for their neighbors. Only the
+// neighbors of a failed node are alerted about the failure, as chord does, the neighbors of a node that left
+// gracefully only see their predecessor leaving (nil new predecessor) because it already handed over its state.
+func (r *Ring) remove(ip string, failed bool) []pendingEvent {
+	events := make([]pendingEvent, 0)
+	for index := 0; index < len(r.vnodes); {
+		removed := r.vnodes[index]
+		if removed.ip != ip {
+			index++
+			continue
+		}
+
+		r.vnodes = append(r.vnodes[:index], r.vnodes[index+1:]...)
+		if len(r.vnodes) == 0 {
+			break
+		}
+		// After the removal, the predecessor is at index-1 and the successor at index.
+		predecessor, successor := r.vnodes[(index-1+len(r.vnodes))%len(r.vnodes)], r.vnodes[index%len(r.vnodes)]
+		if failed && predecessor.ip != ip {
+			events = append(events, pendingEvent{appNode: predecessor.appNode, event: &overlay.MembershipEvent{
+				Type:        overlay.SuccessorFailed,
+				LocalNodeID: predecessor.id.Bytes(),
+				Node:        removed.overlayNode(),
+			}})
+		}
+		if successor.ip != ip {
+			var newPredecessor *overlay.OverlayNode = nil
+			if failed {
+				newPredecessor = predecessor.overlayNode()
+			}
+			events = append(events, pendingEvent{appNode: successor.appNode, event: &overlay.MembershipEvent{
+				Type:         overlay.PredecessorChanged,
+				LocalNodeID:  successor.id.Bytes(),
+				Node:         newPredecessor,
+				PreviousNode: removed.overlayNode(),
+			}})
+		}
+	}
+	return events
+}
+
+// lookup returns the (at most) n virtual nodes, visible from the given node, responsible for the key, i.e. the
+// first virtual node with a GUID higher or equal than the key and the following ones.
+func (r *Ring) lookup(fromIP string, key *guid.GUID, n int) []*vnode {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	res := make([]*vnode, 0, n)
+	start := r.search(key)
+	for i := 0; i < len(r.vnodes) && len(res) < n; i++ {
+		if v := r.vnodes[(start+i)%len(r.vnodes)]; v.ip == fromIP || r.reachable(fromIP, v.ip) {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// neighbors returns the successor and the predecessor (visible from the given node) of a virtual node.
+func (r *Ring) neighbors(fromIP string, id *guid.GUID) (*vnode, *vnode) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	visible := func(v *vnode) bool {
+		return !v.id.Equals(*id) && (v.ip == fromIP || r.reachable(fromIP, v.ip))
+	}
+
+	var successor, predecessor *vnode = nil, nil
+	start := r.search(id)
+	for i := 0; i < len(r.vnodes) && successor == nil; i++ {
+		if v := r.vnodes[(start+i)%len(r.vnodes)]; visible(v) {
+			successor = v
+		}
+	}
+	for i := 1; i <= len(r.vnodes) && predecessor == nil; i++ {
+		if v := r.vnodes[(start-i+len(r.vnodes))%len(r.vnodes)]; visible(v) {
+			predecessor = v
+		}
+	}
+	return successor, predecessor
+}
+
+func (r *Ring) predecessorOf(index int) *vnode {
+	if len(r.vnodes) < 2 {
+		return nil
+	}
+	return r.vnodes[(index-1+len(r.vnodes))%len(r.vnodes)]
+}
+
+func (r *Ring) successorOf(index int) *vnode {
+	if len(r.vnodes) < 2 {
+		return nil
+	}
+	return r.vnodes[(index+1)%len(r.vnodes)]
+}
+
+func overlayNodeOf(v *vnode) *overlay.OverlayNode {
+	if v == nil {
+		return nil
+	}
+	return v.overlayNode()
+}
+
+// deliver delivers the membership events into the respective nodes.
+func deliver(events []pendingEvent) {
+	for _, pending := range events {
+		pending.appNode.MembershipChanged(pending.event)
+	}
+}
@@ -0,0 +1,184 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	log "github.com/Sirupsen/logrus"
+	dockerClient "github.com/docker/docker/client"
+	caravelaTypes "github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/configuration"
+	"github.com/strabox/caravela/util"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ArchiveBackend represents a image storage backend that loads the images from docker save archives, stored in
+// a local directory or in an HTTP file server (useful for sites without internet access).
+// An index file, in the same location, maps the images keys to the archives and their checksums.
+type ArchiveBackend struct {
+	BaseBackend
+	location   string       // Directory or HTTP(S) URL where the archives are stored.
+	indexFile  string       // Name of the index file inside the location.
+	httpClient *http.Client // Client used when the location is an HTTP file server.
+}
+
+// archiveIndexEntry represents an image in the archives index.
+type archiveIndexEntry struct {
+	File   string `json:"File"`   // Archive file, relative to the location.
+	SHA256 string `json:"SHA256"` // Hexadecimal SHA256 checksum of the archive file.
+}
+
+// newArchiveBackend creates a new ArchiveBackend storage backend.
+func newArchiveBackend(config *configuration.Configuration) (Backend, error) {
+	return &ArchiveBackend{
+		BaseBackend: BaseBackend{},
+		location:    config.ArchiveLocation(),
+		indexFile:   config.ArchiveIndexFile(),
+		httpClient: &http.Client{
+			Timeout: config.ArchiveDownloadTimeout(),
+		},
+	}, nil
+}
+
+func (a *ArchiveBackend) Init(dockerClient *dockerClient.Client) {
+	a.docker = dockerClient
+}
+
+func (a *ArchiveBackend) LoadImage(imageKey string, _ *caravelaTypes.RegistryAuth) (string, error) {
+	archivePath, cleanup, err := a.fetchArchive(imageKey)
+	if err != nil {
+		log.Errorf(util.LogTag("Archive")+"Obtaining image %s archive error: %s", imageKey, err)
+		return imageKey, err
+	}
+	defer cleanup()
+
+	archive, err := os.Open(archivePath)
+	if err != nil {
+		return imageKey, err
+	}
+	defer archive.Close()
+
+	loadResp, err := a.docker.ImageLoad(context.Background(), archive, true)
+	if err != nil {
+		log.Errorf(util.LogTag("Archive")+"Loading image %s error: %s", imageKey, err)
+		return imageKey, err
+	}
+	defer loadResp.Body.Close()
+
+	if _, err := ioutil.ReadAll(loadResp.Body); err != nil {
+		log.Errorf(util.LogTag("Archive")+"Reading image %s load error: %s", imageKey, err)
+		return imageKey, err
+	}
+	return imageKey, nil
+}
+
+// fetchArchive returns the path of a local copy of the image's archive, with its checksum already verified.
+// The cleanup function must be called when the archive is no longer necessary.
+func (a *ArchiveBackend) fetchArchive(imageKey string) (string, func(), error) {
+	noCleanup := func() {}
+
+	index, err := a.readIndex()
+	if err != nil {
+		return "", noCleanup, err
+	}
+
+	entry, exist := index[imageKey]
+	if !exist {
+		return "", noCleanup, fmt.Errorf("image %s not present in the archives index", imageKey)
+	}
+
+	if !a.isRemote() { // Verify the archive directly in the local directory.
+		archivePath := filepath.Join(a.location, filepath.FromSlash(entry.File))
+		archive, err := os.Open(archivePath)
+		if err != nil {
+			return "", noCleanup, err
+		}
+		defer archive.Close()
+
+		if err := verifyChecksum(archive, ioutil.Discard, entry.SHA256); err != nil {
+			return "", noCleanup, err
+		}
+		return archivePath, noCleanup, nil
+	}
+
+	remoteArchive, err := a.openRemote(entry.File)
+	if err != nil {
+		return "", noCleanup, err
+	}
+	defer remoteArchive.Close()
+
+	tempFile, err := ioutil.TempFile("", "caravela_archive")
+	if err != nil {
+		return "", noCleanup, err
+	}
+	cleanup := func() {
+		os.Remove(tempFile.Name())
+	}
+	defer tempFile.Close()
+
+	if err := verifyChecksum(remoteArchive, tempFile, entry.SHA256); err != nil {
+		cleanup()
+		return "", noCleanup, err
+	}
+	return tempFile.Name(), cleanup, nil
+}
+
+// readIndex reads the index that maps the images keys to the archives.
+func (a *ArchiveBackend) readIndex() (map[string]archiveIndexEntry, error) {
+	var indexReader io.ReadCloser
+	var err error
+	if a.isRemote() {
+		indexReader, err = a.openRemote(a.indexFile)
+	} else {
+		indexReader, err = os.Open(filepath.Join(a.location, a.indexFile))
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer indexReader.Close()
+
+	index := make(map[string]archiveIndexEntry)
+	if err := json.NewDecoder(indexReader).Decode(&index); err != nil {
+		return nil, fmt.Errorf("invalid archives index: %s", err)
+	}
+	return index, nil
+}
+
+// openRemote obtains a file from the HTTP file server.
+func (a *ArchiveBackend) openRemote(file string) (io.ReadCloser, error) {
+	resp, err := a.httpClient.Get(strings.TrimSuffix(a.location, "/") + "/" + strings.TrimPrefix(file, "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("impossible obtain %s, HTTP status: %d", file, resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+// isRemote returns true if the archives are stored in an HTTP file server, false if stored in a local directory.
+func (a *ArchiveBackend) isRemote() bool {
+	return strings.HasPrefix(a.location, "http://") || strings.HasPrefix(a.location, "https://")
+}
+
+// verifyChecksum copies the archive content into the destination verifying if it matches the expected checksum.
+func verifyChecksum(archive io.Reader, destination io.Writer, expectedSHA256 string) error {
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(hash, destination), archive); err != nil {
+		return err
+	}
+
+	if checksum := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(checksum, expectedSHA256) {
+		return fmt.Errorf("archive checksum mismatch, expected: %s, obtained: %s", expectedSHA256, checksum)
+	}
+	return nil
+}
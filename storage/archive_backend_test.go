@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/strabox/caravela/configuration"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const archiveContentTest = "docker save archive content"
+
+func TestArchiveBackend_FetchArchive_Directory(t *testing.T) {
+	checksum := sha256.Sum256([]byte(archiveContentTest))
+	dir, err := ioutil.TempDir("", "caravela_archives")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, "redis.tar"), []byte(archiveContentTest), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "index.json"), []byte(fmt.Sprintf(
+		`{"redis:alpine": {"File": "redis.tar", "SHA256": "%s"}}`, hex.EncodeToString(checksum[:]))), 0644)
+	config := configuration.Default("127.0.0.1")
+	config.ImagesStorage.ArchiveBackend.Location = dir
+	backend, _ := newArchiveBackend(config)
+
+	archivePath, cleanup, err := backend.(*ArchiveBackend).fetchArchive("redis:alpine")
+	defer cleanup()
+
+	assert.Nil(t, err, "Fetching the archive should not fail!")
+	assert.Equal(t, filepath.Join(dir, "redis.tar"), archivePath, "Archive path is incorrect!")
+
+	_, _, err = backend.(*ArchiveBackend).fetchArchive("nginx:latest")
+	assert.NotNil(t, err, "Fetching an image not indexed should fail!")
+}
+
+func TestArchiveBackend_FetchArchive_HTTP(t *testing.T) {
+	checksum := sha256.Sum256([]byte(archiveContentTest))
+	dir, err := ioutil.TempDir("", "caravela_archives")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, "redis.tar"), []byte(archiveContentTest), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "index.json"), []byte(fmt.Sprintf(
+		`{"redis:alpine": {"File": "redis.tar", "SHA256": "%s"}}`, hex.EncodeToString(checksum[:]))), 0644)
+	server := httptest.NewServer(http.FileServer(http.Dir(dir)))
+	defer server.Close()
+	config := configuration.Default("127.0.0.1")
+	config.ImagesStorage.ArchiveBackend.Location = server.URL
+	backend, _ := newArchiveBackend(config)
+
+	archivePath, cleanup, err := backend.(*ArchiveBackend).fetchArchive("redis:alpine")
+
+	assert.Nil(t, err, "Fetching the archive should not fail!")
+	content, _ := ioutil.ReadFile(archivePath)
+	assert.Equal(t, archiveContentTest, string(content), "Archive content is incorrect!")
+	cleanup()
+	_, err = os.Stat(archivePath)
+	assert.True(t, os.IsNotExist(err), "Downloaded archive should be removed!")
+}
+
+func TestArchiveBackend_FetchArchive_ChecksumMismatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "caravela_archives")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, "redis.tar"), []byte(archiveContentTest), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "index.json"),
+		[]byte(`{"redis:alpine": {"File": "redis.tar", "SHA256": "0000"}}`), 0644)
+	server := httptest.NewServer(http.FileServer(http.Dir(dir)))
+	defer server.Close()
+
+	dirConfig := configuration.Default("127.0.0.1")
+	dirConfig.ImagesStorage.ArchiveBackend.Location = dir
+	dirBackend, _ := newArchiveBackend(dirConfig)
+	_, _, err = dirBackend.(*ArchiveBackend).fetchArchive("redis:alpine")
+	assert.NotNil(t, err, "Checksum mismatch in directory should fail!")
+
+	httpConfig := configuration.Default("127.0.0.1")
+	httpConfig.ImagesStorage.ArchiveBackend.Location = server.URL
+	httpBackend, _ := newArchiveBackend(httpConfig)
+	_, _, err = httpBackend.(*ArchiveBackend).fetchArchive("redis:alpine")
+	assert.NotNil(t, err, "Checksum mismatch in HTTP should fail!")
+}
@@ -1,17 +1,67 @@
 package storage
 
-import dockerClient "github.com/docker/docker/client"
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	log "github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types"
+	dockerClient "github.com/docker/docker/client"
+	caravelaTypes "github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/util"
+	"io/ioutil"
+)
 
 // Backend interface for container's images storage backends.
 type Backend interface {
 	// Init initialize the storage backend with the docker client (SDK).
 	Init(dockerClient *dockerClient.Client)
 
+	// SetPeers provides the backend with a way to share images with other CARAVELA's nodes.
+	SetPeers(peers ImagesPeers)
+
 	// Load loads the image inside the docker engine and returns the image key assigned inside the docker engine.
-	LoadImage(imageKey string) (string, error)
+	// The registry credentials are optional (nil) and are only used to pull the image from a registry.
+	LoadImage(imageKey string, auth *caravelaTypes.RegistryAuth) (string, error)
 }
 
 // BaseBackend is a base for all the storage backends.
 type BaseBackend struct {
 	docker *dockerClient.Client
 }
+
+// SetPeers is ignored by default, only the backends that share images between nodes use it.
+func (b *BaseBackend) SetPeers(_ ImagesPeers) {}
+
+// pullImage pulls an image from a registry using the given encoded registry credentials (can be empty).
+func (b *BaseBackend) pullImage(logTag, imageKey, registryAuth string) error {
+	out, err := b.docker.ImagePull(context.Background(), imageKey, types.ImagePullOptions{RegistryAuth: registryAuth})
+	if err != nil { // Error pulling the image from Docker
+		log.Errorf(util.LogTag(logTag)+"Pulling container image error: %s", err)
+		return err
+	}
+	defer out.Close()
+
+	if _, err := ioutil.ReadAll(out); err != nil {
+		log.Errorf(util.LogTag(logTag)+"Reading container image error: %s", err)
+		return err
+	}
+	return nil
+}
+
+// encodeRegistryAuth encodes the registry credentials in the format expected by the Docker engine.
+func encodeRegistryAuth(auth *caravelaTypes.RegistryAuth) (string, error) {
+	if auth == nil {
+		return "", nil
+	}
+
+	authJSON, err := json.Marshal(types.AuthConfig{
+		Username:      auth.Username,
+		Password:      auth.Password,
+		ServerAddress: auth.ServerAddress,
+	})
+	if err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(authJSON), nil
+}
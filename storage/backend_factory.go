@@ -9,7 +9,7 @@ import (
 )
 
 // BackendFactory represents a method that creates a new image storage backend.
-type BackendFactory func() (Backend, error)
+type BackendFactory func(config *configuration.Configuration) (Backend, error)
 
 // backends holds all the registered image storage backends available.
 var backends = make(map[string]BackendFactory)
@@ -17,6 +17,9 @@ var backends = make(map[string]BackendFactory)
 // init initializes our predefined storage backends.
 func init() {
 	RegisterBackend("DockerHub", newDockerHubBackend)
+	RegisterBackend("Peers", newPeersBackend)
+	RegisterBackend("Registry", newRegistryBackend)
+	RegisterBackend("Archive", newArchiveBackend)
 }
 
 // RegisterBackend can be used to register a new storage backend in order to be available.
@@ -46,7 +49,7 @@ func CreateBackend(config *configuration.Configuration) Backend {
 		log.Panic(err)
 	}
 
-	backend, err := backendFactory()
+	backend, err := backendFactory(config)
 	if err != nil {
 		log.Panic(err)
 	}
@@ -1,12 +1,9 @@
 package storage
 
 import (
-	"context"
-	log "github.com/Sirupsen/logrus"
-	"github.com/docker/docker/api/types"
 	dockerClient "github.com/docker/docker/client"
-	"github.com/strabox/caravela/util"
-	"io/ioutil"
+	caravelaTypes "github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/configuration"
 )
 
 // DockerHubBackend represents a image storage backup that uses the Docker public registry to store
@@ -16,7 +13,7 @@ type DockerHubBackend struct {
 }
 
 // newDockerHubBackend creates a new DockerHubBackend storage backend.
-func newDockerHubBackend() (Backend, error) {
+func newDockerHubBackend(_ *configuration.Configuration) (Backend, error) {
 	return &DockerHubBackend{
 		BaseBackend: BaseBackend{},
 	}, nil
@@ -26,18 +23,11 @@ func (dockerHub *DockerHubBackend) Init(dockerClient *dockerClient.Client) {
 	dockerHub.docker = dockerClient
 }
 
-func (dockerHub *DockerHubBackend) LoadImage(imageKey string) (string, error) {
-	out, err := dockerHub.docker.ImagePull(context.Background(), imageKey, types.ImagePullOptions{})
-	if err != nil { // Error pulling the image from Docker
-		log.Errorf(util.LogTag("DockerHub")+"Pulling container image error: %s", err)
+func (dockerHub *DockerHubBackend) LoadImage(imageKey string, auth *caravelaTypes.RegistryAuth) (string, error) {
+	registryAuth, err := encodeRegistryAuth(auth)
+	if err != nil {
 		return imageKey, err
 	}
-	defer out.Close()
 
-	if _, err := ioutil.ReadAll(out); err != nil {
-		log.Errorf(util.LogTag("DockerHub")+"Reading container image error: %s", err)
-		return imageKey, err
-	}
-
-	return imageKey, nil
+	return imageKey, dockerHub.pullImage("DockerHub", imageKey, registryAuth)
 }
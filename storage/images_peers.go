@@ -0,0 +1,18 @@
+package storage
+
+import (
+	"context"
+	"io"
+)
+
+// ImagesPeers allows a storage backend to share container's images with other CARAVELA's nodes.
+type ImagesPeers interface {
+	// FindImageHolders returns the IP addresses of the nodes that advertised holding the image.
+	FindImageHolders(ctx context.Context, imageKey string) []string
+
+	// DownloadImage obtains the image (in the docker save format) from the given holder node.
+	DownloadImage(ctx context.Context, holderIP string, imageKey string) (io.ReadCloser, error)
+
+	// AdvertiseImage advertises that the local node holds an image, known by the given keys.
+	AdvertiseImage(ctx context.Context, imageKeys ...string)
+}
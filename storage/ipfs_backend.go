@@ -1,6 +1,10 @@
 package storage
 
-import dockerClient "github.com/docker/docker/client"
+import (
+	dockerClient "github.com/docker/docker/client"
+	caravelaTypes "github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/configuration"
+)
 
 // IPFSBackend is a backend implemented on top of the highly distributed IPFS file system.
 type IPFSBackend struct {
@@ -8,7 +12,7 @@ type IPFSBackend struct {
 }
 
 // newIPFSBackend creates a new IPFSBackend storage backend.
-func newIPFSBackend() (Backend, error) {
+func newIPFSBackend(_ *configuration.Configuration) (Backend, error) {
 	return &IPFSBackend{
 		BaseBackend: BaseBackend{},
 	}, nil
@@ -18,7 +22,7 @@ func (ipfs *IPFSBackend) Init(dockerClient *dockerClient.Client) {
 	ipfs.docker = dockerClient
 }
 
-func (ipfs *IPFSBackend) LoadImage(imageKey string) (string, error) {
+func (ipfs *IPFSBackend) LoadImage(imageKey string, _ *caravelaTypes.RegistryAuth) (string, error) {
 	// To be implemented if there is time.
 	return "", nil
 }
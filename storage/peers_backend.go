@@ -0,0 +1,175 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	log "github.com/Sirupsen/logrus"
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	dockerClient "github.com/docker/docker/client"
+	caravelaTypes "github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/configuration"
+	"github.com/strabox/caravela/util"
+	"io/ioutil"
+	"strings"
+	"sync"
+)
+
+// PeersBackend represents a image storage backend where the images are shared between the CARAVELA's nodes.
+// A node missing an image pinned by digest first tries to obtain it from other nodes that hold it (found through the
+// overlay), and only if that fails it pulls the image from the Docker public registry. The images referenced by tag
+// are always pulled from the registry, because the content sent by other node could not be verified.
+type PeersBackend struct {
+	DockerHubBackend                 // Fallback used when no other node can provide the image.
+	peers            ImagesPeers     // Used to find and download images from other nodes.
+	privateImages    map[string]bool // Images pulled with credentials, that must never be shared.
+	advertised       map[string]bool // Images already advertised (the images manager keeps re-advertising them).
+	mutex            sync.Mutex      // Mutex to control the access to the private and advertised images.
+}
+
+// newPeersBackend creates a new PeersBackend storage backend.
+func newPeersBackend(_ *configuration.Configuration) (Backend, error) {
+	return &PeersBackend{
+		DockerHubBackend: DockerHubBackend{
+			BaseBackend: BaseBackend{},
+		},
+		peers:         nil,
+		privateImages: make(map[string]bool),
+		advertised:    make(map[string]bool),
+		mutex:         sync.Mutex{},
+	}, nil
+}
+
+func (p *PeersBackend) Init(dockerClient *dockerClient.Client) {
+	p.docker = dockerClient
+}
+
+func (p *PeersBackend) SetPeers(peers ImagesPeers) {
+	p.peers = peers
+}
+
+func (p *PeersBackend) LoadImage(imageKey string, auth *caravelaTypes.RegistryAuth) (string, error) {
+	ctx := context.Background()
+
+	if auth != nil { // Private images are never shared, the registry must validate the credentials.
+		p.mutex.Lock()
+		p.privateImages[imageKey] = true
+		p.mutex.Unlock()
+		return p.DockerHubBackend.LoadImage(imageKey, auth)
+	}
+
+	p.mutex.Lock()
+	private := p.privateImages[imageKey]
+	p.mutex.Unlock()
+	if private { // Without credentials the registry decides if the image can be used.
+		return p.DockerHubBackend.LoadImage(imageKey, auth)
+	}
+
+	if _, _, err := p.docker.ImageInspectWithRaw(ctx, imageKey); err == nil { // Image already in the engine.
+		p.advertise(imageKey)
+		return imageKey, nil
+	}
+
+	if digest, pinned := pinnedDigest(imageKey); pinned && p.peers != nil {
+		for _, holderIP := range p.peers.FindImageHolders(ctx, imageKey) {
+			if err := p.loadFromPeer(ctx, holderIP, imageKey, digest); err != nil {
+				log.Warnf(util.LogTag("Peers")+"Loading image %s from %s error: %s", imageKey, holderIP, err)
+				continue
+			}
+			log.Debugf(util.LogTag("Peers")+"Image %s LOADED from %s", imageKey, holderIP)
+			p.advertise(imageKey)
+			return imageKey, nil
+		}
+	}
+
+	if _, err := p.DockerHubBackend.LoadImage(imageKey, auth); err != nil {
+		return imageKey, err
+	}
+	p.advertise(imageKey)
+	return imageKey, nil
+}
+
+// loadFromPeer downloads the image from a holder node and loads it into the docker engine, verifying that the
+// image loaded is the one pinned by the digest.
+func (p *PeersBackend) loadFromPeer(ctx context.Context, holderIP, imageKey, digest string) error {
+	imageArchive, err := p.peers.DownloadImage(ctx, holderIP, imageKey)
+	if err != nil {
+		return err
+	}
+	defer imageArchive.Close()
+
+	loadResp, err := p.docker.ImageLoad(ctx, imageArchive, true)
+	if err != nil {
+		return err
+	}
+	defer loadResp.Body.Close()
+
+	if _, err := ioutil.ReadAll(loadResp.Body); err != nil {
+		return err
+	}
+
+	imageInspect, _, err := p.docker.ImageInspectWithRaw(ctx, imageKey)
+	if err != nil {
+		return fmt.Errorf("image not present after load: %s", err)
+	}
+	if !matchesDigest(imageInspect, digest) {
+		return fmt.Errorf("image loaded does not match the digest %s", digest)
+	}
+	return nil
+}
+
+// pinnedDigest returns the digest of an image reference pinned by digest (e.g. redis@sha256:...) or by image ID
+// (sha256:...), false if the reference is a tag.
+func pinnedDigest(imageKey string) (string, bool) {
+	imageRef, err := reference.ParseAnyReference(imageKey)
+	if err != nil {
+		return "", false
+	}
+	digested, isDigested := imageRef.(reference.Digested)
+	if !isDigested {
+		return "", false
+	}
+	return digested.Digest().String(), true
+}
+
+// matchesDigest returns true if the image's ID or one of its repository digests is the given digest.
+func matchesDigest(imageInspect types.ImageInspect, digest string) bool {
+	if imageInspect.ID == digest {
+		return true
+	}
+	for _, repoDigest := range imageInspect.RepoDigests {
+		if strings.HasSuffix(repoDigest, "@"+digest) {
+			return true
+		}
+	}
+	return false
+}
+
+// advertise advertises the image, using its key and its digests, to the other nodes.
+// Each image is advertised only once and in background, so it does not delay the container's launch.
+func (p *PeersBackend) advertise(imageKey string) {
+	if p.peers == nil {
+		return
+	}
+
+	p.mutex.Lock()
+	if p.advertised[imageKey] {
+		p.mutex.Unlock()
+		return
+	}
+	p.advertised[imageKey] = true
+	p.mutex.Unlock()
+
+	go func() {
+		ctx := context.Background()
+		imageKeys := []string{imageKey}
+		if imageInspect, _, err := p.docker.ImageInspectWithRaw(ctx, imageKey); err == nil {
+			for _, repoDigest := range imageInspect.RepoDigests {
+				if repoDigest != imageKey {
+					imageKeys = append(imageKeys, repoDigest)
+				}
+			}
+		}
+		p.peers.AdvertiseImage(ctx, imageKeys...)
+	}()
+}
@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const peersTestDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestPinnedDigest(t *testing.T) {
+	digest, pinned := pinnedDigest("redis@" + peersTestDigest)
+	assert.True(t, pinned, "Image pinned by digest not detected")
+	assert.Equal(t, peersTestDigest, digest)
+
+	digest, pinned = pinnedDigest(peersTestDigest)
+	assert.True(t, pinned, "Image pinned by ID not detected")
+	assert.Equal(t, peersTestDigest, digest)
+
+	_, pinned = pinnedDigest("redis:alpine")
+	assert.False(t, pinned, "Image referenced by tag can't be verified")
+}
+
+func TestMatchesDigest(t *testing.T) {
+	assert.True(t, matchesDigest(types.ImageInspect{ID: peersTestDigest}, peersTestDigest))
+	assert.True(t, matchesDigest(types.ImageInspect{ID: "sha256:other",
+		RepoDigests: []string{"redis@" + peersTestDigest}}, peersTestDigest))
+	assert.False(t, matchesDigest(types.ImageInspect{ID: "sha256:other", RepoDigests: []string{"redis@sha256:other"}},
+		peersTestDigest), "Image with other content accepted")
+}
@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/docker/distribution/reference"
+	dockerClient "github.com/docker/docker/client"
+	caravelaTypes "github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/configuration"
+	"io/ioutil"
+	"strings"
+)
+
+// Domain used by the Docker public registry in the images references.
+const dockerHubDomain = "docker.io"
+
+// RegistryBackend represents a image storage backend that pulls the images from private (or public) registries.
+// The credentials of each registry come from the configuration or from a Docker's config.json like file, the
+// credentials given in a deployment request take precedence over them.
+type RegistryBackend struct {
+	BaseBackend
+	credentials map[string]caravelaTypes.RegistryAuth // Credentials of each registry (registry domain->credentials).
+}
+
+// newRegistryBackend creates a new RegistryBackend storage backend.
+func newRegistryBackend(config *configuration.Configuration) (Backend, error) {
+	credentials := make(map[string]caravelaTypes.RegistryAuth)
+
+	if config.RegistryCredentialsFile() != "" {
+		fileCredentials, err := readCredentialsFile(config.RegistryCredentialsFile())
+		if err != nil {
+			return nil, err
+		}
+		for registry, auth := range fileCredentials {
+			credentials[registry] = auth
+		}
+	}
+
+	for _, registryCredentials := range config.RegistryCredentials() { // Configuration overrides the file.
+		registry := normalizeRegistry(registryCredentials.Registry)
+		credentials[registry] = caravelaTypes.RegistryAuth{
+			ServerAddress: registryCredentials.Registry,
+			Username:      registryCredentials.Username,
+			Password:      registryCredentials.Password,
+		}
+	}
+
+	return &RegistryBackend{
+		BaseBackend: BaseBackend{},
+		credentials: credentials,
+	}, nil
+}
+
+func (r *RegistryBackend) Init(dockerClient *dockerClient.Client) {
+	r.docker = dockerClient
+}
+
+func (r *RegistryBackend) LoadImage(imageKey string, auth *caravelaTypes.RegistryAuth) (string, error) {
+	registryAuth, err := encodeRegistryAuth(r.pullCredentials(imageKey, auth))
+	if err != nil {
+		return imageKey, err
+	}
+
+	return imageKey, r.pullImage("Registry", imageKey, registryAuth)
+}
+
+// pullCredentials returns the credentials used to pull the image, nil if the pull is anonymous.
+func (r *RegistryBackend) pullCredentials(imageKey string, auth *caravelaTypes.RegistryAuth) *caravelaTypes.RegistryAuth {
+	if auth != nil {
+		return auth
+	}
+
+	imageRef, err := reference.ParseNormalizedNamed(imageKey)
+	if err != nil {
+		return nil
+	}
+
+	if registryAuth, exist := r.credentials[reference.Domain(imageRef)]; exist {
+		return &registryAuth
+	}
+	return nil
+}
+
+// dockerConfigFile represents the relevant content of a Docker's config.json credentials file.
+type dockerConfigFile struct {
+	Auths map[string]struct {
+		Auth     string `json:"auth"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+	} `json:"auths"`
+}
+
+// readCredentialsFile reads the registries credentials from a Docker's config.json like file.
+func readCredentialsFile(filePath string) (map[string]caravelaTypes.RegistryAuth, error) {
+	fileContent, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("impossible read registry credentials file %s: %s", filePath, err)
+	}
+
+	var configFile dockerConfigFile
+	if err := json.Unmarshal(fileContent, &configFile); err != nil {
+		return nil, fmt.Errorf("invalid registry credentials file %s: %s", filePath, err)
+	}
+
+	res := make(map[string]caravelaTypes.RegistryAuth)
+	for registry, entry := range configFile.Auths {
+		username, password := entry.Username, entry.Password
+		if entry.Auth != "" {
+			decodedAuth, err := base64.StdEncoding.DecodeString(entry.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("invalid auth of registry %s in credentials file", registry)
+			}
+			userPass := strings.SplitN(string(decodedAuth), ":", 2)
+			if len(userPass) != 2 {
+				return nil, fmt.Errorf("invalid auth of registry %s in credentials file", registry)
+			}
+			username, password = userPass[0], userPass[1]
+		}
+		res[normalizeRegistry(registry)] = caravelaTypes.RegistryAuth{
+			ServerAddress: registry,
+			Username:      username,
+			Password:      password,
+		}
+	}
+	return res, nil
+}
+
+// normalizeRegistry converts a registry address (e.g. https://index.docker.io/v1/) into the domain used
+// in the images references (e.g. docker.io).
+func normalizeRegistry(registry string) string {
+	registry = strings.TrimPrefix(strings.TrimPrefix(registry, "https://"), "http://")
+	registry = strings.SplitN(registry, "/", 2)[0]
+	if registry == "index.docker.io" || registry == "registry-1.docker.io" {
+		return dockerHubDomain
+	}
+	return registry
+}
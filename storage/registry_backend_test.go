@@ -0,0 +1,51 @@
+package storage
+
+import (
+	caravelaTypes "github.com/strabox/caravela/api/types"
+	"github.com/strabox/caravela/configuration"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestNormalizeRegistry(t *testing.T) {
+	assert.Equal(t, "docker.io", normalizeRegistry("https://index.docker.io/v1/"), "Docker Hub domain is incorrect!")
+	assert.Equal(t, "registry.example.com:5000", normalizeRegistry("registry.example.com:5000"), "Domain is incorrect!")
+	assert.Equal(t, "registry.example.com", normalizeRegistry("http://registry.example.com/v2/"), "Domain is incorrect!")
+}
+
+func TestReadCredentialsFile(t *testing.T) {
+	file, _ := ioutil.TempFile("", "caravela_credentials")
+	defer os.Remove(file.Name())
+	file.WriteString(`{"auths": {"https://index.docker.io/v1/": {"auth": "dXNlcjpwYXNzOndvcmQ="},
+		"registry.example.com": {"username": "admin", "password": "secret"}}}`)
+	file.Close()
+
+	credentials, err := readCredentialsFile(file.Name())
+
+	assert.Nil(t, err, "Reading the credentials file should not fail!")
+	assert.Equal(t, "user", credentials["docker.io"].Username, "Username is incorrect!")
+	assert.Equal(t, "pass:word", credentials["docker.io"].Password, "Password is incorrect!")
+	assert.Equal(t, "admin", credentials["registry.example.com"].Username, "Username is incorrect!")
+	assert.Equal(t, "secret", credentials["registry.example.com"].Password, "Password is incorrect!")
+}
+
+func TestRegistryBackend_PullCredentials(t *testing.T) {
+	config := configuration.Default("127.0.0.1")
+	config.ImagesStorage.Registry.Credentials = []configuration.RegistryCredentials{
+		{Registry: "registry.example.com:5000", Username: "admin", Password: "secret"},
+	}
+	backend, _ := newRegistryBackend(config)
+	registryBackend := backend.(*RegistryBackend)
+
+	auth := registryBackend.pullCredentials("registry.example.com:5000/app:1.0", nil)
+	assert.NotNil(t, auth, "Configured credentials should be used!")
+	assert.Equal(t, "admin", auth.Username, "Username is incorrect!")
+
+	assert.Nil(t, registryBackend.pullCredentials("redis:alpine", nil), "Public images should be pulled anonymously!")
+
+	requestAuth := &caravelaTypes.RegistryAuth{Username: "user", Password: "pass"}
+	assert.Equal(t, requestAuth, registryBackend.pullCredentials("registry.example.com:5000/app:1.0", requestAuth),
+		"Request credentials should take precedence!")
+}
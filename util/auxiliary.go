@@ -1,6 +1,8 @@
 package util
 
 import (
+	"fmt"
+	"github.com/docker/distribution/reference"
 	"strconv"
 	"strings"
 )
@@ -11,3 +13,29 @@ func ObtainIpPort(hostname string) (string, int) {
 	nodePort, _ := strconv.Atoi(strings.Split(hostname, ":")[1])
 	return nodeIP, nodePort
 }
+
+// NormalizeImageKey converts an image key into its canonical short form i.e. redis -> redis:latest.
+// Image keys that are not valid references are returned unchanged.
+func NormalizeImageKey(imageKey string) string {
+	imageRef, err := reference.ParseNormalizedNamed(imageKey)
+	if err != nil {
+		return imageKey
+	}
+	return reference.FamiliarString(reference.TagNameOnly(imageRef))
+}
+
+// ParsePortRange parses a port range i.e. 1000-2000 (or a single port i.e. 22) returning its first and last port.
+func ParsePortRange(portRange string) (int, int, error) {
+	ports := strings.SplitN(strings.TrimSpace(portRange), "-", 2)
+	first, err := strconv.Atoi(strings.TrimSpace(ports[0]))
+	if err != nil || !IsValidPort(first) {
+		return 0, 0, fmt.Errorf("invalid port range: %s", portRange)
+	}
+	last := first
+	if len(ports) == 2 {
+		if last, err = strconv.Atoi(strings.TrimSpace(ports[1])); err != nil || !IsValidPort(last) || last < first {
+			return 0, 0, fmt.Errorf("invalid port range: %s", portRange)
+		}
+	}
+	return first, last, nil
+}
@@ -0,0 +1,73 @@
+/*
+Bloom package provides a compact bloom filter of strings, used to summarize a set of keys (e.g. images) in messages.
+*/
+package bloom
+
+import (
+	"hash/fnv"
+)
+
+// Number of hash functions used by all the filters (all the nodes must use the same).
+const numHashes = 3
+
+// Filter is a bloom filter of strings. It can have false positives but never false negatives.
+type Filter struct {
+	bits []byte
+}
+
+// NewFilter creates a new empty filter with the given size in bytes.
+func NewFilter(sizeBytes int) *Filter {
+	return &Filter{
+		bits: make([]byte, sizeBytes),
+	}
+}
+
+// NewFilterBytes creates a filter from its array of bytes representation.
+func NewFilterBytes(bits []byte) *Filter {
+	return &Filter{
+		bits: append([]byte(nil), bits...),
+	}
+}
+
+// Add adds a key to the filter.
+func (f *Filter) Add(key string) {
+	if len(f.bits) == 0 {
+		return
+	}
+	for _, bit := range f.positions(key) {
+		f.bits[bit/8] |= 1 << (bit % 8)
+	}
+}
+
+// Contains returns true if the key is (probably) in the filter and false if it is definitely not.
+func (f *Filter) Contains(key string) bool {
+	if len(f.bits) == 0 {
+		return false
+	}
+	for _, bit := range f.positions(key) {
+		if f.bits[bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Bytes returns the array of bytes representation of the filter.
+func (f *Filter) Bytes() []byte {
+	return append([]byte(nil), f.bits...)
+}
+
+// positions returns the bits of the filter that represent the key, using the double hashing technique.
+func (f *Filter) positions(key string) []uint64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(key))
+	sum := hash.Sum64()
+	hash1, hash2 := sum&0xffffffff, sum>>32
+
+	numBits := uint64(len(f.bits) * 8)
+	res := make([]uint64, numHashes)
+	for i := range res {
+		res[i] = (hash1 + uint64(i)*hash2) % numBits
+	}
+	return res
+}
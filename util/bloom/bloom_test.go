@@ -0,0 +1,35 @@
+package bloom
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFilter_Contains(t *testing.T) {
+	filter := NewFilter(128)
+
+	filter.Add("redis:alpine")
+	filter.Add("nginx:latest")
+
+	assert.True(t, filter.Contains("redis:alpine"), "Key should be in the filter!")
+	assert.True(t, filter.Contains("nginx:latest"), "Key should be in the filter!")
+	assert.False(t, filter.Contains("postgres:10"), "Key should not be in the filter!")
+}
+
+func TestFilter_Bytes(t *testing.T) {
+	filter := NewFilter(128)
+	filter.Add("redis:alpine")
+
+	filterCopy := NewFilterBytes(filter.Bytes())
+
+	assert.True(t, filterCopy.Contains("redis:alpine"), "Key should be in the filter copy!")
+	assert.Equal(t, filter.Bytes(), filterCopy.Bytes(), "Filter copy is incorrect!")
+}
+
+func TestFilter_Empty(t *testing.T) {
+	filter := NewFilterBytes(nil)
+
+	filter.Add("redis:alpine")
+
+	assert.False(t, filter.Contains("redis:alpine"), "Empty filter should not contain keys!")
+}
@@ -0,0 +1,24 @@
+package version
+
+const (
+	// ProtocolVersion is the version of the node-to-node protocol (messages format) implemented by the node.
+	// The nodes that predate the protocol versions implicitly run the version 1.
+	ProtocolVersion = 2
+	// MinProtocolVersion is the oldest version of the protocol, run by other nodes, that the node understands.
+	// Nodes running versions between MinProtocolVersion and ProtocolVersion can coexist (e.g. rolling upgrades).
+	// The version 1 nodes neither replicate nor hand over their offers, which the suppliers rely on to keep
+	// their offers when a trader fails or leaves.
+	MinProtocolVersion = 2
+)
+
+// Capabilities (optional messages) of the protocol that the node supports. The messages that need a capability are
+// not sent to the nodes known not to support it.
+const (
+	CapabilityOfferReplication = "offer-replication" // Replicate the trader's offers into its successors
+	CapabilityOfferHandOver    = "offer-handover"    // Hand over the trader's offers when it leaves
+)
+
+// Capabilities returns all the capabilities supported by the node.
+func Capabilities() []string {
+	return []string{CapabilityOfferReplication, CapabilityOfferHandOver}
+}